@@ -0,0 +1,206 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"spaceforce/safety"
+	"spaceforce/scanner"
+)
+
+// AppUsage is the space used by one application's sandbox containers
+type AppUsage struct {
+	AppName    string
+	BundleIDs  []string // Container directory names that resolved to this app
+	Size       int64
+	Containers []*scanner.FileNode // Largest first
+}
+
+// containerParents are the directory names whose children are per-app containers
+var containerParents = []string{
+	"Library/Containers",
+	"Library/Group Containers",
+}
+
+// teamIDPrefix matches the "ABCDE12345." developer team prefix on group container ids
+var teamIDPrefix = regexp.MustCompile(`^[A-Z0-9]{10}\.`)
+
+// AppNameResolver maps bundle identifiers to human-readable application names
+type AppNameResolver struct {
+	names   map[string]string // Bundle ID (lowercase) -> app name
+	loaded  bool
+	appDirs []string // Folders searched for *.app bundles
+}
+
+// NewAppNameResolver creates a resolver that reads Info.plist files from the usual app folders
+func NewAppNameResolver() *AppNameResolver {
+	appDirs := []string{"/Applications", "/System/Applications"}
+	if homeDir, err := safety.HomeDir(); err == nil {
+		appDirs = append(appDirs, filepath.Join(homeDir, "Applications"))
+	}
+	return &AppNameResolver{
+		names:   make(map[string]string),
+		appDirs: appDirs,
+	}
+}
+
+// AddApp registers a known bundle ID -> name mapping
+func (r *AppNameResolver) AddApp(bundleID string, name string) {
+	r.names[strings.ToLower(bundleID)] = name
+}
+
+// Resolve returns the app name for a container directory name
+// Falls back to a readable name derived from the bundle id when the app is not installed
+func (r *AppNameResolver) Resolve(containerID string) string {
+	r.load()
+
+	id := normalizeContainerID(containerID)
+	if name, ok := r.names[strings.ToLower(id)]; ok {
+		return name
+	}
+
+	// Group containers often use a prefix of the app's bundle id (e.g. group.com.vendor.app)
+	lower := strings.ToLower(id)
+	for bundleID, name := range r.names {
+		if strings.HasPrefix(bundleID, lower+".") {
+			return name
+		}
+	}
+
+	return humanizeBundleID(id)
+}
+
+// load reads CFBundleIdentifier/CFBundleName from installed apps (once)
+func (r *AppNameResolver) load() {
+	if r.loaded {
+		return
+	}
+	r.loaded = true
+
+	for _, dir := range r.appDirs {
+		apps, _ := filepath.Glob(filepath.Join(dir, "*.app"))
+		for _, app := range apps {
+			bundleID, name := readInfoPlist(filepath.Join(app, "Contents", "Info.plist"))
+			if bundleID == "" {
+				continue
+			}
+			if name == "" {
+				name = strings.TrimSuffix(filepath.Base(app), ".app")
+			}
+			if _, exists := r.names[strings.ToLower(bundleID)]; !exists {
+				r.AddApp(bundleID, name)
+			}
+		}
+	}
+}
+
+// readInfoPlist extracts the bundle id and display name from an XML Info.plist
+// Binary plists are skipped (the bundle id fallback still gives a usable name)
+func readInfoPlist(path string) (string, string) {
+	data, err := os.ReadFile(path)
+	if err != nil || strings.HasPrefix(string(data), "bplist") {
+		return "", ""
+	}
+
+	content := string(data)
+	name := plistString(content, "CFBundleDisplayName")
+	if name == "" {
+		name = plistString(content, "CFBundleName")
+	}
+	return plistString(content, "CFBundleIdentifier"), name
+}
+
+// plistString returns the <string> value following <key>key</key> in an XML plist
+func plistString(content string, key string) string {
+	pattern := regexp.MustCompile(`<key>` + regexp.QuoteMeta(key) + `</key>\s*<string>([^<]*)</string>`)
+	if match := pattern.FindStringSubmatch(content); match != nil {
+		return strings.TrimSpace(match[1])
+	}
+	return ""
+}
+
+// normalizeContainerID strips group/team prefixes from a container directory name
+func normalizeContainerID(id string) string {
+	id = teamIDPrefix.ReplaceAllString(id, "")
+	id = strings.TrimPrefix(id, "group.")
+	id = strings.TrimPrefix(id, "groups.")
+	return id
+}
+
+// humanizeBundleID turns "com.docker.docker" into "Docker" and "com.apple.Safari" into "Safari"
+func humanizeBundleID(id string) string {
+	parts := strings.Split(id, ".")
+	name := parts[len(parts)-1]
+
+	// Skip generic trailing components like "helper" or "shared" when there is something better
+	generic := map[string]bool{"helper": true, "shared": true, "agent": true, "extension": true, "group": true}
+	for i := len(parts) - 1; i > 0 && generic[strings.ToLower(name)]; i-- {
+		name = parts[i-1]
+	}
+
+	if name == "" {
+		return id
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// isContainerParent reports whether a directory holds per-app containers
+func isContainerParent(path string) bool {
+	for _, parent := range containerParents {
+		if strings.HasSuffix(path, "/"+parent) {
+			return true
+		}
+	}
+	return false
+}
+
+// GroupContainersByApp collects container directories under root and groups them by app name
+// Results are sorted by total size, largest first
+func GroupContainersByApp(root *scanner.FileNode, resolver *AppNameResolver) []*AppUsage {
+	byApp := make(map[string]*AppUsage)
+
+	var walk func(node *scanner.FileNode)
+	walk = func(node *scanner.FileNode) {
+		if !node.IsDir {
+			return
+		}
+		if isContainerParent(node.Path) {
+			for _, container := range node.Children {
+				if !container.IsDir {
+					continue
+				}
+				name := resolver.Resolve(container.Name)
+				usage, exists := byApp[name]
+				if !exists {
+					usage = &AppUsage{AppName: name}
+					byApp[name] = usage
+				}
+				usage.BundleIDs = append(usage.BundleIDs, container.Name)
+				usage.Containers = append(usage.Containers, container)
+				usage.Size += container.TotalSize()
+			}
+			return
+		}
+		for _, child := range node.Children {
+			walk(child)
+		}
+	}
+	walk(root)
+
+	apps := make([]*AppUsage, 0, len(byApp))
+	for _, usage := range byApp {
+		sort.Slice(usage.Containers, func(i, j int) bool {
+			return usage.Containers[i].TotalSize() > usage.Containers[j].TotalSize()
+		})
+		apps = append(apps, usage)
+	}
+
+	sort.Slice(apps, func(i, j int) bool {
+		return apps[i].Size > apps[j].Size
+	})
+
+	return apps
+}
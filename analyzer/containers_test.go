@@ -0,0 +1,66 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+)
+
+// testResolver returns a resolver that knows only the given apps, without reading installed ones
+func testResolver(apps map[string]string) *AppNameResolver {
+	resolver := NewAppNameResolver()
+	resolver.appDirs = nil
+	for bundleID, name := range apps {
+		resolver.AddApp(bundleID, name)
+	}
+	return resolver
+}
+
+func TestGroupContainersByApp(t *testing.T) {
+	tt := newTestTree(t)
+	now := time.Now()
+	tt.file("Library/Containers/com.docker.docker/Data/disk.raw", 5000, now)
+	tt.file("Library/Containers/com.docker.helper/Data/cache", 700, now)
+	tt.file("Library/Group Containers/ABCDE12345.group.com.docker/shared.db", 300, now)
+	tt.file("Library/Containers/com.apple.Safari/Data/history.db", 2000, now)
+	tt.file("Library/Containers/com.vendor.unknown.helper/Data/state", 100, now)
+	tt.file("Library/Containers/stray-file", 9000, now)
+	tt.file("Documents/com.docker.docker/not-a-container", 8000, now)
+
+	resolver := testResolver(map[string]string{
+		"com.docker.docker": "Docker",
+		"com.docker.helper": "Docker",
+		"com.apple.Safari":  "Safari",
+	})
+	apps := GroupContainersByApp(tt.root, resolver)
+
+	want := []struct {
+		name       string
+		size       int64
+		containers int
+	}{
+		{"Docker", 6000, 3},
+		{"Safari", 2000, 1},
+		{"Unknown", 100, 1},
+	}
+	if len(apps) != len(want) {
+		for _, app := range apps {
+			t.Logf("%s: %d bytes in %v", app.AppName, app.Size, app.BundleIDs)
+		}
+		t.Fatalf("got %d apps, want %d", len(apps), len(want))
+	}
+	for i, w := range want {
+		app := apps[i]
+		if app.AppName != w.name || app.Size != w.size || len(app.Containers) != w.containers {
+			t.Errorf("apps[%d] = %s, %d bytes, %d containers; want %s, %d bytes, %d containers",
+				i, app.AppName, app.Size, len(app.Containers), w.name, w.size, w.containers)
+		}
+		if len(app.BundleIDs) != len(app.Containers) {
+			t.Errorf("%s has %d bundle ids for %d containers", app.AppName, len(app.BundleIDs), len(app.Containers))
+		}
+		for j := 1; j < len(app.Containers); j++ {
+			if app.Containers[j].TotalSize() > app.Containers[j-1].TotalSize() {
+				t.Errorf("%s containers are not sorted largest first", app.AppName)
+			}
+		}
+	}
+}
@@ -0,0 +1,258 @@
+package analyzer
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"spaceforce/scanner"
+)
+
+const (
+	// DuplicateMinSize is the smallest file considered by FindDuplicates
+	DuplicateMinSize = 10 * 1024 * 1024
+
+	// sampleBlockSize is how much of each end of a file the quick hash reads
+	sampleBlockSize = 64 * 1024
+
+	// duplicateWorkers is how many files are hashed at once
+	duplicateWorkers = 4
+
+	// DuplicatesCategory is the category of the suggestion built by DuplicatesSuggestion
+	DuplicatesCategory = "Duplicates"
+)
+
+// DuplicateGroup is a set of files with identical content
+type DuplicateGroup struct {
+	Size  int64
+	Files []*scanner.FileNode // Sorted by path; the first is the copy to keep
+}
+
+// Reclaimable returns the bytes freed by keeping a single copy
+func (g *DuplicateGroup) Reclaimable() int64 {
+	return g.Size * int64(len(g.Files)-1)
+}
+
+// FindDuplicates groups files of at least minSize with identical content
+// Files sharing a size are compared by a quick hash of their first and last blocks,
+// and only files that still collide are hashed in full with SHA-256
+// Unreadable files are skipped. Returns ctx.Err() if cancelled
+func FindDuplicates(ctx context.Context, nodes []*scanner.FileNode, minSize int64) ([]*DuplicateGroup, error) {
+	bySize := make(map[int64][]*scanner.FileNode)
+	for _, node := range nodes {
+		if !node.IsDir && node.Size >= minSize {
+			bySize[node.Size] = append(bySize[node.Size], node)
+		}
+	}
+
+	candidates := make([]*scanner.FileNode, 0)
+	for _, files := range bySize {
+		if len(files) > 1 {
+			candidates = append(candidates, files...)
+		}
+	}
+
+	quick, err := hashFiles(ctx, candidates, quickHash)
+	if err != nil {
+		return nil, err
+	}
+	collisions := make([]*scanner.FileNode, 0)
+	for _, files := range groupByHash(candidates, quick) {
+		collisions = append(collisions, files...)
+	}
+
+	full, err := hashFiles(ctx, collisions, fullHash)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]*DuplicateGroup, 0)
+	for _, files := range groupByHash(collisions, full) {
+		sort.Slice(files, func(i, j int) bool {
+			return files[i].Path < files[j].Path
+		})
+		groups = append(groups, &DuplicateGroup{Size: files[0].Size, Files: files})
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Reclaimable() > groups[j].Reclaimable()
+	})
+	return groups, nil
+}
+
+// PruneDuplicates drops deleted files from the groups, and groups left with a single file
+func PruneDuplicates(groups []*DuplicateGroup, deleted []string) []*DuplicateGroup {
+	gone := make(map[string]bool, len(deleted))
+	for _, path := range deleted {
+		gone[path] = true
+	}
+
+	pruned := make([]*DuplicateGroup, 0, len(groups))
+	for _, group := range groups {
+		files := make([]*scanner.FileNode, 0, len(group.Files))
+		for _, file := range group.Files {
+			if !gone[file.Path] && !insideDeleted(file.Path, gone) {
+				files = append(files, file)
+			}
+		}
+		if len(files) > 1 {
+			pruned = append(pruned, &DuplicateGroup{Size: group.Size, Files: files})
+		}
+	}
+	return pruned
+}
+
+// insideDeleted reports whether path is under one of the deleted directories
+func insideDeleted(path string, gone map[string]bool) bool {
+	for dir := range gone {
+		if strings.HasPrefix(path, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// DuplicatesSuggestion turns duplicate groups into one suggestion listing the extra copies
+// Returns nil if there is nothing to reclaim
+func DuplicatesSuggestion(groups []*DuplicateGroup) *Suggestion {
+	var savings int64
+	extras := make([]*scanner.FileNode, 0)
+	for _, group := range groups {
+		savings += group.Reclaimable()
+		extras = append(extras, group.Files[1:]...)
+	}
+	if savings == 0 {
+		return nil
+	}
+
+	return &Suggestion{
+		Path:        "Multiple locations",
+		Description: fmt.Sprintf("Identical files in %d group(s)", len(groups)),
+		Reason:      "Same size and SHA-256 content - one copy of each is kept",
+		Savings:     savings,
+		RiskLevel:   1,
+		Category:    DuplicatesCategory,
+		Files:       extras,
+	}
+}
+
+// groupByHash groups files by the hash computed for them, keeping groups of two or more
+// Files are keyed by size too, so different-size files can never share a group
+func groupByHash(files []*scanner.FileNode, hashes map[*scanner.FileNode]string) map[string][]*scanner.FileNode {
+	groups := make(map[string][]*scanner.FileNode)
+	for _, file := range files {
+		hash, ok := hashes[file]
+		if !ok {
+			continue // Unreadable
+		}
+		key := fmt.Sprintf("%d:%s", file.Size, hash)
+		groups[key] = append(groups[key], file)
+	}
+	for key, group := range groups {
+		if len(group) < 2 {
+			delete(groups, key)
+		}
+	}
+	return groups
+}
+
+// hashFiles hashes files with a pool of workers
+// Files that cannot be hashed are left out of the result
+func hashFiles(ctx context.Context, files []*scanner.FileNode, hash func(context.Context, string) (string, error)) (map[*scanner.FileNode]string, error) {
+	jobs := make(chan *scanner.FileNode)
+	results := make(map[*scanner.FileNode]string, len(files))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for i := 0; i < duplicateWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for file := range jobs {
+				sum, err := hash(ctx, file.Path)
+				if err != nil {
+					continue
+				}
+				mu.Lock()
+				results[file] = sum
+				mu.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for _, file := range files {
+		select {
+		case jobs <- file:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// quickHash hashes the first and last sampleBlockSize bytes of a file
+func quickHash(ctx context.Context, path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	if _, err := io.CopyN(h, file, sampleBlockSize); err != nil && err != io.EOF {
+		return "", err
+	}
+	if info.Size() > 2*sampleBlockSize {
+		if _, err := file.Seek(-sampleBlockSize, io.SeekEnd); err != nil {
+			return "", err
+		}
+		if _, err := io.Copy(h, file); err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// fullHash hashes a whole file with SHA-256, stopping early if ctx is cancelled
+func fullHash(ctx context.Context, path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	h := sha256.New()
+	buf := make([]byte, 1024*1024)
+	for {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		n, err := file.Read(buf)
+		h.Write(buf[:n])
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
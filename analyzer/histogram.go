@@ -0,0 +1,78 @@
+package analyzer
+
+import (
+	"fmt"
+	"math"
+
+	"spaceforce/scanner"
+	"spaceforce/util"
+)
+
+// SizeBucket counts the files whose size falls in [Min, Max)
+type SizeBucket struct {
+	Label     string
+	Min       int64
+	Max       int64
+	Count     int64
+	TotalSize int64
+	Largest   *scanner.FileNode // Biggest file in the bucket (nil if empty)
+}
+
+// DefaultSizeBuckets returns the empty histogram buckets, smallest first
+// Boundaries and labels follow the current size unit mode (binary or decimal)
+func DefaultSizeBuckets() []*SizeBucket {
+	kb := util.UnitBase()
+	mb := kb * kb
+	gb := mb * kb
+	kbName, mbName, gbName := util.UnitName(0), util.UnitName(1), util.UnitName(2)
+	return []*SizeBucket{
+		{Label: "< 1 " + kbName, Min: 0, Max: kb},
+		{Label: fmt.Sprintf("1 %s - 1 %s", kbName, mbName), Min: kb, Max: mb},
+		{Label: fmt.Sprintf("1 %s - 100 %s", mbName, mbName), Min: mb, Max: 100 * mb},
+		{Label: fmt.Sprintf("100 %s - 1 %s", mbName, gbName), Min: 100 * mb, Max: gb},
+		{Label: "> 1 " + gbName, Min: gb, Max: math.MaxInt64},
+	}
+}
+
+// BuildSizeHistogram bins every file in nodes by size (directories are ignored)
+func BuildSizeHistogram(nodes []*scanner.FileNode) []*SizeBucket {
+	buckets := DefaultSizeBuckets()
+
+	for _, node := range nodes {
+		if node.IsDir {
+			continue
+		}
+		size := node.FileSize()
+		for _, bucket := range buckets {
+			if size >= bucket.Min && size < bucket.Max {
+				bucket.Count++
+				bucket.TotalSize += size
+				if bucket.Largest == nil || size > bucket.Largest.FileSize() {
+					bucket.Largest = node
+				}
+				break
+			}
+		}
+	}
+
+	return buckets
+}
+
+// RemoveFromHistogram takes deleted files back out of buckets built by BuildSizeHistogram
+// A bucket whose largest file was deleted is left without one, as finding the next
+// largest would mean walking every file again
+func RemoveFromHistogram(buckets []*SizeBucket, files []*scanner.FileNode) {
+	for _, node := range files {
+		size := node.FileSize()
+		for _, bucket := range buckets {
+			if size >= bucket.Min && size < bucket.Max {
+				bucket.Count--
+				bucket.TotalSize -= size
+				if bucket.Largest == node {
+					bucket.Largest = nil
+				}
+				break
+			}
+		}
+	}
+}
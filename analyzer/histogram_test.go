@@ -0,0 +1,76 @@
+package analyzer
+
+import (
+	"testing"
+	"time"
+
+	"spaceforce/scanner"
+	"spaceforce/util"
+)
+
+func histogramFiles(sizes ...int64) []*scanner.FileNode {
+	root := scanner.NewFileNode("/root", 0, true, time.Now())
+	nodes := []*scanner.FileNode{root}
+	for i, size := range sizes {
+		file := scanner.NewFileNode(root.Path+"/f"+string(rune('a'+i)), size, false, time.Now())
+		root.AddChild(file)
+		nodes = append(nodes, file)
+	}
+	return nodes
+}
+
+func TestBuildSizeHistogramBoundaries(t *testing.T) {
+	t.Cleanup(func() { util.SetSizeFormat(util.DefaultSizeFormat) })
+	for _, decimal := range []bool{false, true} {
+		util.SetSizeFormat(util.SizeFormat{Precision: util.PrecisionAuto, Decimal: decimal})
+		kb := util.UnitBase()
+		mb, gb := kb*kb, kb*kb*kb
+
+		// Each boundary value opens the next bucket; one byte less stays in the one below
+		nodes := histogramFiles(0, kb-1, kb, mb-1, mb, 100*mb-1, 100*mb, gb-1, gb, 5*gb)
+		buckets := BuildSizeHistogram(nodes)
+
+		want := []struct {
+			count   int64
+			total   int64
+			largest int64
+		}{
+			{2, kb - 1, kb - 1},
+			{2, kb + mb - 1, mb - 1},
+			{2, mb + 100*mb - 1, 100*mb - 1},
+			{2, 100*mb + gb - 1, gb - 1},
+			{2, 6 * gb, 5 * gb},
+		}
+		if len(buckets) != len(want) {
+			t.Fatalf("decimal=%v: got %d buckets, want %d", decimal, len(buckets), len(want))
+		}
+		for i, w := range want {
+			b := buckets[i]
+			if b.Count != w.count || b.TotalSize != w.total {
+				t.Errorf("decimal=%v: %s = %d files, %d bytes; want %d files, %d bytes",
+					decimal, b.Label, b.Count, b.TotalSize, w.count, w.total)
+			}
+			if b.Largest == nil || b.Largest.FileSize() != w.largest {
+				t.Errorf("decimal=%v: %s largest is wrong: %v", decimal, b.Label, b.Largest)
+			}
+		}
+
+		// Deleting files takes them back out of the same buckets
+		RemoveFromHistogram(buckets, nodes[1:3])
+		if buckets[0].Count != 0 || buckets[0].TotalSize != 0 || buckets[0].Largest != nil {
+			t.Errorf("decimal=%v: first bucket after removal = %d files, %d bytes", decimal, buckets[0].Count, buckets[0].TotalSize)
+		}
+	}
+}
+
+func TestBuildSizeHistogramIgnoresDirectories(t *testing.T) {
+	nodes := histogramFiles(10, 20)
+	var count, total int64
+	for _, b := range BuildSizeHistogram(nodes) {
+		count += b.Count
+		total += b.TotalSize
+	}
+	if count != 2 || total != 30 {
+		t.Errorf("histogram holds %d files, %d bytes; want 2 files, 30 bytes", count, total)
+	}
+}
@@ -1,7 +1,7 @@
 package analyzer
 
 import (
-	"os"
+	"fmt"
 	"path/filepath"
 	"sort"
 	"strings"
@@ -22,18 +22,95 @@ type Suggestion struct {
 	Files       []*scanner.FileNode
 }
 
+const (
+	// DefaultOldFileMonths is the default age cutoff for the old-files suggestion
+	DefaultOldFileMonths = 12
+
+	// MinOldFileMonths and MaxOldFileMonths bound the adjustable cutoff
+	MinOldFileMonths = 1
+	MaxOldFileMonths = 120
+)
+
+// reclaimableCategories are the suggestion categories counted by SafelyReclaimable:
+// content that apps and tools recreate, rather than the user's own files
+var reclaimableCategories = map[string]bool{
+	"Known Bloat": true,
+	"Caches":      true,
+	"Logs":        true,
+}
+
 // SuggestionEngine generates cleanup suggestions
 type SuggestionEngine struct {
-	protector *safety.Protector
-	root      *scanner.FileNode
+	protector     *safety.Protector
+	root          *scanner.FileNode
+	allNodes      []*scanner.FileNode // Flattened tree, passed in or built once and reused
+	safeCache     map[string]bool     // Path -> IsSafeToDelete result (avoids repeated stats)
+	oldFileMonths int                 // Age cutoff for the old-files suggestion
 }
 
 // NewSuggestionEngine creates a new suggestion engine
-func NewSuggestionEngine(root *scanner.FileNode) *SuggestionEngine {
+// nodes is the flattened tree if the caller already has it, or nil to flatten on first use
+func NewSuggestionEngine(root *scanner.FileNode, nodes []*scanner.FileNode) *SuggestionEngine {
 	return &SuggestionEngine{
-		protector: safety.NewProtector(),
-		root:      root,
+		protector:     safety.NewProtector(),
+		root:          root,
+		allNodes:      nodes,
+		safeCache:     make(map[string]bool),
+		oldFileMonths: DefaultOldFileMonths,
+	}
+}
+
+// SetOldFileMonths sets the age cutoff (in months) for the old-files suggestion
+func (se *SuggestionEngine) SetOldFileMonths(months int) {
+	if months < MinOldFileMonths {
+		months = MinOldFileMonths
+	}
+	if months > MaxOldFileMonths {
+		months = MaxOldFileMonths
+	}
+	se.oldFileMonths = months
+}
+
+// OldFileMonths returns the current age cutoff for the old-files suggestion
+func (se *SuggestionEngine) OldFileMonths() int {
+	return se.oldFileMonths
+}
+
+// OldFilesSuggestion re-derives the old-files suggestion for the current cutoff
+// Uses the already-flattened tree, so it is cheap enough to call on every adjustment
+func (se *SuggestionEngine) OldFilesSuggestion() *Suggestion {
+	if found := se.findOldFiles(); len(found) > 0 {
+		return found[0]
+	}
+	return nil
+}
+
+// flattened returns the flattened tree, building it on first use
+func (se *SuggestionEngine) flattened() []*scanner.FileNode {
+	if se.allNodes == nil {
+		se.allNodes = scanner.FlattenTree(se.root)
 	}
+	return se.allNodes
+}
+
+// Nodes returns the flattened tree the engine works from
+func (se *SuggestionEngine) Nodes() []*scanner.FileNode {
+	return se.flattened()
+}
+
+// SetNodes replaces the flattened tree, e.g. once deleted nodes are dropped from it
+func (se *SuggestionEngine) SetNodes(nodes []*scanner.FileNode) {
+	se.allNodes = nodes
+}
+
+// isSafe caches protector.IsSafeToDelete results by path
+func (se *SuggestionEngine) isSafe(path string) bool {
+	if safe, ok := se.safeCache[path]; ok {
+		return safe
+	}
+	safe, _ := se.protector.IsSafeToDelete(path)
+	se.safeCache[path] = safe
+	return safe
 }
 
 // GenerateSuggestions analyzes the filesystem and generates cleanup suggestions
@@ -52,9 +129,6 @@ func (se *SuggestionEngine) GenerateSuggestions() []*Suggestion {
 	// Find old log files
 	suggestions = append(suggestions, se.findOldLogs()...)
 
-	// Find duplicate large files (simplified - just by size)
-	suggestions = append(suggestions, se.findDuplicateSizes()...)
-
 	// Development-specific suggestions
 	suggestions = append(suggestions, se.findDevelopmentBloat()...)
 
@@ -66,17 +140,130 @@ func (se *SuggestionEngine) GenerateSuggestions() []*Suggestion {
 	return suggestions
 }
 
+// SafelyReclaimable estimates the bytes in suggestions that can go without a second thought:
+// no-risk caches, logs and known bloat whose paths are safe to delete
+// Items listed by several suggestions, or inside another listed directory, are counted once
+func (se *SuggestionEngine) SafelyReclaimable(suggestions []*Suggestion) int64 {
+	items := make([]*scanner.FileNode, 0)
+	for _, suggestion := range suggestions {
+		if reclaimableCategories[suggestion.Category] && suggestion.RiskLevel == 0 {
+			items = append(items, suggestion.Files...)
+		}
+	}
+
+	var total int64
+	for _, item := range se.outermostSafe(items) {
+		total += item.TotalSize()
+	}
+	return total
+}
+
+// SafeCleanupItems returns what the one-step safe cleanup deletes, largest first: the items
+// of no-risk cache, log and known-bloat suggestions that lie inside a known no-risk bloat
+// location, carry no deletion risk themselves and are safe to delete
+// Suggestions found by name alone (build/, dist/, any "Cache" folder) are left out, since
+// such a folder can be the user's own. Items listed by several suggestions, or inside
+// another returned directory, appear once
+func (se *SuggestionEngine) SafeCleanupItems(suggestions []*Suggestion) []*scanner.FileNode {
+	roots := safeCleanupRoots()
+	items := make([]*scanner.FileNode, 0)
+	for _, suggestion := range suggestions {
+		if !reclaimableCategories[suggestion.Category] || suggestion.RiskLevel != 0 {
+			continue
+		}
+		for _, item := range suggestion.Files {
+			if insideAny(item.Path, roots) && se.protector.GetRiskLevel(item.Path) == 0 {
+				items = append(items, item)
+			}
+		}
+	}
+
+	items = se.outermostSafe(items)
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].TotalSize() > items[j].TotalSize()
+	})
+	return items
+}
+
+// outermostSafe returns the items that are safe to delete, dropping duplicates and items
+// inside another kept directory, in path order
+func (se *SuggestionEngine) outermostSafe(items []*scanner.FileNode) []*scanner.FileNode {
+	// Sorting by path puts each directory before its contents
+	sorted := make([]*scanner.FileNode, len(items))
+	copy(sorted, items)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Path < sorted[j].Path
+	})
+
+	kept := make([]*scanner.FileNode, 0, len(sorted))
+	counted := make(map[string]bool)
+	for _, item := range sorted {
+		if counted[item.Path] || insideCounted(item.Path, counted) || !se.isSafe(item.Path) {
+			continue
+		}
+		counted[item.Path] = true
+		kept = append(kept, item)
+	}
+	return kept
+}
+
+// safeCleanupRoots returns the expanded no-risk bloat locations the safe cleanup may
+// delete from, leaving out those marked ReviewFirst
+// Home-relative locations are skipped when the home directory is unknown
+func safeCleanupRoots() []string {
+	homeDir, homeErr := safety.HomeDir()
+	roots := make([]string, 0)
+	for _, location := range safety.GetCommonBloatLocations() {
+		if location.RiskLevel != 0 || location.ReviewFirst {
+			continue
+		}
+		path := location.Path
+		if strings.HasPrefix(path, "~") {
+			if homeErr != nil {
+				continue
+			}
+			path = strings.Replace(path, "~", homeDir, 1)
+		}
+		roots = append(roots, path)
+	}
+	return roots
+}
+
+// insideAny reports whether path is one of roots or beneath one of them
+func insideAny(path string, roots []string) bool {
+	for _, root := range roots {
+		if path == root || strings.HasPrefix(path, root+string(filepath.Separator)) {
+			return true
+		}
+	}
+	return false
+}
+
+// insideCounted reports whether path is beneath one of the counted directories
+func insideCounted(path string, counted map[string]bool) bool {
+	for dir := filepath.Dir(path); dir != filepath.Dir(dir); dir = filepath.Dir(dir) {
+		if counted[dir] {
+			return true
+		}
+	}
+	return false
+}
+
 // checkBloatLocations checks known bloat locations
 func (se *SuggestionEngine) checkBloatLocations() []*Suggestion {
 	suggestions := make([]*Suggestion, 0)
 	bloatLocations := safety.GetCommonBloatLocations()
 
-	homeDir, _ := os.UserHomeDir()
+	homeDir, homeErr := safety.HomeDir()
 
 	for _, location := range bloatLocations {
 		// Expand ~ to home directory
 		path := location.Path
 		if strings.HasPrefix(path, "~") {
+			if homeErr != nil {
+				// Without a home, "~/Library/Caches" would become "/Library/Caches"
+				continue
+			}
 			path = strings.Replace(path, "~", homeDir, 1)
 		}
 
@@ -110,20 +297,43 @@ func (se *SuggestionEngine) checkBloatLocations() []*Suggestion {
 	return suggestions
 }
 
+// MatchOldFiles returns files last modified before cutoff and larger than minSize
+func MatchOldFiles(nodes []*scanner.FileNode, cutoff time.Time, minSize int64) []*scanner.FileNode {
+	matches := make([]*scanner.FileNode, 0)
+	for _, node := range nodes {
+		if !node.IsDir && node.ModTime.Before(cutoff) && node.Size > minSize {
+			matches = append(matches, node)
+		}
+	}
+	return matches
+}
+
+// describeAge formats a month count as "N months" or "N year(s)"
+func describeAge(months int) string {
+	if months%12 == 0 {
+		years := months / 12
+		if years == 1 {
+			return "1 year"
+		}
+		return fmt.Sprintf("%d years", years)
+	}
+	if months == 1 {
+		return "1 month"
+	}
+	return fmt.Sprintf("%d months", months)
+}
+
 // findOldFiles finds files that haven't been modified in a long time
 func (se *SuggestionEngine) findOldFiles() []*Suggestion {
-	cutoffDate := time.Now().Add(-365 * 24 * time.Hour) // 1 year ago
+	cutoffDate := time.Now().AddDate(0, -se.oldFileMonths, 0)
 	oldFiles := make([]*scanner.FileNode, 0)
 	totalSize := int64(0)
 
-	allFiles := scanner.FlattenTree(se.root)
-	for _, file := range allFiles {
-		if !file.IsDir && file.ModTime.Before(cutoffDate) && file.Size > 10*1024*1024 {
-			// Check if safe to delete
-			if safe, _ := se.protector.IsSafeToDelete(file.Path); safe {
-				oldFiles = append(oldFiles, file)
-				totalSize += file.Size
-			}
+	for _, file := range MatchOldFiles(se.flattened(), cutoffDate, 10*1024*1024) {
+		// Check if safe to delete
+		if se.isSafe(file.Path) {
+			oldFiles = append(oldFiles, file)
+			totalSize += file.FileSize()
 		}
 	}
 
@@ -131,7 +341,7 @@ func (se *SuggestionEngine) findOldFiles() []*Suggestion {
 		return []*Suggestion{
 			{
 				Path:        "Multiple locations",
-				Description: "Files not modified in over 1 year",
+				Description: "Files not modified in over " + describeAge(se.oldFileMonths),
 				Reason:      "Old files may no longer be needed",
 				Savings:     totalSize,
 				RiskLevel:   1,
@@ -147,7 +357,7 @@ func (se *SuggestionEngine) findOldFiles() []*Suggestion {
 // findLargeCaches finds large cache directories
 func (se *SuggestionEngine) findLargeCaches() []*Suggestion {
 	suggestions := make([]*Suggestion, 0)
-	allFiles := scanner.FlattenTree(se.root)
+	allFiles := se.flattened()
 
 	cacheNodes := make([]*scanner.FileNode, 0)
 	for _, file := range allFiles {
@@ -179,66 +389,96 @@ func (se *SuggestionEngine) findLargeCaches() []*Suggestion {
 	return suggestions
 }
 
-// findOldLogs finds old log files
-func (se *SuggestionEngine) findOldLogs() []*Suggestion {
-	cutoffDate := time.Now().Add(-90 * 24 * time.Hour) // 3 months ago
-	logFiles := make([]*scanner.FileNode, 0)
-	totalSize := int64(0)
+// logGroupMinSize is the smallest reclaimable size worth a per-app log suggestion
+const logGroupMinSize = 10 * 1024 * 1024
 
-	allFiles := scanner.FlattenTree(se.root)
-	for _, file := range allFiles {
-		if !file.IsDir && se.protector.IsLogFile(file.Path) && file.ModTime.Before(cutoffDate) {
-			logFiles = append(logFiles, file)
-			totalSize += file.Size
+// LogGroup is a set of old log files belonging to one application or directory
+type LogGroup struct {
+	Name   string              // App name (the folder under Library/Logs) or directory name
+	Dir    string              // Directory the group is rooted at
+	Files  []*scanner.FileNode // Old logs that can be removed (newest excluded)
+	Newest *scanner.FileNode   // Most recent log in the group, kept for context
+	Size   int64               // Total size of Files
+}
+
+// logGroupDir returns the directory a log file is grouped under
+// Logs inside Library/Logs/<App>/... belong to <App>; any other log belongs to its own directory
+func logGroupDir(path string) string {
+	parts := strings.Split(path, string(filepath.Separator))
+	for i := 0; i+2 < len(parts); i++ {
+		if parts[i] == "Library" && parts[i+1] == "Logs" {
+			if i+3 < len(parts) {
+				return strings.Join(parts[:i+3], string(filepath.Separator))
+			}
+			// A log directly in Library/Logs
+			return strings.Join(parts[:i+2], string(filepath.Separator))
 		}
 	}
+	return filepath.Dir(path)
+}
 
-	if len(logFiles) > 0 && totalSize > 100*1024*1024 {
-		return []*Suggestion{
-			{
-				Path:        "Multiple locations",
-				Description: "Old log files (>3 months)",
-				Reason:      "Old logs are rarely needed",
-				Savings:     totalSize,
-				RiskLevel:   0,
-				Category:    "Logs",
-				Files:       logFiles,
-			},
+// GroupLogsByApp groups log files by owning app/directory, keeping the newest log of each group
+// Groups are sorted by reclaimable size, largest first
+func GroupLogsByApp(logs []*scanner.FileNode) []*LogGroup {
+	byDir := make(map[string][]*scanner.FileNode)
+	for _, log := range logs {
+		dir := logGroupDir(log.Path)
+		byDir[dir] = append(byDir[dir], log)
+	}
+
+	groups := make([]*LogGroup, 0, len(byDir))
+	for dir, files := range byDir {
+		sort.Slice(files, func(i, j int) bool {
+			return files[i].ModTime.After(files[j].ModTime)
+		})
+
+		group := &LogGroup{
+			Name:   filepath.Base(dir),
+			Dir:    dir,
+			Newest: files[0],
+			Files:  files[1:],
+		}
+		for _, file := range group.Files {
+			group.Size += file.FileSize()
 		}
+		groups = append(groups, group)
 	}
 
-	return nil
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Size != groups[j].Size {
+			return groups[i].Size > groups[j].Size
+		}
+		return groups[i].Dir < groups[j].Dir
+	})
+	return groups
 }
 
-// findDuplicateSizes finds files with the same size (potential duplicates)
-func (se *SuggestionEngine) findDuplicateSizes() []*Suggestion {
-	allFiles := scanner.FlattenTree(se.root)
-	sizeMap := make(map[int64][]*scanner.FileNode)
+// findOldLogs finds old log files, one suggestion per owning app/directory
+func (se *SuggestionEngine) findOldLogs() []*Suggestion {
+	cutoffDate := time.Now().Add(-90 * 24 * time.Hour) // 3 months ago
+	logFiles := make([]*scanner.FileNode, 0)
 
-	// Group files by size
+	allFiles := se.flattened()
 	for _, file := range allFiles {
-		if !file.IsDir && file.Size > 10*1024*1024 { // Only large files
-			sizeMap[file.Size] = append(sizeMap[file.Size], file)
+		if !file.IsDir && se.protector.IsLogFile(file.Path) && file.ModTime.Before(cutoffDate) {
+			logFiles = append(logFiles, file)
 		}
 	}
 
 	suggestions := make([]*Suggestion, 0)
-	for size, files := range sizeMap {
-		if len(files) > 1 {
-			// Potential duplicates
-			totalWaste := size * int64(len(files)-1)
-			if totalWaste > 100*1024*1024 {
-				suggestions = append(suggestions, &Suggestion{
-					Path:        "Multiple locations",
-					Description: "Files with identical sizes (potential duplicates)",
-					Reason:      "These files might be duplicates - review before deleting",
-					Savings:     totalWaste,
-					RiskLevel:   2,
-					Category:    "Potential Duplicates",
-					Files:       files,
-				})
-			}
+	for _, group := range GroupLogsByApp(logFiles) {
+		if group.Size < logGroupMinSize {
+			continue
 		}
+		suggestions = append(suggestions, &Suggestion{
+			Path:        group.Dir,
+			Description: fmt.Sprintf("Old logs: %s (%d files, >3 months)", group.Name, len(group.Files)),
+			Reason:      fmt.Sprintf("Old logs are rarely needed; the newest (%s) is kept", group.Newest.Name),
+			Savings:     group.Size,
+			RiskLevel:   0,
+			Category:    "Logs",
+			Files:       group.Files,
+		})
 	}
 
 	return suggestions
@@ -247,7 +487,7 @@ func (se *SuggestionEngine) findDuplicateSizes() []*Suggestion {
 // findDevelopmentBloat finds development-related bloat
 func (se *SuggestionEngine) findDevelopmentBloat() []*Suggestion {
 	suggestions := make([]*Suggestion, 0)
-	allFiles := scanner.FlattenTree(se.root)
+	allFiles := se.flattened()
 
 	devPaths := map[string]string{
 		"node_modules":  "NPM package dependencies",
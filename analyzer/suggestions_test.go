@@ -0,0 +1,255 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"spaceforce/scanner"
+)
+
+// testTree builds a scanner tree under a temporary home directory
+// Directories are created on disk, since the safety checks stat them; file sizes are only recorded
+type testTree struct {
+	t    *testing.T
+	root *scanner.FileNode
+}
+
+func newTestTree(t *testing.T) *testTree {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	return &testTree{t: t, root: scanner.NewFileNode(home, 0, true, time.Now())}
+}
+
+// dir returns the directory at rel (relative to the home directory), creating it as needed
+func (tt *testTree) dir(rel string) *scanner.FileNode {
+	node := tt.root
+	for _, name := range splitPath(rel) {
+		var next *scanner.FileNode
+		for _, child := range node.Children {
+			if child.Name == name {
+				next = child
+			}
+		}
+		if next == nil {
+			next = scanner.NewFileNode(filepath.Join(node.Path, name), 0, true, time.Now())
+			if err := os.MkdirAll(next.Path, 0o755); err != nil {
+				tt.t.Fatal(err)
+			}
+			node.AddChild(next)
+		}
+		node = next
+	}
+	return node
+}
+
+// file adds a file of size bytes at rel, modified at modTime
+func (tt *testTree) file(rel string, size int64, modTime time.Time) *scanner.FileNode {
+	parent := tt.dir(filepath.Dir(rel))
+	node := scanner.NewFileNode(filepath.Join(parent.Path, filepath.Base(rel)), size, false, modTime)
+	if err := os.WriteFile(node.Path, nil, 0o644); err != nil {
+		tt.t.Fatal(err)
+	}
+	parent.AddChild(node)
+	return node
+}
+
+func splitPath(rel string) []string {
+	if rel == "." || rel == "" {
+		return nil
+	}
+	return append(splitPath(filepath.Dir(rel)), filepath.Base(rel))
+}
+
+func TestSafeCleanupItemsLeavesUserFoldersAlone(t *testing.T) {
+	tt := newTestTree(t)
+	const big = 200 * 1024 * 1024
+	now := time.Now()
+	tt.file(".cache/pip/wheels.bin", big, now)
+	tt.file("project/dist/release.dmg", big, now)
+	tt.file("project/build/app.bin", big, now)
+	tt.file("project/target/release/app", big, now)
+	tt.file("project/Cache/notes.db", big, now)
+	tt.file("Library/Developer/CoreSimulator/Devices/data.img", big, now)
+
+	engine := NewSuggestionEngine(tt.root, nil)
+	suggestions := engine.GenerateSuggestions()
+	home := tt.root.Path
+
+	// The user folders are suggested (by name), so the cleanup has to filter them out
+	distDir := filepath.Join(home, "project", "dist")
+	suggested := false
+	for _, suggestion := range suggestions {
+		if suggestion.Path == distDir && suggestion.RiskLevel == 0 {
+			suggested = true
+		}
+	}
+	if !suggested {
+		t.Fatalf("expected a no-risk suggestion for %s", distDir)
+	}
+
+	var got []string
+	for _, item := range engine.SafeCleanupItems(suggestions) {
+		rel, _ := filepath.Rel(home, item.Path)
+		got = append(got, rel)
+		if strings.HasPrefix(rel, "project") || strings.HasPrefix(rel, filepath.Join("Library", "Developer")) {
+			t.Errorf("safe cleanup returned %s", rel)
+		}
+	}
+	if len(got) != 1 || got[0] != ".cache" {
+		t.Errorf("safe cleanup items = %v, want [.cache]", got)
+	}
+}
+
+func TestMatchOldFilesFollowsCutoff(t *testing.T) {
+	now := time.Now()
+	nodes := []*scanner.FileNode{
+		scanner.NewFileNode("/data/two-months.bin", 50<<20, false, now.AddDate(0, -2, 0)),
+		scanner.NewFileNode("/data/eight-months.bin", 50<<20, false, now.AddDate(0, -8, 0)),
+		scanner.NewFileNode("/data/three-years.bin", 50<<20, false, now.AddDate(-3, 0, 0)),
+		scanner.NewFileNode("/data/three-years-small.bin", 1<<20, false, now.AddDate(-3, 0, 0)),
+		scanner.NewFileNode("/data/three-years-dir", 50<<20, true, now.AddDate(-3, 0, 0)),
+	}
+
+	tests := []struct {
+		months int
+		want   []string
+	}{
+		{1, []string{"two-months.bin", "eight-months.bin", "three-years.bin"}},
+		{6, []string{"eight-months.bin", "three-years.bin"}},
+		{12, []string{"three-years.bin"}},
+		{48, nil},
+	}
+	for _, tt := range tests {
+		matches := MatchOldFiles(nodes, now.AddDate(0, -tt.months, 0), 10<<20)
+		var got []string
+		for _, match := range matches {
+			got = append(got, match.Name)
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("%d months: matched %v, want %v", tt.months, got, tt.want)
+		}
+	}
+}
+
+func TestSetOldFileMonthsClamps(t *testing.T) {
+	engine := NewSuggestionEngine(scanner.NewFileNode("/", 0, true, time.Now()), nil)
+	if got := engine.OldFileMonths(); got != DefaultOldFileMonths {
+		t.Errorf("default OldFileMonths() = %d, want %d", got, DefaultOldFileMonths)
+	}
+
+	tests := []struct {
+		set, want int
+	}{
+		{-5, MinOldFileMonths},
+		{0, MinOldFileMonths},
+		{1, 1},
+		{18, 18},
+		{120, 120},
+		{121, MaxOldFileMonths},
+		{1000, MaxOldFileMonths},
+	}
+	for _, tt := range tests {
+		engine.SetOldFileMonths(tt.set)
+		if got := engine.OldFileMonths(); got != tt.want {
+			t.Errorf("SetOldFileMonths(%d): OldFileMonths() = %d, want %d", tt.set, got, tt.want)
+		}
+	}
+}
+
+func TestOldFilesSuggestionFollowsThreshold(t *testing.T) {
+	tt := newTestTree(t)
+	now := time.Now()
+	tt.file("Documents/recent.mov", 50<<20, now.AddDate(0, -3, 0))
+	tt.file("Documents/old.mov", 50<<20, now.AddDate(-2, 0, 0))
+
+	engine := NewSuggestionEngine(tt.root, nil)
+	counts := map[int]int{}
+	for _, months := range []int{1, 12, 36} {
+		engine.SetOldFileMonths(months)
+		if suggestion := engine.OldFilesSuggestion(); suggestion != nil {
+			counts[months] = len(suggestion.Files)
+		}
+	}
+	if counts[1] != 2 || counts[12] != 1 || counts[36] != 0 {
+		t.Errorf("old files matched per threshold = %v, want 1:2, 12:1, 36:0", counts)
+	}
+}
+
+func TestGroupLogsByApp(t *testing.T) {
+	tt := newTestTree(t)
+	now := time.Now()
+	days := func(n int) time.Time { return now.AddDate(0, 0, -n) }
+	logs := []*scanner.FileNode{
+		tt.file("Library/Logs/Docker/com.docker.log", 30<<20, days(200)),
+		tt.file("Library/Logs/Docker/backend/old.log", 20<<20, days(150)),
+		tt.file("Library/Logs/Docker/newest.log", 5<<20, days(100)),
+		tt.file("Library/Logs/Zoom/zoom.log", 8<<20, days(120)),
+		tt.file("Library/Logs/Zoom/zoom.1.log", 1<<20, days(300)),
+		tt.file("Library/Logs/stray.log", 2<<20, days(400)),
+		tt.file("project/logs/server.log", 1<<20, days(100)),
+	}
+	home := tt.root.Path
+
+	groups := GroupLogsByApp(logs)
+
+	want := []struct {
+		name, dir, newest string
+		files             int
+		size              int64
+	}{
+		{"Docker", "Library/Logs/Docker", "newest.log", 2, 50 << 20},
+		{"Zoom", "Library/Logs/Zoom", "zoom.log", 1, 1 << 20},
+		{"Logs", "Library/Logs", "stray.log", 0, 0},
+		{"logs", "project/logs", "server.log", 0, 0},
+	}
+	if len(groups) != len(want) {
+		for _, g := range groups {
+			t.Logf("%s (%s): %d files", g.Name, g.Dir, len(g.Files))
+		}
+		t.Fatalf("got %d groups, want %d", len(groups), len(want))
+	}
+	for i, w := range want {
+		g := groups[i]
+		if g.Name != w.name || g.Dir != filepath.Join(home, w.dir) || g.Newest.Name != w.newest ||
+			len(g.Files) != w.files || g.Size != w.size {
+			t.Errorf("group %d = %s in %s, newest %s, %d files, %d bytes; want %s in %s, newest %s, %d files, %d bytes",
+				i, g.Name, g.Dir, g.Newest.Name, len(g.Files), g.Size, w.name, w.dir, w.newest, w.files, w.size)
+		}
+		for _, file := range g.Files {
+			if file == g.Newest {
+				t.Errorf("%s lists its newest log for removal", g.Name)
+			}
+		}
+	}
+}
+
+func TestOldLogSuggestionsKeepNewest(t *testing.T) {
+	tt := newTestTree(t)
+	old := time.Now().AddDate(0, -6, 0)
+	tt.file("Library/Logs/Docker/a.log", 40<<20, old.AddDate(0, 0, -2))
+	tt.file("Library/Logs/Docker/b.log", 40<<20, old)
+	tt.file("Library/Logs/Tiny/a.log", 1<<20, old.AddDate(0, 0, -2))
+	tt.file("Library/Logs/Tiny/b.log", 1<<20, old)
+
+	engine := NewSuggestionEngine(tt.root, nil)
+	var logs []*Suggestion
+	for _, suggestion := range engine.GenerateSuggestions() {
+		if suggestion.Category == "Logs" {
+			logs = append(logs, suggestion)
+		}
+	}
+
+	// Tiny's one removable log is under the per-app minimum
+	if len(logs) != 1 {
+		t.Fatalf("got %d log suggestions, want 1", len(logs))
+	}
+	if len(logs[0].Files) != 1 || logs[0].Files[0].Name != "a.log" || logs[0].Savings != 40<<20 {
+		t.Errorf("Docker suggestion removes %d files for %d bytes", len(logs[0].Files), logs[0].Savings)
+	}
+	if !strings.Contains(logs[0].Reason, "b.log") {
+		t.Errorf("reason %q does not name the kept log", logs[0].Reason)
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"spaceforce/scanner"
+	"spaceforce/util"
+)
+
+const (
+	// exitOverBudget is returned by -fail-over when the scanned total exceeds the budget
+	// Distinct from 1 so CI can tell "too big" apart from "scan failed"
+	exitOverBudget = 2
+
+	// maxOffendersShown limits how many contributing paths are printed
+	maxOffendersShown = 10
+)
+
+// exceedsBudget reports whether a scanned total is over the allowed budget
+func exceedsBudget(total int64, budget int64) bool {
+	return total > budget
+}
+
+// largestChildren returns the children of node sorted by total size (largest first)
+func largestChildren(node *scanner.FileNode, limit int) []*scanner.FileNode {
+	children := make([]*scanner.FileNode, len(node.Children))
+	copy(children, node.Children)
+
+	sort.Slice(children, func(i, j int) bool {
+		return children[i].TotalSize() > children[j].TotalSize()
+	})
+
+	if len(children) > limit {
+		children = children[:limit]
+	}
+	return children
+}
+
+// runBudgetCheck scans rootPath without the TUI and returns the process exit code
+// 0 = within budget, 1 = scan error, 2 = over budget
+func runBudgetCheck(rootPath string, budget int64, opts scanOptions) int {
+	scn := opts.newScanner()
+
+	root, err := scn.Scan(context.Background(), rootPath, nil)
+	if err != nil {
+		fmt.Printf("Error: scan failed: %v\n", err)
+		return 1
+	}
+
+	if opts.skipManifest != "" {
+		if err := writeSkipManifest(opts.skipManifest, scn.GetSkippedPaths()); err != nil {
+			fmt.Printf("Warning: cannot write skip manifest: %v\n", err)
+		}
+	}
+
+	total := root.TotalSize()
+	if !exceedsBudget(total, budget) {
+		fmt.Printf("OK: %s is %s (budget %s)\n",
+			root.Path, util.FormatBytesPlain(total), util.FormatBytesPlain(budget))
+		return 0
+	}
+
+	fmt.Printf("FAIL: %s is %s, over budget of %s by %s\n",
+		root.Path,
+		util.FormatBytesPlain(total),
+		util.FormatBytesPlain(budget),
+		util.FormatBytesPlain(total-budget))
+
+	// Show which paths contribute most to the overage
+	offenders := largestChildren(root, maxOffendersShown)
+	if len(offenders) > 0 {
+		fmt.Println("\nLargest contributors:")
+		for _, child := range offenders {
+			fmt.Printf("  %10s  %s\n", util.FormatBytesPlain(child.TotalSize()), child.Path)
+		}
+	}
+
+	return exitOverBudget
+}
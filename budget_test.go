@@ -0,0 +1,57 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestExceedsBudget(t *testing.T) {
+	tests := []struct {
+		total, budget int64
+		want          bool
+	}{
+		{999, 1000, false},
+		{1000, 1000, false},
+		{1001, 1000, true},
+		{0, 0, false},
+		{1, 0, true},
+	}
+	for _, tt := range tests {
+		if got := exceedsBudget(tt.total, tt.budget); got != tt.want {
+			t.Errorf("exceedsBudget(%d, %d) = %v, want %v", tt.total, tt.budget, got, tt.want)
+		}
+	}
+}
+
+func TestRunBudgetCheckExitCode(t *testing.T) {
+	dir := t.TempDir()
+	for name, size := range map[string]int{"a.bin": 6000, "sub/b.bin": 4000} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, make([]byte, size), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The tree holds 10000 bytes
+	tests := []struct {
+		budget int64
+		want   int
+	}{
+		{9999, exitOverBudget},
+		{10000, 0},
+		{10001, 0},
+	}
+	for _, tt := range tests {
+		if got := runBudgetCheck(dir, tt.budget, scanOptions{}); got != tt.want {
+			t.Errorf("runBudgetCheck with budget %d = %d, want %d", tt.budget, got, tt.want)
+		}
+	}
+
+	if got := runBudgetCheck(filepath.Join(dir, "missing"), 10000, scanOptions{}); got != 1 {
+		t.Errorf("runBudgetCheck on a missing path = %d, want 1", got)
+	}
+}
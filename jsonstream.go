@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"spaceforce/scanner"
+)
+
+// streamEvent is one line of -json-stream output
+// Progress lines have Type "progress"; the last line has Type "summary"
+// (with TotalSize) or "error" (with Error)
+type streamEvent struct {
+	Type         string `json:"type"`
+	Path         string `json:"path"`
+	FilesScanned int64  `json:"files_scanned"`
+	DirsScanned  int64  `json:"dirs_scanned"`
+	BytesScanned int64  `json:"bytes_scanned"`
+	TotalBytes   int64  `json:"total_bytes,omitempty"` // Estimated total, 0 if unknown
+	Errors       int    `json:"errors"`
+	ElapsedMs    int64  `json:"elapsed_ms"`
+	TotalSize    int64  `json:"total_size,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// progressEvent converts a scanner progress update to a stream event
+func progressEvent(eventType string, progress scanner.ScanProgress, start time.Time) streamEvent {
+	return streamEvent{
+		Type:         eventType,
+		Path:         progress.CurrentPath,
+		FilesScanned: progress.FilesScanned,
+		DirsScanned:  progress.DirsScanned,
+		BytesScanned: progress.BytesScanned,
+		TotalBytes:   progress.TotalBytes,
+		Errors:       len(progress.Errors),
+		ElapsedMs:    time.Since(start).Milliseconds(),
+	}
+}
+
+// runJSONStream scans rootPath without the TUI, writing newline-delimited JSON
+// progress objects to stdout as the scan runs, then a final summary object
+// Returns the process exit code: 0 = scan finished, 1 = scan error
+func runJSONStream(rootPath string, opts scanOptions) int {
+	scn := opts.newScanner()
+	encoder := json.NewEncoder(os.Stdout)
+
+	// Scan closes progressChan when it returns; encode updates until then
+	start := time.Now()
+	progressChan := make(chan scanner.ScanProgress, 100)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for progress := range progressChan {
+			encoder.Encode(progressEvent("progress", progress, start))
+		}
+	}()
+
+	root, err := scn.Scan(context.Background(), rootPath, progressChan)
+	<-done
+	if err != nil {
+		event := progressEvent("error", scn.GetProgress(), start)
+		event.Path = rootPath
+		event.Error = err.Error()
+		encoder.Encode(event)
+		return 1
+	}
+
+	if opts.skipManifest != "" {
+		if err := writeSkipManifest(opts.skipManifest, scn.GetSkippedPaths()); err != nil {
+			// stdout carries only JSON, so warnings go to stderr
+			fmt.Fprintf(os.Stderr, "Warning: cannot write skip manifest: %v\n", err)
+		}
+	}
+
+	summary := progressEvent("summary", scn.GetProgress(), start)
+	summary.Path = root.Path
+	summary.TotalSize = root.TotalSize()
+	encoder.Encode(summary)
+	return 0
+}
@@ -5,25 +5,222 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"spaceforce/safety"
 	"spaceforce/scanner"
 	"spaceforce/ui"
+	"spaceforce/ui/views"
+	"spaceforce/util"
 )
 
 var (
 	version = "1.0.0"
 )
 
+// userExcludeFile is the optional list of exclusion globs, relative to the config dir
+const userExcludeFile = "exclude.txt"
+
+// stringList is a repeatable string flag (e.g. -exclude a -exclude b)
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// scanOptions holds the scanner settings chosen on the command line
+type scanOptions struct {
+	networkMode   safety.NetworkMode
+	oneFilesystem bool
+	exclusions    []string
+	gitignore     bool
+	hardlinks     bool
+	maxDepth      int
+	minFileSize   int64
+	dropTiny      bool
+	symlinks      bool
+	skipHidden    bool
+	bundleAsFile  bool
+	lowMemory     bool
+	dirSize       bool   // Directories count their own size on top of their contents
+	skipManifest  string // File to write the list of skipped paths to ("" = none)
+}
+
+// newScanner creates a scanner configured with these options
+func (o scanOptions) newScanner() *scanner.Scanner {
+	scn := scanner.NewScanner()
+	scn.SetNetworkMode(o.networkMode)
+	scn.SetOneFilesystem(o.oneFilesystem)
+	scn.SetExclusions(o.exclusions)
+	scn.SetRespectGitignore(o.gitignore)
+	scn.SetCountHardlinks(o.hardlinks)
+	scn.SetMaxDepth(o.maxDepth)
+	scn.SetMinFileSize(o.minFileSize)
+	scn.SetDropTinyNodes(o.dropTiny)
+	scn.SetFollowSymlinks(o.symlinks)
+	scn.SetSkipHidden(o.skipHidden)
+	scn.SetBundleAsFile(o.bundleAsFile)
+	scn.SetLowMemory(o.lowMemory)
+	scn.SetIncludeDirSize(o.dirSize)
+	return scn
+}
+
+// tuiOptions holds the interactive interface settings chosen on the command line
+type tuiOptions struct {
+	linkPath             string                 // Symlink given as -path, shown instead of its target ("" = none)
+	markFile             string                 // -mark-from file the paths were read from
+	markPaths            []string               // Paths to mark once the scan completes (nil = none)
+	exportTarget         string                 // Where to write the marked paths on exit ("" = nowhere)
+	exportNul            bool                   // Export NUL-delimited instead of shell-quoted
+	cdFile               string                 // File for the 'c' cd command ("" = clipboard)
+	verifyDeletes        bool                   // Re-check deleted paths afterwards
+	singleConfirmCleanup bool                   // No-risk cache and log items need only one confirmation
+	permanent            bool                   // Delete permanently instead of moving to the Trash
+	freeGoal             int64                  // Bytes the user wants to free (0 = no goal)
+	typedConfirmSize     int64                  // Batches at least this big need DELETE typed (0 = never)
+	typedConfirmRisk     int                    // Batches at this risk level or above need DELETE typed (0 = never)
+	timelineCutoffs      []views.TimelineCutoff // -timeline-buckets boundaries (nil = built-in buckets)
+	maxTreeChildren      int                    // Children of one directory the tree shows before folding (0 = all)
+}
+
+// configure applies these options to a new model
+func (o tuiOptions) configure(model *ui.Model) {
+	model.SetLinkPath(o.linkPath)
+	if o.markPaths != nil {
+		model.SetMarkFrom(o.markFile, o.markPaths)
+	}
+	model.SetExportTarget(o.exportTarget, o.exportNul)
+	model.SetCdFile(o.cdFile)
+	model.SetVerifyDeletes(o.verifyDeletes)
+	model.SetSingleConfirmCleanup(o.singleConfirmCleanup)
+	model.SetTypedConfirm(o.typedConfirmSize, o.typedConfirmRisk)
+	if o.permanent {
+		model.SetDeleteMethod(safety.DeletePermanent)
+	}
+	model.SetFreeGoal(o.freeGoal)
+	model.SetTimelineCutoffs(o.timelineCutoffs)
+	model.SetMaxTreeChildren(o.maxTreeChildren)
+}
+
+// runMode is what SpaceForce does once the flags are parsed
+type runMode int
+
+const (
+	modeTUI         runMode = iota // Interactive Bubble Tea interface (the default)
+	modeUsersReport                // -users: per-user home directory sizes
+	modeBudgetCheck                // -fail-over: CI disk-budget check
+	modeTextReport                 // -report: plain-text summary on stdout
+	modeJSONStream                 // -json-stream: NDJSON progress on stdout
+)
+
+// modeFlag ties a non-interactive mode to the flag that selects it
+type modeFlag struct {
+	name string
+	mode runMode
+	set  bool
+}
+
+// tuiOnlyFlags only affect the interactive interface
+var tuiOnlyFlags = map[string]bool{
+	"export-marked": true, "export-nul": true, "mark-from": true, "cd-file": true,
+	"timeline-buckets": true, "verify-deletes": true, "single-confirm-caches": true,
+	"typed-confirm-over": true, "typed-confirm-risk": true, "permanent": true,
+	"target": true, "tree-max-children": true,
+}
+
+// reportOnlyFlags only affect the -report output
+var reportOnlyFlags = map[string]bool{
+	"top": true,
+}
+
+// chooseRunMode picks the run mode from the non-interactive flags
+// Non-interactive modes never start the TUI, so selecting more than one of them
+// (or combining one with a TUI-only flag) is an error rather than a silent choice.
+// Report-only flags are likewise an error in any mode but -report
+func chooseRunMode(modes []modeFlag, tuiOnly []string, reportOnly []string) (runMode, error) {
+	chosen := make([]modeFlag, 0, 1)
+	for _, m := range modes {
+		if m.set {
+			chosen = append(chosen, m)
+		}
+	}
+
+	switch len(chosen) {
+	case 0:
+		if len(reportOnly) > 0 {
+			return modeTUI, fmt.Errorf("-%s only applies to -report", reportOnly[0])
+		}
+		return modeTUI, nil
+	case 1:
+		if len(tuiOnly) > 0 {
+			return modeTUI, fmt.Errorf("-%s only applies to the interactive interface and cannot be used with -%s",
+				tuiOnly[0], chosen[0].name)
+		}
+		if len(reportOnly) > 0 && chosen[0].mode != modeTextReport {
+			return modeTUI, fmt.Errorf("-%s only applies to -report and cannot be used with -%s",
+				reportOnly[0], chosen[0].name)
+		}
+		return chosen[0].mode, nil
+	default:
+		names := make([]string, len(chosen))
+		for i, m := range chosen {
+			names[i] = "-" + m.name
+		}
+		return modeTUI, fmt.Errorf("%s cannot be combined; choose one output mode", strings.Join(names, " and "))
+	}
+}
+
 func main() {
 	// Parse command-line flags
 	var (
 		scanPath      = flag.String("path", ".", "Path to scan")
 		skipNetwork   = flag.Bool("skip-network", true, "Skip network volumes (default: true)")
+		flagNetwork   = flag.Bool("flag-network", false, "Scan network volumes and cloud folders but mark them as remote (overrides -skip-network)")
 		oneFilesystem = flag.Bool("one-filesystem", true, "Stay on one filesystem (like du -x)")
+		usersReport   = flag.Bool("users", false, "Report the size of each user's home directory under /Users (read-only)")
+		failOver      = flag.String("fail-over", "", "Scan without the TUI and exit non-zero if the total exceeds this size (e.g. 500MB, 2G)")
+		textReport    = flag.Bool("report", false, "Scan without the TUI and print a plain-text summary")
+		jsonStream    = flag.Bool("json-stream", false, "Scan without the TUI and stream newline-delimited JSON progress to stdout")
+		gitignore     = flag.Bool("respect-gitignore", false, "Skip entries ignored by .gitignore files")
+		hardlinks     = flag.Bool("count-hardlinks", false, "Count every hard link to a file at full size")
+		symlinks      = flag.Bool("follow-symlinks", false, "Follow symlinks and scan the directories they point to")
+		skipHidden    = flag.Bool("skip-hidden", false, "Leave files and folders whose name starts with '.' out of the scan")
+		bundleAsFile  = flag.Bool("bundle-as-file", false, "Show .app, .framework and .bundle directories as single items")
+		dirSize       = flag.Bool("include-dir-size", false, "Count each directory's own size (its entry table) on top of its contents")
+		lowMemory     = flag.Bool("low-memory", false, "Keep only directories and the largest files in memory, for volumes with millions of files")
+		maxDepth      = flag.Int("max-depth", 0, "Maximum directory depth to scan (0 = unlimited)")
+		minSize       = flag.String("min-size", "", "Leave files smaller than this out of the tree (e.g. 50M)")
+		dropTiny      = flag.Bool("drop-tiny-nodes", false, "With -min-size, still count omitted files in their directory's totals")
+		exportMarked  = flag.String("export-marked", "", "On exit, write marked paths to this file ('-' = stdout)")
+		exportNul     = flag.Bool("export-nul", false, "Export marked paths NUL-delimited (for xargs -0) instead of shell-quoted")
+		markFrom      = flag.String("mark-from", "", "After the scan, mark the paths listed in this file (a W manifest or an exported list)")
+		cdFile        = flag.String("cd-file", "", "Write the 'c' cd command to this file instead of the clipboard")
+		verifyDeletes = flag.Bool("verify-deletes", false, "After deleting, re-check each path is gone and report items left behind")
+		quickCleanup  = flag.Bool("single-confirm-caches", false, "Need only one confirmation for no-risk cache and log items in sensitive locations")
+		typedOver     = flag.String("typed-confirm-over", "", "Require typing DELETE to delete batches of at least this size (e.g. 50G)")
+		typedRisk     = flag.Int("typed-confirm-risk", 3, "Require typing DELETE when a batch has an item at this risk level or above (1-3, 0 = never)")
+		permanent     = flag.Bool("permanent", false, "Delete marked items permanently instead of moving them to the Trash")
+		freeTarget    = flag.String("target", "", "Space you want to free (e.g. 20G); the marked readout shows progress toward it")
+		skipManifest  = flag.String("skip-manifest", "", "Write every skipped path and the reason to this file (.json for JSON, '-' = stdout)")
+		timeline      = flag.String("timeline-buckets", "", "Custom timeline cutoffs, e.g. 7d,30d,180d,2y")
+		topFiles      = flag.Int("top", 0, "With -report, also list this many of the largest files (0 = leave the list out)")
+		treeChildren  = flag.Int("tree-max-children", views.DefaultMaxTreeChildren, "Show only this many of a directory's largest children in the tree, folding the rest into an expandable row (0 = show all)")
+		precision     = flag.Int("precision", util.PrecisionAuto, "Decimal places for sizes (0-2, default: automatic)")
+		siUnits       = flag.Bool("si", false, "Show sizes in decimal units (1 GB = 1000^3 bytes, like Finder) instead of binary GiB")
+		sizeColors    = flag.String("size-colors", "", "Sizes where size colors turn medium and large, e.g. 10M,1G (default: 1M,100M)")
 		showVersion   = flag.Bool("version", false, "Show version")
 		showHelp      = flag.Bool("help", false, "Show help")
+		excludes      stringList
 	)
+	flag.Var(&excludes, "exclude", "Glob pattern of paths to skip (repeatable, e.g. **/node_modules)")
 
 	flag.Parse()
 
@@ -37,8 +234,77 @@ func main() {
 		os.Exit(0)
 	}
 
+	if *precision != util.PrecisionAuto && (*precision < 0 || *precision > 2) {
+		fmt.Println("Error: -precision must be between 0 and 2")
+		os.Exit(1)
+	}
+	sizeFormat := util.DefaultSizeFormat
+	sizeFormat.Precision = *precision
+	sizeFormat.Decimal = *siUnits
+	if *sizeColors != "" {
+		medium, large, err := util.ParseSizeThresholds(*sizeColors)
+		if err != nil {
+			fmt.Printf("Error: invalid -size-colors value: %v\n", err)
+			os.Exit(1)
+		}
+		sizeFormat.Medium, sizeFormat.Large = medium, large
+	}
+	util.SetSizeFormat(sizeFormat)
+
+	// Work out whether to start the TUI before doing anything else
+	var tuiOnly, reportOnly []string
+	flag.Visit(func(f *flag.Flag) {
+		if tuiOnlyFlags[f.Name] {
+			tuiOnly = append(tuiOnly, f.Name)
+		}
+		if reportOnlyFlags[f.Name] {
+			reportOnly = append(reportOnly, f.Name)
+		}
+	})
+	mode, err := chooseRunMode([]modeFlag{
+		{name: "users", mode: modeUsersReport, set: *usersReport},
+		{name: "fail-over", mode: modeBudgetCheck, set: *failOver != ""},
+		{name: "report", mode: modeTextReport, set: *textReport},
+		{name: "json-stream", mode: modeJSONStream, set: *jsonStream},
+	}, tuiOnly, reportOnly)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	var timelineCutoffs []views.TimelineCutoff
+	if *timeline != "" {
+		timelineCutoffs, err = views.ParseTimelineCutoffs(*timeline)
+		if err != nil {
+			fmt.Printf("Error: invalid -timeline-buckets value: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if *topFiles < 0 {
+		fmt.Println("Error: -top cannot be negative")
+		os.Exit(1)
+	}
+	if *treeChildren < 0 {
+		fmt.Println("Error: -tree-max-children cannot be negative")
+		os.Exit(1)
+	}
+
+	// The users report scans /Users unless a path was given explicitly
+	if mode == modeUsersReport {
+		pathSet := false
+		flag.Visit(func(f *flag.Flag) {
+			if f.Name == "path" {
+				pathSet = true
+			}
+		})
+		if !pathSet {
+			*scanPath = defaultUsersDir
+		}
+	}
+
 	// Safety check: prevent running as root
-	if os.Getuid() == 0 {
+	// The users report never deletes anything, so it may run under sudo to read other homes
+	if os.Getuid() == 0 && mode != modeUsersReport {
 		fmt.Println("╔════════════════════════════════════════════════════════════════════╗")
 		fmt.Println("║                           ⚠️  WARNING ⚠️                            ║")
 		fmt.Println("║                                                                    ║")
@@ -68,21 +334,151 @@ func main() {
 		os.Exit(1)
 	}
 
-	if !info.IsDir() {
+	// A symlink is resolved, so the scan (and any deletion) works on what it points to
+	linkPath := ""
+	if linkInfo, err := os.Lstat(*scanPath); err == nil && linkInfo.Mode()&os.ModeSymlink != 0 {
+		target, err := filepath.EvalSymlinks(*scanPath)
+		if err != nil {
+			fmt.Printf("Error: cannot resolve symlink '%s': %v\n", *scanPath, err)
+			os.Exit(1)
+		}
+		linkPath = *scanPath
+		*scanPath = target
+	}
+
+	// A single file gets a detail view in the TUI; only the users report needs a directory
+	if mode == modeUsersReport && !info.IsDir() {
 		fmt.Printf("Error: '%s' is not a directory\n", *scanPath)
 		os.Exit(1)
 	}
 
+	if *maxDepth < 0 {
+		fmt.Println("Error: -max-depth cannot be negative")
+		os.Exit(1)
+	}
+
+	minFileSize := int64(0)
+	if *minSize != "" {
+		minFileSize, err = util.ParseSize(*minSize)
+		if err != nil {
+			fmt.Printf("Error: invalid -min-size value: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if *dropTiny && minFileSize == 0 {
+		fmt.Println("Error: -drop-tiny-nodes requires -min-size")
+		os.Exit(1)
+	}
+
+	// Exclusions come from the config file (if the home directory is known) plus any -exclude flags
+	var exclusions []string
+	if configDir := safety.ConfigDir(); configDir != "" {
+		exclusions, err = safety.ReadPatternFile(filepath.Join(configDir, userExcludeFile))
+		if err != nil {
+			fmt.Printf("Warning: cannot read exclusions file: %v\n", err)
+		}
+	}
+	networkMode := safety.NetworkScan
+	if *flagNetwork {
+		networkMode = safety.NetworkFlag
+	} else if *skipNetwork {
+		networkMode = safety.NetworkSkip
+	}
+	opts := scanOptions{
+		networkMode:   networkMode,
+		oneFilesystem: *oneFilesystem,
+		exclusions:    append(exclusions, excludes...),
+		gitignore:     *gitignore,
+		hardlinks:     *hardlinks,
+		maxDepth:      *maxDepth,
+		minFileSize:   minFileSize,
+		dropTiny:      *dropTiny,
+		symlinks:      *symlinks,
+		skipHidden:    *skipHidden,
+		bundleAsFile:  *bundleAsFile,
+		lowMemory:     *lowMemory,
+		dirSize:       *dirSize,
+		skipManifest:  *skipManifest,
+	}
+
+	// Non-interactive modes print their result and exit without starting the TUI
+	switch mode {
+	case modeUsersReport:
+		os.Exit(runUsersReport(*scanPath, opts))
+	case modeBudgetCheck:
+		budget, err := util.ParseSize(*failOver)
+		if err != nil {
+			fmt.Printf("Error: invalid -fail-over value: %v\n", err)
+			os.Exit(1)
+		}
+		os.Exit(runBudgetCheck(*scanPath, budget, opts))
+	case modeTextReport:
+		os.Exit(runReport(*scanPath, *topFiles, opts))
+	case modeJSONStream:
+		os.Exit(runJSONStream(*scanPath, opts))
+	}
+
+	freeGoal := int64(0)
+	if *freeTarget != "" {
+		freeGoal, err = util.ParseSize(*freeTarget)
+		if err != nil {
+			fmt.Printf("Error: invalid -target value: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	typedConfirmSize := int64(0)
+	if *typedOver != "" {
+		typedConfirmSize, err = util.ParseSize(*typedOver)
+		if err != nil {
+			fmt.Printf("Error: invalid -typed-confirm-over value: %v\n", err)
+			os.Exit(1)
+		}
+	}
+	if *typedRisk < 0 || *typedRisk > 3 {
+		fmt.Println("Error: -typed-confirm-risk must be between 0 and 3")
+		os.Exit(1)
+	}
+
+	var markPaths []string
+	if *markFrom != "" {
+		markPaths, err = util.ReadPathListFile(*markFrom)
+		if err != nil {
+			fmt.Printf("Error: cannot read -mark-from file: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
 	// Start the TUI
-	if err := runTUI(*scanPath, *skipNetwork, *oneFilesystem); err != nil {
+	tui := tuiOptions{
+		linkPath:             linkPath,
+		markFile:             *markFrom,
+		markPaths:            markPaths,
+		exportTarget:         *exportMarked,
+		exportNul:            *exportNul,
+		cdFile:               *cdFile,
+		verifyDeletes:        *verifyDeletes,
+		singleConfirmCleanup: *quickCleanup,
+		permanent:            *permanent,
+		freeGoal:             freeGoal,
+		typedConfirmSize:     typedConfirmSize,
+		typedConfirmRisk:     *typedRisk,
+		timelineCutoffs:      timelineCutoffs,
+		maxTreeChildren:      *treeChildren,
+	}
+	if err := runTUI(*scanPath, opts, tui); err != nil {
 		fmt.Printf("Error running application: %v\n", err)
 		os.Exit(1)
 	}
 }
 
-func runTUI(rootPath string, skipNetwork bool, oneFilesystem bool) error {
-	// Create the main model
+func runTUI(rootPath string, opts scanOptions, tui tuiOptions) error {
+	// Create the main model and the scanner it can pause
 	model := ui.NewModel(rootPath)
+	tui.configure(model)
+	scn := opts.newScanner()
+	model.SetScanner(scn)
+	model.SetScannerFactory(opts.newScanner)
 
 	// Create the Bubble Tea program
 	p := tea.NewProgram(model, tea.WithAltScreen())
@@ -103,9 +499,7 @@ func runTUI(rootPath string, skipNetwork bool, oneFilesystem bool) error {
 		}()
 
 		// Start the scan
-		scn := scanner.NewScanner()
-		scn.SetSkipNetwork(skipNetwork)
-		scn.SetOneFilesystem(oneFilesystem)
+		start := time.Now()
 		root, err := scn.Scan(ctx, rootPath, progressChan)
 
 		// Send completion message
@@ -113,6 +507,8 @@ func runTUI(rootPath string, skipNetwork bool, oneFilesystem bool) error {
 			Root:           root,
 			Err:            err,
 			SkippedVolumes: scn.GetSkippedVolumes(),
+			Progress:       scn.GetProgress(),
+			Elapsed:        time.Since(start),
 		})
 	}()
 
@@ -122,11 +518,48 @@ func runTUI(rootPath string, skipNetwork bool, oneFilesystem bool) error {
 	// Cancel the scan when the program exits (user pressed 'q')
 	cancel()
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	if opts.skipManifest != "" {
+		if err := writeSkipManifest(opts.skipManifest, scn.GetSkippedPaths()); err != nil {
+			return fmt.Errorf("cannot write skip manifest: %w", err)
+		}
+	}
+
+	// Hand the marked set to another tool (written after the TUI releases stdout)
+	if tui.exportTarget != "" {
+		if paths := model.MarkedPaths(); len(paths) > 0 {
+			if err := util.ExportPathList(tui.exportTarget, paths, tui.exportNul); err != nil {
+				return fmt.Errorf("cannot export marked files: %w", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeSkipManifest saves what the scan did not descend into and why
+// The format is JSON when the file name ends in .json, otherwise tab-separated text
+func writeSkipManifest(path string, skipped []scanner.SkippedPath) error {
+	if path == "-" {
+		return scanner.WriteSkipManifest(os.Stdout, skipped, false)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := scanner.WriteSkipManifest(file, skipped, strings.HasSuffix(path, ".json")); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
 }
 
 func printHelp() {
-	fmt.Println(`SpaceForce - Disk Space Analyzer for macOS
+	fmt.Print(`SpaceForce - Disk Space Analyzer for macOS
 
 A beautiful TUI application to help you find and clean up large files.
 
@@ -135,15 +568,151 @@ Usage:
 
 Options:
   -path string
-        Path to scan (default: current directory)
+        Path to scan (default: current directory). A single file opens a
+        detail view (size, type, age, safety) where it can be marked and
+        deleted; a symlink is resolved and its target scanned
   -skip-network
         Skip network volumes and cloud storage during scan (default: true)
         Skips: network drives, iCloud Drive, Dropbox, Google Drive, etc.
         Use -skip-network=false to include these directories
+  -flag-network
+        Scan network volumes and cloud storage, but mark everything on them
+        with 🌐 in the tree and "(net)" in Top Items, so slow or remote
+        items stand out. Overrides -skip-network. Network mounts are
+        separate filesystems, so also pass -one-filesystem=false
   -one-filesystem
         Stay on one filesystem, don't cross mount points (default: true)
         Like 'du -x', prevents scanning external drives and mounted volumes
         Use -one-filesystem=false to scan across all mounted filesystems
+  -exclude pattern
+        Skip paths matching a glob pattern (repeatable)
+        Patterns without a leading / match the end of a path:
+        -exclude node_modules -exclude '*/Caches' -exclude '**/build'
+        Additional patterns are read from ~/.config/spaceforce/exclude.txt
+  -respect-gitignore
+        Skip files and directories ignored by .gitignore files found while
+        scanning (e.g. node_modules, target, dist in project folders)
+  -count-hardlinks
+        Count every hard link to a file at full size. By default a file
+        with several hard links is only counted once (true on-disk usage)
+  -follow-symlinks
+        Follow symlinks: report the size of what they point to and scan
+        symlinked directories. Cycles and targets already scanned are skipped.
+        Symlinks are shown with 🔗 and their target either way
+  -skip-hidden
+        Leave files and folders whose name starts with '.' out of the scan
+        (the path given is always scanned, even if it is hidden)
+  -bundle-as-file
+        Show .app, .framework and .bundle directories as single items sized
+        by their contents, instead of folders with thousands of files
+  -max-depth n
+        Only scan n levels below the path (default: 0 = unlimited)
+        Directories at the limit are shown but cannot be expanded
+  -min-size size
+        Leave files smaller than size out of the tree (e.g. 50M). Directory
+        sizes then only include the files that were kept
+  -drop-tiny-nodes
+        With -min-size, add the omitted files' bytes to their directory
+        instead of discarding them. Totals stay correct while millions of
+        tiny files use no memory
+  -low-memory
+        For volumes with tens of millions of files: keep only directories
+        and the 10,000 largest files in memory. Other files are added to
+        their directory's totals and the type breakdown as they are found,
+        but cannot be listed or selected individually
+  -include-dir-size
+        Add each directory's own size (the directory file that holds its
+        entries) to its total. Usually small, but folders with huge numbers
+        of entries stand out, and totals match du more closely
+  -export-marked file
+        When quitting, write the paths marked with 'm' to file, one
+        shell-quoted path per line ('-' writes to stdout). Press 'e' in the
+        TUI to export immediately (to spaceforce-marked.txt if file is '-'):
+        spaceforce -export-marked - | xargs rm
+  -export-nul
+        Write exported paths NUL-delimited, for use with xargs -0:
+        spaceforce -export-marked - -export-nul | xargs -0 rm
+  -mark-from file
+        After the scan, mark every path listed in file that is still in the
+        tree, and report how many were not found. Reads manifests saved
+        with W, exported lists (shell-quoted or NUL-delimited) and plain
+        one-path-per-line text
+  -cd-file file
+        Make 'c' write "cd '<dir>'" to file instead of copying it to the
+        clipboard, so a shell function can source it after SpaceForce exits:
+        sf() { spaceforce -cd-file /tmp/sf-cd "$@" && . /tmp/sf-cd; }
+  -verify-deletes
+        After a deletion batch, check that every deleted path is really
+        gone. Items still on disk are listed in the summary and not
+        counted in the space reclaimed
+  -single-confirm-caches
+        Skip the second confirmation for items in sensitive locations
+        (like ~/Library) when they are no-risk caches or log files. Any
+        other sensitive item in the batch still needs Y twice
+  -typed-confirm-over size
+        Batches of at least size (e.g. 50G) must be confirmed by typing
+        DELETE and pressing Enter instead of pressing Y (default: off)
+  -typed-confirm-risk n
+        Batches with an item at risk level n or above (1 = low risk,
+        2 = review, 3 = protected) must be confirmed by typing DELETE
+        (default: 3; 0 turns this off)
+  -permanent
+        Delete marked items permanently instead of moving them to the
+        Trash. Space is freed at once, but nothing can be recovered
+  -target size
+        How much space you want to free (e.g. 20G). The marked readout in
+        the help bar shows progress toward it ("Marked: 3 item(s), 12 GiB /
+        20 GiB target") and turns green once enough is marked
+  -skip-manifest file
+        Write every path the scan did not descend into, with its reason
+        (network-volume, cloud-storage, user-exclusion, gitignore, alias,
+        filesystem-boundary, depth-limit, user-skipped). JSON if file ends
+        in .json, otherwise tab-separated text; '-' writes text to stdout
+  -timeline-buckets list
+        Comma-separated age cutoffs for the timeline view, replacing the
+        built-in periods (e.g. 7d,30d,180d,2y). Units: h, d, w, m (30 days),
+        y (365 days). Files older than the last cutoff get their own bucket
+  -tree-max-children n
+        Show only the n largest children of a directory in the tree; the
+        rest are folded into a "… and M more (X total)" row that Enter
+        expands, so folders like node_modules stay quick to browse
+        (default: 1000; 0 shows every child)
+  -precision n
+        Decimal places shown for sizes, 0-2 (default: 1 below 10, else 0)
+  -si
+        Show sizes in decimal units (KB, MB, GB = powers of 1000) to match
+        Finder, instead of binary units (KiB, MiB, GiB = powers of 1024)
+        that match du. Size arguments like -min-size are always binary
+  -size-colors medium,large
+        Where size colors change from small to medium and to large (default:
+        1 MB and 100 MB). Raise them where everything is big, e.g. 50M,2G
+        on a photo library
+  -users
+        Report each user's home directory size under /Users (or -path)
+        without the TUI. Homes that cannot be fully read are flagged, with
+        a hint to re-run under sudo. This read-only mode may run as root
+  -fail-over size
+        Scan without the TUI and exit with code 2 if the total size exceeds
+        the given budget (e.g. 500MB, 2G). Prints the largest contributors.
+        Intended for CI disk-budget checks
+  -report
+        Scan without the TUI and print a plain-text report: the total size,
+        the 20 largest items, a breakdown by category and the number of
+        scan errors. For scripts and SSH sessions
+  -top n
+        With -report, also list the n largest files, e.g. -top 1000 for a
+        big media library. Only valid with -report
+  -json-stream
+        Scan without the TUI and write one JSON object per line to stdout:
+        progress objects (path, files, dirs, bytes, errors) during the scan,
+        then a summary with the total size and elapsed time. For GUIs and
+        other tools wrapping SpaceForce
+
+  -users, -fail-over, -report and -json-stream never start the TUI. Only one may be given, and
+  TUI-only flags (-export-marked, -export-nul, -mark-from, -cd-file,
+  -timeline-buckets, -verify-deletes, -single-confirm-caches,
+  -typed-confirm-over, -typed-confirm-risk, -permanent, -target,
+  -tree-max-children) are rejected alongside them
   -version
         Show version information
   -help
@@ -151,20 +720,60 @@ Options:
 
 Controls:
   Tab         Switch between views
-  1-5         Jump to specific view
+  1-8         Jump to specific view
+  p           Pause/resume the scan (while scanning)
+  s           Skip the folder the scan is stuck on (while scanning); it is
+              listed under S and in -skip-manifest as user-skipped
+  R           Rescan the path (picks up changes made outside SpaceForce)
+  S           List every skipped volume and path with the reason it was
+              skipped (scroll with ↑/↓, PgUp/PgDn; Esc closes)
+  c           Copy "cd '<dir>'" for the selected item's directory
+  O           Open the selected file with its default app (directories are
+              revealed in the Finder)
+  D           Delete the selected no-risk cache folder after one y/N
+              (anything else is marked for the usual x review)
+  C           Safe cleanup: mark the no-risk caches, logs and known bloat in
+              well-known locations and move them all to the Trash after one Y
+  P           Mark items matching a pattern (glob or path substring)
+  >           List the files larger than a typed size (e.g. 1G) in Top Items
+  a           Toggle apparent size (ls -l) vs allocated size (du)
+  A           Color file names by age in Tree and Top Items (green = recent,
+              gray = old; bands follow the timeline buckets)
+  e           Export marked paths (see -export-marked)
+              (in errors view: write errors to ~/spaceforce-errors.txt)
+  W           Save the marked paths and sizes to
+              ~/spaceforce-manifest-<time>.txt (in the delete confirmation)
+  r           Rescan permission-denied directories (in errors view)
   ↑/↓ or j/k  Navigate up/down
+  PgUp/PgDn   Move a page up/down (Home/End: first/last item)
   Enter/Space Expand/collapse (in tree view)
+  L           Jump to the largest child (in tree view)
   s           Change sort mode (in top list view)
   f           Toggle files (in top list view)
   d           Toggle directories (in top list view)
+  p           Show, dim or hide protected items (in top list view)
+  v           Visual select a range that m marks at once (in top list view)
+  %           Show/hide the share of the whole scan (in top list view)
+  g           Group by extension or category (in breakdown view)
+              or errors by type (in errors view)
+  i           Identify files without an extension by their contents
+              (in breakdown view)
+  Enter       List the selected type's or period's files in the top list
+              (in breakdown and timeline views)
   q           Quit
 
 Views:
   1. Tree View      - Hierarchical directory tree
-  2. Top Items      - Largest files and folders sorted
-  3. Breakdown      - File type statistics and breakdown
+  2. Top Items      - Largest files and folders sorted (g groups the files
+                       by parent folder, with each folder's total)
+  3. Breakdown      - File type statistics and breakdown, plus scanned vs
+                       filesystem-used space (the rest is purgeable/unscanned)
   4. Timeline       - Files grouped by modification date
   5. Errors         - Scan errors and warnings (permission denied, etc.)
+  6. Suggestions    - Cleanup suggestions (+/- adjusts the old-file age cutoff,
+                       T deletes Time Machine local snapshots when listed)
+  7. Apps           - App container space (~/Library/Containers) grouped by app name
+  8. Sizes          - Files binned by size range (count and total per range)
 
 Safety:
   SpaceForce uses intelligent safety checks to prevent deletion of:
@@ -185,6 +794,21 @@ Examples:
   # Scan a specific directory
   spaceforce -path /Users/yourname/Downloads
 
+  # Check a single large file
+  spaceforce -path ~/Downloads/backup.dmg
+
+  # Fail a CI job if build output grows beyond 2 GB
+  spaceforce -path ./build -fail-over 2G
+
+  # Keep size colors useful on a photo library
+  spaceforce -path ~/Pictures -size-colors 50M,2G
+
+  # Print a summary of a server's disk usage over SSH
+  spaceforce -path /var -report
+
+  # Feed scan progress to another program as JSON lines
+  spaceforce -path ~/Library -json-stream
+
 For more information, visit: https://github.com/yourusername/spaceforce
 `)
 }
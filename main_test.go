@@ -0,0 +1,246 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runModes returns the non-interactive mode flags with the named ones set
+func runModes(set ...string) []modeFlag {
+	modes := []modeFlag{
+		{name: "users", mode: modeUsersReport},
+		{name: "fail-over", mode: modeBudgetCheck},
+		{name: "report", mode: modeTextReport},
+		{name: "json-stream", mode: modeJSONStream},
+	}
+	for i := range modes {
+		for _, name := range set {
+			if modes[i].name == name {
+				modes[i].set = true
+			}
+		}
+	}
+	return modes
+}
+
+func TestChooseRunModeDispatches(t *testing.T) {
+	tests := []struct {
+		flag string
+		want runMode
+	}{
+		{"users", modeUsersReport},
+		{"fail-over", modeBudgetCheck},
+		{"report", modeTextReport},
+		{"json-stream", modeJSONStream},
+	}
+	for _, tt := range tests {
+		mode, err := chooseRunMode(runModes(tt.flag), nil, nil)
+		if err != nil || mode != tt.want {
+			t.Errorf("-%s: mode %d, err %v; want mode %d", tt.flag, mode, err, tt.want)
+		}
+	}
+
+	if mode, err := chooseRunMode(runModes(), nil, nil); err != nil || mode != modeTUI {
+		t.Errorf("no mode flag: mode %d, err %v; want the TUI", mode, err)
+	}
+	if mode, err := chooseRunMode(runModes(), []string{"permanent", "target"}, nil); err != nil || mode != modeTUI {
+		t.Errorf("TUI-only flags alone: mode %d, err %v; want the TUI", mode, err)
+	}
+}
+
+func TestChooseRunModeRejectsConflicts(t *testing.T) {
+	names := []string{"users", "fail-over", "report", "json-stream"}
+	for i, first := range names {
+		for _, second := range names[i+1:] {
+			_, err := chooseRunMode(runModes(first, second), nil, nil)
+			if err == nil {
+				t.Errorf("-%s with -%s was accepted", first, second)
+				continue
+			}
+			if !strings.Contains(err.Error(), "-"+first) || !strings.Contains(err.Error(), "-"+second) {
+				t.Errorf("-%s with -%s: error %q does not name both flags", first, second, err)
+			}
+		}
+	}
+
+	if _, err := chooseRunMode(runModes(names...), nil, nil); err == nil {
+		t.Error("every mode flag at once was accepted")
+	}
+}
+
+func TestChooseRunModeRejectsTUIOnlyFlags(t *testing.T) {
+	for tuiFlag := range tuiOnlyFlags {
+		for _, mode := range []string{"users", "fail-over", "report", "json-stream"} {
+			_, err := chooseRunMode(runModes(mode), []string{tuiFlag}, nil)
+			if err == nil {
+				t.Errorf("-%s with -%s was accepted", tuiFlag, mode)
+				continue
+			}
+			if !strings.Contains(err.Error(), "-"+tuiFlag) || !strings.Contains(err.Error(), "-"+mode) {
+				t.Errorf("-%s with -%s: error %q does not name both flags", tuiFlag, mode, err)
+			}
+		}
+	}
+}
+
+func TestChooseRunModeReportOnlyFlags(t *testing.T) {
+	for reportFlag := range reportOnlyFlags {
+		if mode, err := chooseRunMode(runModes("report"), nil, []string{reportFlag}); err != nil || mode != modeTextReport {
+			t.Errorf("-%s with -report: mode %d, err %v; want the report", reportFlag, mode, err)
+		}
+		if _, err := chooseRunMode(runModes(), nil, []string{reportFlag}); err == nil {
+			t.Errorf("-%s without -report was accepted by the TUI", reportFlag)
+		}
+		for _, mode := range []string{"users", "fail-over", "json-stream"} {
+			_, err := chooseRunMode(runModes(mode), nil, []string{reportFlag})
+			if err == nil || !strings.Contains(err.Error(), "-"+mode) {
+				t.Errorf("-%s with -%s: err %v, want one naming -%s", reportFlag, mode, err, mode)
+			}
+		}
+	}
+}
+
+// captureStdout runs fn with stdout redirected and returns what it printed and its exit code
+func captureStdout(t *testing.T, fn func() int) (string, int) {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	saved := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = saved }()
+
+	output := make(chan string)
+	go func() {
+		data, _ := io.ReadAll(r)
+		output <- string(data)
+	}()
+
+	code := fn()
+	w.Close()
+	return <-output, code
+}
+
+// writeModeTree creates a small tree for the non-interactive modes to scan
+func writeModeTree(t *testing.T) string {
+	t.Helper()
+	root := t.TempDir()
+	for name, size := range map[string]int{"media/movie.mov": 6000, "media/song.mp3": 3000, "notes.txt": 1000} {
+		path := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, make([]byte, size), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return root
+}
+
+func TestRunReportOutput(t *testing.T) {
+	root := writeModeTree(t)
+
+	out, code := captureStdout(t, func() int { return runReport(root, 0, scanOptions{}) })
+	if code != 0 {
+		t.Fatalf("exit code = %d, output:\n%s", code, out)
+	}
+	for _, want := range []string{"SpaceForce report for " + root, "in 3 file(s)", "Largest items:", "movie.mov", "By category:", "Scan errors: 0"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("report is missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "Largest files:") {
+		t.Error("the largest files list is shown without -top")
+	}
+
+	out, _ = captureStdout(t, func() int { return runReport(root, 2, scanOptions{}) })
+	section := out[strings.Index(out, "Largest files:"):]
+	section = section[:strings.Index(section, "By category:")]
+	if strings.Count(section, root) != 2 || !strings.Contains(section, "movie.mov") {
+		t.Errorf("-top 2 listed:\n%s", section)
+	}
+
+	if _, code := captureStdout(t, func() int { return runReport(filepath.Join(root, "missing"), 0, scanOptions{}) }); code != 1 {
+		t.Errorf("exit code for a missing path = %d, want 1", code)
+	}
+}
+
+func TestRunBudgetCheckOutput(t *testing.T) {
+	root := writeModeTree(t)
+
+	out, code := captureStdout(t, func() int { return runBudgetCheck(root, 1<<20, scanOptions{}) })
+	if code != 0 || !strings.HasPrefix(out, "OK: "+root) {
+		t.Errorf("within budget: exit code %d, output:\n%s", code, out)
+	}
+
+	out, code = captureStdout(t, func() int { return runBudgetCheck(root, 5000, scanOptions{}) })
+	if code != exitOverBudget || !strings.HasPrefix(out, "FAIL: "+root) {
+		t.Errorf("over budget: exit code %d, output:\n%s", code, out)
+	}
+	if !strings.Contains(out, "Largest contributors:") || !strings.Contains(out, filepath.Join(root, "media")) {
+		t.Errorf("over budget output does not name the largest contributor:\n%s", out)
+	}
+}
+
+func TestRunJSONStreamOutput(t *testing.T) {
+	root := writeModeTree(t)
+
+	out, code := captureStdout(t, func() int { return runJSONStream(root, scanOptions{}) })
+	if code != 0 {
+		t.Fatalf("exit code = %d, output:\n%s", code, out)
+	}
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	var last streamEvent
+	for i, line := range lines {
+		var event streamEvent
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("line %d is not JSON: %q", i+1, line)
+		}
+		if i < len(lines)-1 && event.Type != "progress" {
+			t.Errorf("line %d has type %q, want progress", i+1, event.Type)
+		}
+		last = event
+	}
+	if last.Type != "summary" || last.Path != root || last.TotalSize < 10000 {
+		t.Errorf("last line = %+v, want the summary for %s", last, root)
+	}
+
+	out, code = captureStdout(t, func() int { return runJSONStream(filepath.Join(root, "missing"), scanOptions{}) })
+	if code != 1 || !strings.Contains(out, `"type":"error"`) {
+		t.Errorf("missing path: exit code %d, output:\n%s", code, out)
+	}
+}
+
+func TestRunUsersReportOutput(t *testing.T) {
+	usersDir := t.TempDir()
+	for name, size := range map[string]int{"alex/big.bin": 8000, "sam/small.bin": 2000} {
+		path := filepath.Join(usersDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, make([]byte, size), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out, code := captureStdout(t, func() int { return runUsersReport(usersDir, scanOptions{}) })
+	if code != 0 {
+		t.Fatalf("exit code = %d, output:\n%s", code, out)
+	}
+	alex, sam := strings.Index(out, "alex"), strings.Index(out, "sam")
+	if !strings.HasPrefix(out, "User home directories in "+usersDir) || alex < 0 || sam < alex {
+		t.Errorf("users report should list alex before sam:\n%s", out)
+	}
+	if strings.Contains(out, "could not be fully read") {
+		t.Errorf("readable homes were reported as partial:\n%s", out)
+	}
+
+	if _, code := captureStdout(t, func() int { return runUsersReport(filepath.Join(usersDir, "missing"), scanOptions{}) }); code != 1 {
+		t.Errorf("exit code for a missing users directory = %d, want 1", code)
+	}
+}
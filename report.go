@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"spaceforce/scanner"
+	"spaceforce/ui/views"
+	"spaceforce/util"
+)
+
+// reportTopItems is how many of the largest items -report lists
+const reportTopItems = 20
+
+// runReport scans rootPath without the TUI and prints a plain-text summary to stdout,
+// listing the topFiles largest files as well when topFiles is above 0
+// Returns the process exit code: 0 = report printed, 1 = scan error
+func runReport(rootPath string, topFiles int, opts scanOptions) int {
+	scn := opts.newScanner()
+
+	root, err := scn.Scan(context.Background(), rootPath, nil)
+	if err != nil {
+		fmt.Printf("Error: scan failed: %v\n", err)
+		return 1
+	}
+
+	if opts.skipManifest != "" {
+		if err := writeSkipManifest(opts.skipManifest, scn.GetSkippedPaths()); err != nil {
+			fmt.Printf("Warning: cannot write skip manifest: %v\n", err)
+		}
+	}
+
+	var stats *scanner.DirStats
+	if topFiles > 0 {
+		stats = scanner.CalculateStatsN(root, topFiles)
+	} else {
+		stats = scanner.CalculateStats(root)
+	}
+	total := root.TotalSize()
+
+	fmt.Printf("SpaceForce report for %s\n", root.Path)
+	fmt.Printf("Total: %s in %d file(s) and %d folder(s)\n",
+		util.FormatBytesPlain(total), stats.FileCount, stats.DirCount)
+
+	fmt.Printf("\nLargest items:\n")
+	for _, item := range largestItems(scanner.FlattenTree(root), root, reportTopItems) {
+		kind := "file"
+		if item.node.IsDir {
+			kind = "dir"
+		}
+		fmt.Printf("  %10s  %-4s  %s\n", util.FormatBytesPlain(item.size), kind, item.node.Path)
+	}
+
+	if topFiles > 0 {
+		fmt.Printf("\nLargest files:\n")
+		for _, file := range stats.LargestFiles {
+			fmt.Printf("  %10s  %s\n", util.FormatBytesPlain(file.TotalSize()), file.Path)
+		}
+	}
+
+	types := make([]*scanner.TypeStats, 0, len(stats.TypeBreakdown))
+	for _, typeStats := range stats.TypeBreakdown {
+		types = append(types, typeStats)
+	}
+	fmt.Printf("\nBy category:\n")
+	for _, category := range views.GroupByCategory(types) {
+		share := 0.0
+		if stats.TotalSize > 0 {
+			share = float64(category.TotalSize) / float64(stats.TotalSize) * 100
+		}
+		fmt.Printf("  %-22s %10s  %8d file(s)  %5.1f%%\n",
+			category.Extension, util.FormatBytesPlain(category.TotalSize), category.FileCount, share)
+	}
+
+	fmt.Printf("\nScan errors: %d\n", len(scn.GetProgress().Errors))
+	return 0
+}
+
+// sizedNode pairs a node with its total size, computed once for sorting
+type sizedNode struct {
+	node *scanner.FileNode
+	size int64
+}
+
+// largestItems returns the biggest files and folders in nodes (excluding root), largest first
+func largestItems(nodes []*scanner.FileNode, root *scanner.FileNode, limit int) []sizedNode {
+	items := make([]sizedNode, 0, len(nodes))
+	for _, node := range nodes {
+		if node != root {
+			items = append(items, sizedNode{node: node, size: node.TotalSize()})
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		return items[i].size > items[j].size
+	})
+
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	return items
+}
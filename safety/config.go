@@ -0,0 +1,81 @@
+package safety
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strings"
+)
+
+// currentUser looks the user up in the user database; tests replace it to simulate an unknown home
+var currentUser = user.Current
+
+// HomeDir returns the current user's home directory
+// Uses $HOME, falling back to the user database when HOME is unset (CI, launchd daemons)
+// Returns an error rather than "" so callers skip home-relative rules instead of
+// matching them against "/Library"-style paths
+func HomeDir() (string, error) {
+	if homeDir, err := os.UserHomeDir(); err == nil && homeDir != "" {
+		return homeDir, nil
+	}
+	if current, err := currentUser(); err == nil && current.HomeDir != "" {
+		return current.HomeDir, nil
+	}
+	return "", errors.New("cannot determine home directory (HOME is not set)")
+}
+
+// ConfigDir returns the SpaceForce configuration directory (~/.config/spaceforce)
+// Returns "" if the home directory is unknown
+func ConfigDir() string {
+	homeDir, err := HomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(homeDir, ".config", "spaceforce")
+}
+
+// ExpandHome replaces a leading ~ with the user's home directory
+func ExpandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+
+	homeDir, err := HomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(homeDir, strings.TrimPrefix(path, "~"))
+}
+
+// ReadPatternFile reads a list of paths or globs, one per line
+// Blank lines and lines starting with # are ignored, and ~ is expanded
+// A missing file is not an error - it simply yields no entries
+func ReadPatternFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer file.Close()
+
+	entries := make([]string, 0)
+	lines := bufio.NewScanner(file)
+	for lines.Scan() {
+		line := strings.TrimSpace(lines.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		entries = append(entries, ExpandHome(line))
+	}
+
+	return entries, lines.Err()
+}
+
+// isGlobPattern reports whether a path contains glob metacharacters
+func isGlobPattern(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
@@ -0,0 +1,82 @@
+package safety
+
+import (
+	"errors"
+	"os/user"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// withoutHome makes the home directory unknown: HOME is unset and the user database has no entry
+func withoutHome(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", "")
+	saved := currentUser
+	currentUser = func() (*user.User, error) { return nil, errors.New("no such user") }
+	t.Cleanup(func() { currentUser = saved })
+}
+
+func TestHomeDirFallsBackToUserDatabase(t *testing.T) {
+	t.Setenv("HOME", "")
+	saved := currentUser
+	currentUser = func() (*user.User, error) { return &user.User{HomeDir: "/home/someone"}, nil }
+	t.Cleanup(func() { currentUser = saved })
+
+	if home, err := HomeDir(); err != nil || home != "/home/someone" {
+		t.Errorf("HomeDir() = %q, %v; want the user database entry", home, err)
+	}
+}
+
+func TestUnknownHomeSkipsHomeRelativeRules(t *testing.T) {
+	withoutHome(t)
+
+	if home, err := HomeDir(); err == nil {
+		t.Fatalf("HomeDir() = %q, want an error", home)
+	}
+	if dir := ConfigDir(); dir != "" {
+		t.Errorf("ConfigDir() = %q, want an empty path", dir)
+	}
+	if got := ExpandHome("~/Library"); got != "~/Library" {
+		t.Errorf("ExpandHome left %q, want the path unexpanded", got)
+	}
+	if paths := getSensitivePaths(); len(paths) != 0 {
+		t.Errorf("sensitive paths = %v, want none", paths)
+	}
+
+	p := NewProtector()
+	before := len(p.absolutelyProtectedPaths)
+	p.AddProtectedPath("~/Projects")
+	p.AddProtectedPath("~")
+	if len(p.absolutelyProtectedPaths) != before {
+		t.Errorf("home-relative rules were added as %v", p.absolutelyProtectedPaths[before:])
+	}
+
+	for _, location := range GetCommonBloatLocations() {
+		if !strings.HasPrefix(location.Path, "~") {
+			continue
+		}
+		if got := ExpandHome(location.Path); filepath.IsAbs(got) {
+			t.Errorf("bloat location %s resolved to %s", location.Path, got)
+		}
+	}
+}
+
+func TestKnownHomeExpandsRules(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	if got := ExpandHome("~/Library"); got != filepath.Join(home, "Library") {
+		t.Errorf("ExpandHome = %q", got)
+	}
+	if got := ConfigDir(); got != filepath.Join(home, ".config", "spaceforce") {
+		t.Errorf("ConfigDir = %q", got)
+	}
+
+	p := NewProtector()
+	p.AddProtectedPath("~/Projects")
+	if !matchesProtectedPath(filepath.Join(home, "Projects", "app"), filepath.Join(home, "Projects")) ||
+		!p.userProtected[filepath.Join(home, "Projects")] {
+		t.Error("~/Projects was not protected under the home directory")
+	}
+}
@@ -1,7 +1,6 @@
 package safety
 
 import (
-	"os"
 	"path/filepath"
 )
 
@@ -33,7 +32,11 @@ func getAbsolutelyProtectedPaths() []string {
 // getSensitivePaths returns paths that require explicit user confirmation to delete
 // These are important user data/config locations but CAN be deleted if user confirms
 func getSensitivePaths() []string {
-	homeDir, _ := os.UserHomeDir()
+	homeDir, err := HomeDir()
+	if err != nil {
+		// Every entry is home-relative; without a home there is nothing to match
+		return []string{}
+	}
 
 	return []string{
 		// Home directory itself (but not contents)
@@ -60,6 +63,16 @@ func getSensitivePaths() []string {
 	}
 }
 
+// credentialDirNames are directory names that hold credentials wherever they appear
+// (e.g. a copied home folder or a backup), mapped to the warning shown for them
+var credentialDirNames = map[string]string{
+	".ssh":    "SSH keys and configuration",
+	".gnupg":  "GPG keys",
+	".aws":    "Cloud credentials",
+	".kube":   "Cluster credentials",
+	".docker": "Registry credentials",
+}
+
 // getProtectedPaths is deprecated - keeping for backward compatibility
 // Use getAbsolutelyProtectedPaths instead
 func getProtectedPaths() []string {
@@ -125,6 +138,7 @@ func GetCommonBloatLocations() []BloatLocation {
 			Description: "iOS Simulator data",
 			RiskLevel:   0,
 			Reason:      "Simulator will recreate runtime environments",
+			ReviewFirst: true, // Also holds the apps and data installed on each simulator
 		},
 		{
 			Path:        "~/Library/Containers/com.docker.docker/Data",
@@ -207,4 +221,5 @@ type BloatLocation struct {
 	Description string
 	RiskLevel   int // 0=safe, 1=low risk, 2=review carefully
 	Reason      string
+	ReviewFirst bool // Left out of the one-step safe cleanup ('C') despite a low risk level
 }
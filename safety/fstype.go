@@ -0,0 +1,103 @@
+package safety
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+)
+
+// mountEntry is one mounted filesystem
+type mountEntry struct {
+	mountPoint string
+	fsType     string
+}
+
+// mountTable is the list of mounted filesystems, read on first use, with the filesystem
+// type found for each device
+// Each VolumeChecker (one per scan) has its own, so filesystems mounted between scans are seen
+type mountTable struct {
+	once    sync.Once
+	entries []mountEntry
+	err     error
+
+	mu       sync.Mutex
+	byDevice map[uint64]string // st_dev -> filesystem type
+}
+
+// filesystemType returns the type of the filesystem containing path (e.g. "apfs", "ext4", "nfs", "smbfs")
+// Results are cached by device (st_dev), so a scan makes one stat per directory and looks
+// a filesystem up in the mount table (getfsstat on macOS, /proc/mounts on Linux) only once.
+// Symlinks are followed, so a link into a network mount reports that mount's type
+func (t *mountTable) filesystemType(path string) (string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return "", err
+	}
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return "", fmt.Errorf("no device information for %s", path)
+	}
+	device := uint64(stat.Dev)
+
+	t.mu.Lock()
+	fsType, cached := t.byDevice[device]
+	t.mu.Unlock()
+	if cached {
+		return fsType, nil
+	}
+
+	// The mount table lists mount points by their real paths
+	resolved, err := filepath.EvalSymlinks(path)
+	if err != nil {
+		return "", err
+	}
+	fsType, err = t.lookup(resolved)
+	if err != nil {
+		return "", err
+	}
+
+	t.mu.Lock()
+	if t.byDevice == nil {
+		t.byDevice = make(map[uint64]string)
+	}
+	t.byDevice[device] = fsType
+	t.mu.Unlock()
+	return fsType, nil
+}
+
+// lookup returns the type of the mount table's filesystem containing the resolved path
+func (t *mountTable) lookup(path string) (string, error) {
+	t.once.Do(func() {
+		t.entries, t.err = readMounts()
+	})
+	if t.err != nil {
+		return "", t.err
+	}
+	mounts := t.entries
+
+	// The longest mount point containing path is the filesystem it lives on
+	best := -1
+	for i, mount := range mounts {
+		if !pathWithin(path, mount.mountPoint) {
+			continue
+		}
+		if best < 0 || len(mount.mountPoint) > len(mounts[best].mountPoint) {
+			best = i
+		}
+	}
+	if best < 0 {
+		return "", fmt.Errorf("no mount point contains %s", path)
+	}
+	return mounts[best].fsType, nil
+}
+
+// pathWithin reports whether path is mountPoint or lies beneath it
+func pathWithin(path, mountPoint string) bool {
+	if mountPoint == "/" || path == mountPoint {
+		return true
+	}
+	return strings.HasPrefix(path, mountPoint+"/")
+}
@@ -0,0 +1,44 @@
+package safety
+
+import (
+	"syscall"
+)
+
+// mntNoWait asks getfsstat for cached statistics instead of querying every filesystem,
+// which would block on unreachable network mounts
+const mntNoWait = 2
+
+// readMounts lists the mounted filesystems with getfsstat
+func readMounts() ([]mountEntry, error) {
+	count, err := syscall.Getfsstat(nil, mntNoWait)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]syscall.Statfs_t, count)
+	count, err = syscall.Getfsstat(stats, mntNoWait)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]mountEntry, 0, count)
+	for _, stat := range stats[:count] {
+		entries = append(entries, mountEntry{
+			mountPoint: cString(stat.Mntonname[:]),
+			fsType:     cString(stat.Fstypename[:]),
+		})
+	}
+	return entries, nil
+}
+
+// cString converts a NUL-terminated statfs name field (an int8 array on macOS) to a string
+func cString(field []int8) string {
+	b := make([]byte, 0, len(field))
+	for _, v := range field {
+		if v == 0 {
+			break
+		}
+		b = append(b, byte(v))
+	}
+	return string(b)
+}
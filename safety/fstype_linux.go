@@ -0,0 +1,56 @@
+package safety
+
+import (
+	"bufio"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// readMounts reads the mount table from /proc/mounts
+func readMounts() ([]mountEntry, error) {
+	return readMountsFile("/proc/mounts")
+}
+
+// readMountsFile parses a mounts table ("device mountpoint fstype options dump pass")
+func readMountsFile(path string) ([]mountEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	entries := make([]mountEntry, 0)
+	lines := bufio.NewScanner(file)
+	for lines.Scan() {
+		fields := strings.Fields(lines.Text())
+		if len(fields) < 3 {
+			continue
+		}
+		entries = append(entries, mountEntry{
+			mountPoint: unescapeMountField(fields[1]),
+			fsType:     fields[2],
+		})
+	}
+	return entries, lines.Err()
+}
+
+// unescapeMountField decodes the octal escapes /proc/mounts uses for spaces, tabs and backslashes
+func unescapeMountField(field string) string {
+	if !strings.Contains(field, `\`) {
+		return field
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(field); i++ {
+		if field[i] == '\\' && i+3 < len(field) {
+			if code, err := strconv.ParseUint(field[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(code))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(field[i])
+	}
+	return b.String()
+}
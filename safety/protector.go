@@ -6,20 +6,78 @@ import (
 	"strings"
 )
 
+// userProtectedFile is the optional list of extra never-delete paths, relative to ConfigDir
+const userProtectedFile = "protected.txt"
+
 // Protector handles safety checks for file operations
 type Protector struct {
 	absolutelyProtectedPaths []string
 	sensitivePaths           []string
 	protectedExts            []string
+	userProtected            map[string]bool // Entries added via AddProtectedPath
 }
 
 // NewProtector creates a new protector with macOS default protections
+// Extra paths from ~/.config/spaceforce/protected.txt are merged in if present
 func NewProtector() *Protector {
-	return &Protector{
+	p := &Protector{
 		absolutelyProtectedPaths: getAbsolutelyProtectedPaths(),
 		sensitivePaths:           getSensitivePaths(),
 		protectedExts:            getProtectedExtensions(),
+		userProtected:            make(map[string]bool),
+	}
+
+	if configDir := ConfigDir(); configDir != "" {
+		// Unreadable config is ignored - the built-in protections still apply
+		entries, _ := ReadPatternFile(filepath.Join(configDir, userProtectedFile))
+		for _, entry := range entries {
+			p.AddProtectedPath(entry)
+		}
+	}
+
+	return p
+}
+
+// AddProtectedPath adds a path or glob that can never be deleted
+// A leading ~ is expanded to the home directory; such entries are skipped when the home is unknown
+func (p *Protector) AddProtectedPath(path string) {
+	path = strings.TrimSpace(path)
+	if path == "" {
+		return
+	}
+
+	path = ExpandHome(path)
+	if path == "~" || strings.HasPrefix(path, "~/") {
+		// Still unexpanded: filepath.Abs would resolve it against the working directory
+		return
+	}
+	if !isGlobPattern(path) {
+		if absPath, err := filepath.Abs(path); err == nil {
+			path = absPath
+		}
+	}
+	path = strings.TrimSuffix(path, "/")
+
+	if p.userProtected[path] {
+		return
+	}
+	p.userProtected[path] = true
+	p.absolutelyProtectedPaths = append(p.absolutelyProtectedPaths, path)
+}
+
+// matchesProtectedPath reports whether absPath is protectedPath or lies under it
+// Glob entries match the path itself or any of its parent directories
+func matchesProtectedPath(absPath string, protectedPath string) bool {
+	if !isGlobPattern(protectedPath) {
+		return absPath == protectedPath || strings.HasPrefix(absPath, protectedPath+"/")
+	}
+
+	for current := absPath; current != "/" && current != "."; current = filepath.Dir(current) {
+		if matched, _ := filepath.Match(protectedPath, current); matched {
+			return true
+		}
 	}
+	return false
 }
 
 // IsSafeToDelete checks if a file/directory is safe to delete
@@ -34,7 +92,10 @@ func (p *Protector) IsSafeToDelete(path string) (bool, string) {
 	// Check if it's an absolutely protected system path
 	for _, protectedPath := range p.absolutelyProtectedPaths {
 		// Exact match or everything under it
-		if absPath == protectedPath || strings.HasPrefix(absPath, protectedPath+"/") {
+		if matchesProtectedPath(absPath, protectedPath) {
+			if p.userProtected[protectedPath] {
+				return false, "Protected by user configuration"
+			}
 			return false, "System path - critical for macOS operation"
 		}
 	}
@@ -62,8 +123,9 @@ func (p *Protector) IsSafeToDelete(path string) (bool, string) {
 		}
 	}
 
-	// Check if path exists and is writable
-	info, err := os.Stat(absPath)
+	// Check if path exists and is writable (a symlink is judged as the link itself,
+	// since deleting it never touches its target)
+	info, err := os.Lstat(absPath)
 	if err != nil {
 		return false, "File does not exist or cannot be accessed"
 	}
@@ -74,8 +136,7 @@ func (p *Protector) IsSafeToDelete(path string) (bool, string) {
 	}
 
 	// Everything else is safe to delete (though may require confirmation)
-	homeDir, _ := os.UserHomeDir()
-	if strings.HasPrefix(absPath, homeDir) {
+	if homeDir, err := HomeDir(); err == nil && strings.HasPrefix(absPath, homeDir) {
 		return true, "User file"
 	}
 
@@ -136,19 +197,35 @@ func (p *Protector) RequiresConfirmation(path string) (bool, string) {
 	return false, ""
 }
 
+// IsSensitiveContent reports whether a directory found inside an item being deleted
+// needs extra confirmation: a sensitive location, or a credentials folder anywhere
+func (p *Protector) IsSensitiveContent(path string) (bool, string) {
+	if requiresConf, reason := p.RequiresConfirmation(path); requiresConf {
+		return true, reason
+	}
+	if reason, ok := credentialDirNames[filepath.Base(path)]; ok {
+		return true, reason
+	}
+	return false, ""
+}
+
 // GetRiskLevel returns a risk level for deleting a path (0-3)
 // 0 = safe, 1 = low risk, 2 = medium risk, 3 = high risk/protected
 func (p *Protector) GetRiskLevel(path string) int {
 	safe, reason := p.IsSafeToDelete(path)
 
 	if !safe {
-		if strings.Contains(reason, "System") || strings.Contains(reason, "critical") {
+		if strings.Contains(reason, "System") || strings.Contains(reason, "critical") ||
+			strings.Contains(reason, "user configuration") {
 			return 3 // High risk
 		}
 		return 2 // Medium risk
 	}
 
-	homeDir, _ := os.UserHomeDir()
+	homeDir, err := HomeDir()
+	if err != nil {
+		return 0
+	}
 	absPath, _ := filepath.Abs(path)
 
 	// Documents, Desktop, etc. are low risk (user knows what's there)
@@ -168,6 +245,12 @@ func (p *Protector) GetRiskLevel(path string) int {
 	return 0
 }
 
+// IsRoutineCleanup reports whether path is a no-risk cache or log item
+// Deleting these only needs a single confirmation when the user opts in
+func (p *Protector) IsRoutineCleanup(path string) bool {
+	return p.GetRiskLevel(path) == 0 && (p.IsCache(path) || p.IsLogFile(path))
+}
+
 // IsCache checks if a path is a cache directory
 func (p *Protector) IsCache(path string) bool {
 	absPath, _ := filepath.Abs(path)
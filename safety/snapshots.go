@@ -0,0 +1,72 @@
+package safety
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// snapshotPrefix and snapshotSuffix wrap the date in a Time Machine local snapshot name,
+// e.g. com.apple.TimeMachine.2024-03-01-101500.local
+const (
+	snapshotPrefix     = "com.apple.TimeMachine."
+	snapshotSuffix     = ".local"
+	snapshotDateLayout = "2006-01-02-150405"
+)
+
+// LocalSnapshot is a Time Machine local (APFS) snapshot
+// Snapshots hold on to the blocks of deleted and changed files, so their space
+// never shows up in a scan
+type LocalSnapshot struct {
+	Name string    // Full snapshot name
+	Date string    // Date part, as tmutil deletelocalsnapshots expects it
+	Time time.Time // Parsed date (zero if the name has an unexpected format)
+}
+
+// ParseLocalSnapshots extracts the Time Machine snapshots from
+// `tmutil listlocalsnapshots` output, skipping the header and any other lines
+func ParseLocalSnapshots(output string) []LocalSnapshot {
+	var snapshots []LocalSnapshot
+	for _, line := range strings.Split(output, "\n") {
+		name := strings.TrimSpace(line)
+		if !strings.HasPrefix(name, snapshotPrefix) {
+			continue
+		}
+
+		date := strings.TrimSuffix(strings.TrimPrefix(name, snapshotPrefix), snapshotSuffix)
+		snapshot := LocalSnapshot{Name: name, Date: date}
+		if t, err := time.ParseInLocation(snapshotDateLayout, date, time.Local); err == nil {
+			snapshot.Time = t
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	return snapshots
+}
+
+// ListLocalSnapshots returns the Time Machine local snapshots of the volume at mountPoint
+// Systems without tmutil (Linux) have none
+func ListLocalSnapshots(mountPoint string) ([]LocalSnapshot, error) {
+	if _, err := exec.LookPath("tmutil"); err != nil {
+		return nil, nil
+	}
+
+	output, err := exec.Command("tmutil", "listlocalsnapshots", mountPoint).Output()
+	if err != nil {
+		return nil, fmt.Errorf("cannot list local snapshots: %w", err)
+	}
+	return ParseLocalSnapshots(string(output)), nil
+}
+
+// DeleteLocalSnapshot deletes a Time Machine local snapshot with tmutil
+func DeleteLocalSnapshot(snapshot LocalSnapshot) error {
+	output, err := exec.Command("tmutil", "deletelocalsnapshots", snapshot.Date).CombinedOutput()
+	if err != nil {
+		message := strings.TrimSpace(string(output))
+		if message == "" {
+			message = err.Error()
+		}
+		return fmt.Errorf("%s: %s", snapshot.Name, message)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package safety
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// FreeSpace returns the bytes available to the current user on the filesystem containing path
+func FreeSpace(path string) (int64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, fmt.Errorf("cannot get filesystem stats: %w", err)
+	}
+	return int64(stat.Bavail) * int64(stat.Bsize), nil
+}
+
+// UsedSpace returns the bytes in use on the filesystem containing path, as df reports them
+// On APFS this includes purgeable space and local snapshots that a scan cannot see
+func UsedSpace(path string) (int64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, fmt.Errorf("cannot get filesystem stats: %w", err)
+	}
+	return int64(stat.Blocks-stat.Bfree) * int64(stat.Bsize), nil
+}
+
+// KeepsBytesOnDisk reports whether deleting with this method leaves the data on disk
+// until the Trash is emptied
+func (m DeleteMethod) KeepsBytesOnDisk() bool {
+	return m == DeleteToTrash
+}
+
+// ProjectedFreeSpace returns the free space right after deleting size bytes with method,
+// and once the deletion is fully realized (after emptying the Trash, if the method uses it)
+func ProjectedFreeSpace(free, size int64, method DeleteMethod) (immediate int64, eventual int64) {
+	if size < 0 {
+		size = 0
+	}
+	eventual = free + size
+	if method.KeepsBytesOnDisk() {
+		return free, eventual
+	}
+	return eventual, eventual
+}
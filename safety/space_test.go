@@ -0,0 +1,41 @@
+package safety
+
+import "testing"
+
+func TestProjectedFreeSpace(t *testing.T) {
+	const gb = int64(1) << 30
+	tests := []struct {
+		name                string
+		free, size          int64
+		method              DeleteMethod
+		immediate, eventual int64
+	}{
+		{"trash keeps bytes until emptied", 10 * gb, 4 * gb, DeleteToTrash, 10 * gb, 14 * gb},
+		{"permanent frees at once", 10 * gb, 4 * gb, DeletePermanent, 14 * gb, 14 * gb},
+		{"nothing marked, trash", 10 * gb, 0, DeleteToTrash, 10 * gb, 10 * gb},
+		{"nothing marked, permanent", 10 * gb, 0, DeletePermanent, 10 * gb, 10 * gb},
+		{"full disk, trash", 0, 2 * gb, DeleteToTrash, 0, 2 * gb},
+		{"full disk, permanent", 0, 2 * gb, DeletePermanent, 2 * gb, 2 * gb},
+		{"negative size is ignored", 10 * gb, -gb, DeletePermanent, 10 * gb, 10 * gb},
+	}
+	for _, tt := range tests {
+		immediate, eventual := ProjectedFreeSpace(tt.free, tt.size, tt.method)
+		if immediate != tt.immediate || eventual != tt.eventual {
+			t.Errorf("%s: got %d now, %d eventually; want %d, %d",
+				tt.name, immediate, eventual, tt.immediate, tt.eventual)
+		}
+	}
+}
+
+func TestFreeSpaceOfTempDir(t *testing.T) {
+	free, err := FreeSpace(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if free < 0 {
+		t.Errorf("FreeSpace = %d", free)
+	}
+	if _, err := FreeSpace("/does/not/exist"); err == nil {
+		t.Error("expected an error for a missing path")
+	}
+}
@@ -1,6 +1,7 @@
 package safety
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -14,6 +15,16 @@ const (
 	DeletePermanent                     // Permanent deletion (unsafe)
 )
 
+// ErrProtected is wrapped by DeleteFile's error when it refuses a protected path
+// Nothing was attempted, so callers can report these apart from real failures
+var ErrProtected = errors.New("file is protected")
+
+// FileDeleter deletes one path and returns the bytes it freed
+// Deleter implements it; tests can stand in for it
+type FileDeleter interface {
+	DeleteFile(path string) (int64, error)
+}
+
 // Deleter handles file deletion operations
 type Deleter struct {
 	method    DeleteMethod
@@ -29,10 +40,11 @@ func NewDeleter(method DeleteMethod) *Deleter {
 }
 
 // DeleteFile deletes a single file or directory
+// A symlink is never followed: only the link is trashed or removed, not what it points to
 // Returns the size of the deleted item and any error
 func (d *Deleter) DeleteFile(path string) (int64, error) {
-	// Check if file exists
-	info, err := os.Stat(path)
+	// Check if file exists (Lstat, so a symlink is looked at as the link itself)
+	info, err := os.Lstat(path)
 	if err != nil {
 		return 0, fmt.Errorf("cannot stat file: %w", err)
 	}
@@ -40,7 +52,11 @@ func (d *Deleter) DeleteFile(path string) (int64, error) {
 	// Safety check
 	safe, reason := d.protector.IsSafeToDelete(path)
 	if !safe {
-		return 0, fmt.Errorf("file is protected: %s (%s)", path, reason)
+		return 0, fmt.Errorf("%w: %s (%s)", ErrProtected, path, reason)
+	}
+
+	if info.Mode()&os.ModeSymlink != 0 {
+		return info.Size(), d.deleteSymlink(path)
 	}
 
 	size := info.Size()
@@ -63,21 +79,13 @@ func (d *Deleter) DeleteFile(path string) (int64, error) {
 	return size, nil
 }
 
-// moveToTrash permanently deletes a file (we use strong confirmation dialogs instead)
-func (d *Deleter) moveToTrash(path string) error {
-	// Convert to absolute path
-	absPath, err := filepath.Abs(path)
-	if err != nil {
-		return fmt.Errorf("cannot get absolute path: %w", err)
-	}
-
-	// Just use os.RemoveAll - it's fast, simple, and works across filesystems
-	// We have strong confirmation dialogs (including double-confirm for sensitive paths)
-	if err := os.RemoveAll(absPath); err != nil {
-		return fmt.Errorf("failed to delete: %w", err)
+// deleteSymlink removes the link at path, leaving its target alone
+func (d *Deleter) deleteSymlink(path string) error {
+	if d.method == DeleteToTrash {
+		return d.trashSymlink(path)
 	}
-
-	return nil
+	// os.Remove unlinks the link; it never descends into a linked directory
+	return os.Remove(path)
 }
 
 // calculateDirSize calculates the total size of a directory
@@ -96,3 +104,64 @@ func calculateDirSize(path string) (int64, error) {
 
 	return size, err
 }
+
+// TrashSize returns the bytes held by items in the user's Trash
+// These still occupy disk space until the Trash is emptied
+func TrashSize() (int64, error) {
+	dirs, err := trashDirs()
+	if err != nil {
+		return 0, err
+	}
+
+	var total int64
+	for _, dir := range dirs {
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return 0, fmt.Errorf("cannot read the Trash: %w", err)
+		}
+		for _, entry := range entries {
+			size, _ := calculateDirSize(filepath.Join(dir, entry.Name()))
+			total += size
+		}
+	}
+	return total, nil
+}
+
+// EmptyTrash permanently deletes the Trash contents and returns how many bytes it freed
+func EmptyTrash() (int64, error) {
+	before, err := TrashSize()
+	if err != nil {
+		return 0, err
+	}
+	if err := emptyTrash(); err != nil {
+		return 0, fmt.Errorf("cannot empty the Trash: %w", err)
+	}
+
+	after, err := TrashSize()
+	if err != nil || after > before {
+		return before, nil
+	}
+	return before - after, nil
+}
+
+// removeContents deletes everything inside dirs, leaving the directories themselves
+func removeContents(dirs []string) error {
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if err := os.RemoveAll(filepath.Join(dir, entry.Name())); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package safety
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+)
+
+// runOsascript runs one AppleScript command and returns its combined output
+// A variable so tests can stand in for the Finder
+var runOsascript = func(script string) ([]byte, error) {
+	return exec.Command("osascript", "-e", script).CombinedOutput()
+}
+
+// finderNotAuthorized is the AppleScript error for Apple events the user has not allowed
+// (System Settings > Privacy & Security > Automation)
+const finderNotAuthorized = "(-1743)"
+
+// moveToTrash asks the Finder to move a file to the Trash, so it can be put back later
+// Going through the Finder (rather than renaming into ~/.Trash) trashes items on other
+// volumes too and records where they came from for Put Back. The first use asks the user
+// to let SpaceForce control the Finder; if that is refused the item is left in place
+func (d *Deleter) moveToTrash(path string) error {
+	// Convert to absolute path
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("cannot get absolute path: %w", err)
+	}
+
+	script := "tell application \"Finder\" to delete POSIX file " + appleScriptString(absPath)
+	if output, err := runOsascript(script); err != nil {
+		return finderError(output, err)
+	}
+
+	return nil
+}
+
+// finderError describes a failed Finder call from osascript's output and exit error
+func finderError(output []byte, err error) error {
+	message := strings.TrimSpace(string(output))
+	switch {
+	case strings.Contains(message, finderNotAuthorized):
+		return errors.New("failed to move to Trash: not allowed to control the Finder " +
+			"(allow it in System Settings > Privacy & Security > Automation, or use -permanent)")
+	case message != "":
+		return fmt.Errorf("failed to move to Trash: %s", message)
+	default:
+		return fmt.Errorf("failed to move to Trash: %w", err)
+	}
+}
+
+// trashSymlink moves a symlink itself into ~/.Trash with a rename, instead of handing it
+// to the Finder, so nothing along the way can resolve the link and trash its target
+// A link on another volume than the home Trash is refused rather than deleted outright
+func (d *Deleter) trashSymlink(path string) error {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("cannot get absolute path: %w", err)
+	}
+	dirs, err := trashDirs()
+	if err != nil {
+		return err
+	}
+
+	base := filepath.Base(absPath)
+	for i := 1; i < 10000; i++ {
+		name := base
+		if i > 1 {
+			name = fmt.Sprintf("%s %d", base, i)
+		}
+		dest := filepath.Join(dirs[0], name)
+		if _, err := os.Lstat(dest); err == nil {
+			continue
+		}
+		if err := os.Rename(absPath, dest); err != nil {
+			if errors.Is(err, syscall.EXDEV) {
+				return fmt.Errorf("cannot move %s to the Trash: it is on a different volume", absPath)
+			}
+			return fmt.Errorf("failed to move to Trash: %w", err)
+		}
+		return nil
+	}
+	return fmt.Errorf("cannot find a free name for %s in the Trash", base)
+}
+
+// appleScriptString quotes s as an AppleScript string literal
+func appleScriptString(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	return `"` + s + `"`
+}
+
+// trashDirs returns the directories holding the user's Trash (~/.Trash)
+func trashDirs() ([]string, error) {
+	homeDir, err := HomeDir()
+	if err != nil {
+		return nil, err
+	}
+	return []string{filepath.Join(homeDir, ".Trash")}, nil
+}
+
+// emptyTrash asks the Finder to empty the Trash, so items from other volumes go too
+// Without the Finder (or if it refuses) the home Trash is emptied directly
+func emptyTrash() error {
+	if _, err := runOsascript(`tell application "Finder" to empty trash`); err == nil {
+		return nil
+	}
+	dirs, err := trashDirs()
+	if err != nil {
+		return err
+	}
+	return removeContents(dirs)
+}
@@ -0,0 +1,67 @@
+package safety
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// withOsascript replaces the Finder with fake for one test, recording the scripts it was given
+func withOsascript(t *testing.T, fake func(script string) ([]byte, error)) *[]string {
+	t.Helper()
+	var scripts []string
+	saved := runOsascript
+	runOsascript = func(script string) ([]byte, error) {
+		scripts = append(scripts, script)
+		return fake(script)
+	}
+	t.Cleanup(func() { runOsascript = saved })
+	return &scripts
+}
+
+func TestMoveToTrashAsksFinder(t *testing.T) {
+	scripts := withOsascript(t, func(string) ([]byte, error) { return nil, nil })
+	path := filepath.Join(t.TempDir(), `say "hi".txt`)
+
+	if err := NewDeleter(DeleteToTrash).moveToTrash(path); err != nil {
+		t.Fatal(err)
+	}
+	want := `tell application "Finder" to delete POSIX file ` + appleScriptString(path)
+	if len(*scripts) != 1 || (*scripts)[0] != want {
+		t.Errorf("scripts = %q, want [%q]", *scripts, want)
+	}
+}
+
+func TestMoveToTrashFinderFailures(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   string
+	}{
+		{"automation denied", "execution error: Not authorized to send Apple events to Finder. (-1743)\n", "Privacy & Security > Automation"},
+		{"finder error", "execution error: Finder got an error: Can’t get file. (-1728)\n", "Can’t get file"},
+		{"no output", "", "exit status 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withOsascript(t, func(string) ([]byte, error) {
+				return []byte(tt.output), errors.New("exit status 1")
+			})
+			path := filepath.Join(t.TempDir(), "keep.txt")
+			if err := os.WriteFile(path, []byte("data"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			err := NewDeleter(DeleteToTrash).moveToTrash(path)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want one mentioning %q", err, tt.want)
+			}
+			if _, statErr := os.Stat(path); statErr != nil {
+				t.Errorf("file is gone after a failed trash: %v", statErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,123 @@
+package safety
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+// moveToTrash moves a file into the user's trash following the freedesktop.org Trash spec
+// ($XDG_DATA_HOME/Trash, with a .trashinfo file so file managers can restore it)
+// Items on another filesystem than the trash are refused rather than deleted outright
+func (d *Deleter) moveToTrash(path string) error {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return fmt.Errorf("cannot get absolute path: %w", err)
+	}
+
+	trashDir, err := xdgTrashDir()
+	if err != nil {
+		return err
+	}
+	filesDir := filepath.Join(trashDir, "files")
+	infoDir := filepath.Join(trashDir, "info")
+	for _, dir := range []string{filesDir, infoDir} {
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return fmt.Errorf("cannot create trash directory: %w", err)
+		}
+	}
+
+	// The spec requires the .trashinfo to be written first; creating it exclusively
+	// also reserves the name against concurrent trashing
+	name, infoPath, err := reserveTrashName(filesDir, infoDir, absPath)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Rename(absPath, filepath.Join(filesDir, name)); err != nil {
+		os.Remove(infoPath)
+		if errors.Is(err, syscall.EXDEV) {
+			return fmt.Errorf("cannot move %s to the Trash: it is on a different filesystem", absPath)
+		}
+		return fmt.Errorf("failed to move to Trash: %w", err)
+	}
+
+	return nil
+}
+
+// trashSymlink moves a symlink to the trash; the rename in moveToTrash already moves
+// the link itself, never its target
+func (d *Deleter) trashSymlink(path string) error {
+	return d.moveToTrash(path)
+}
+
+// xdgTrashDir returns the home trash directory ($XDG_DATA_HOME/Trash or ~/.local/share/Trash)
+func xdgTrashDir() (string, error) {
+	if dataHome := os.Getenv("XDG_DATA_HOME"); filepath.IsAbs(dataHome) {
+		return filepath.Join(dataHome, "Trash"), nil
+	}
+	homeDir, err := HomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(homeDir, ".local", "share", "Trash"), nil
+}
+
+// reserveTrashName picks a name not yet used in the trash and writes the .trashinfo
+// recording originalPath. Returns the name and the path of the info file
+func reserveTrashName(filesDir, infoDir, originalPath string) (string, string, error) {
+	base := filepath.Base(originalPath)
+	info := fmt.Sprintf("[Trash Info]\nPath=%s\nDeletionDate=%s\n",
+		(&url.URL{Path: originalPath}).EscapedPath(), time.Now().Format("2006-01-02T15:04:05"))
+
+	for i := 1; i < 10000; i++ {
+		name := base
+		if i > 1 {
+			name = fmt.Sprintf("%s.%d", base, i)
+		}
+		if _, err := os.Lstat(filepath.Join(filesDir, name)); err == nil {
+			continue
+		}
+
+		infoPath := filepath.Join(infoDir, name+".trashinfo")
+		file, err := os.OpenFile(infoPath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0600)
+		if errors.Is(err, os.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", "", fmt.Errorf("cannot write trash info: %w", err)
+		}
+		_, err = file.WriteString(info)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			os.Remove(infoPath)
+			return "", "", fmt.Errorf("cannot write trash info: %w", err)
+		}
+		return name, infoPath, nil
+	}
+	return "", "", fmt.Errorf("cannot find a free name for %s in the Trash", base)
+}
+
+// trashDirs returns the directories holding the trashed items and their .trashinfo files
+func trashDirs() ([]string, error) {
+	trashDir, err := xdgTrashDir()
+	if err != nil {
+		return nil, err
+	}
+	return []string{filepath.Join(trashDir, "files"), filepath.Join(trashDir, "info")}, nil
+}
+
+// emptyTrash permanently removes everything in the home trash
+func emptyTrash() error {
+	dirs, err := trashDirs()
+	if err != nil {
+		return err
+	}
+	return removeContents(dirs)
+}
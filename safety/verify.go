@@ -0,0 +1,38 @@
+package safety
+
+import (
+	"os"
+	"sort"
+)
+
+// Leftover is a path that was reported deleted but is still on disk
+type Leftover struct {
+	Path    string
+	Claimed int64 // Bytes the deleter reported freeing
+	Remains int64 // Bytes still found at the path
+}
+
+// VerifyDeleted re-stats each deleted path (mapped to the bytes its deletion
+// claimed to free) and returns the ones that still exist
+// A successful Trash move can leave the item behind when permissions or the
+// Finder get in the way, so this catches deletions that did not happen
+func VerifyDeleted(claimed map[string]int64) []Leftover {
+	var leftovers []Leftover
+	for path, bytes := range claimed {
+		info, err := os.Lstat(path)
+		if err != nil {
+			continue // Gone, or no longer reachable to check
+		}
+
+		remains := info.Size()
+		if info.IsDir() {
+			remains, _ = calculateDirSize(path)
+		}
+		leftovers = append(leftovers, Leftover{Path: path, Claimed: bytes, Remains: remains})
+	}
+
+	sort.Slice(leftovers, func(i, j int) bool {
+		return leftovers[i].Path < leftovers[j].Path
+	})
+	return leftovers
+}
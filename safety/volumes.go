@@ -6,42 +6,66 @@ import (
 	"syscall"
 )
 
+// NetworkMode is how a VolumeChecker treats network volumes and cloud-backed directories
+type NetworkMode int
+
+const (
+	NetworkSkip NetworkMode = iota // Leave them out of the scan (the default)
+	NetworkScan                    // Scan them like local paths
+	NetworkFlag                    // Scan them, but classify them as remote so nodes can be marked
+)
+
+// PathClass is ShouldSkipPath's verdict on a path
+type PathClass int
+
+const (
+	PathLocal  PathClass = iota // Scan normally
+	PathRemote                  // Network or cloud-backed, scanned and flagged (NetworkFlag)
+	PathSkip                    // Network or cloud-backed, not scanned (NetworkSkip)
+)
+
 // VolumeChecker detects network and special volumes
 type VolumeChecker struct {
-	skipNetwork bool
+	mode   NetworkMode
+	mounts mountTable // Filesystem types by mount point, read once per checker
 }
 
 // NewVolumeChecker creates a new volume checker
-func NewVolumeChecker(skipNetwork bool) *VolumeChecker {
+func NewVolumeChecker(mode NetworkMode) *VolumeChecker {
 	return &VolumeChecker{
-		skipNetwork: skipNetwork,
+		mode: mode,
 	}
 }
 
-// ShouldSkipPath checks if a path should be skipped during scanning
-func (vc *VolumeChecker) ShouldSkipPath(path string) (bool, string) {
+// ShouldSkipPath classifies a path for scanning
+// The reason names the network filesystem or cloud service for remote and skipped paths
+func (vc *VolumeChecker) ShouldSkipPath(path string) (PathClass, string) {
+	if vc.mode == NetworkScan {
+		return PathLocal, ""
+	}
+
+	remote := PathSkip
+	if vc.mode == NetworkFlag {
+		remote = PathRemote
+	}
+
 	// Check for cloud-backed directories (iCloud, etc.)
-	if vc.skipNetwork {
-		if isCloud, reason := isCloudBackedPath(path); isCloud {
-			return true, reason
-		}
+	if isCloud, reason := isCloudBackedPath(path); isCloud {
+		return remote, reason
 	}
 
 	// Check if it's a network volume
-	if vc.skipNetwork {
-		isNetwork, fsType := vc.isNetworkVolume(path)
-		if isNetwork {
-			return true, "network volume (" + fsType + ")"
-		}
+	if isNetwork, fsType := vc.isNetworkVolume(path); isNetwork {
+		return remote, "network volume (" + fsType + ")"
 	}
 
-	return false, ""
+	return PathLocal, ""
 }
 
 // isCloudBackedPath checks if a path is cloud-backed (iCloud Drive, etc.)
 func isCloudBackedPath(path string) (bool, string) {
 	// Get user's home directory
-	homeDir, err := os.UserHomeDir()
+	homeDir, err := HomeDir()
 	if err != nil {
 		return false, ""
 	}
@@ -79,33 +103,26 @@ func isCloudBackedPath(path string) (bool, string) {
 
 // isNetworkVolume checks if a path is on a network filesystem
 func (vc *VolumeChecker) isNetworkVolume(path string) (bool, string) {
-	var stat syscall.Statfs_t
-	err := syscall.Statfs(path, &stat)
+	// Looked up in the mount table, not with a statfs per path
+	fsTypeName, err := vc.mounts.filesystemType(path)
 	if err != nil {
 		// If we can't stat it, assume it's safe to try
 		return false, ""
 	}
 
-	// Get filesystem type name from the statfs structure
-	// On macOS, f_fstypename is a [16]int8 array
-	fsTypeBytes := make([]byte, len(stat.Fstypename))
-	for i, v := range stat.Fstypename {
-		fsTypeBytes[i] = byte(v)
-	}
-	fsTypeName := string(fsTypeBytes)
-	// Trim null bytes
-	fsTypeName = strings.TrimRight(fsTypeName, "\x00")
-
 	// Check for network filesystem types
 	networkFSTypes := []string{
-		"nfs",      // Network File System
-		"smbfs",    // SMB/CIFS (Windows shares)
-		"afpfs",    // Apple Filing Protocol
-		"cifs",     // Common Internet File System
-		"webdav",   // WebDAV
-		"ftp",      // FTP mounts
-		"davfs",    // DAV filesystem
-		"mtpfs",    // MTP (Android devices)
+		"nfs",        // Network File System
+		"smbfs",      // SMB/CIFS (Windows shares)
+		"afpfs",      // Apple Filing Protocol
+		"cifs",       // Common Internet File System
+		"webdav",     // WebDAV
+		"ftp",        // FTP mounts
+		"davfs",      // DAV filesystem
+		"mtpfs",      // MTP (Android devices)
+		"nfs4",       // NFSv4 (Linux)
+		"smb3",       // SMB3 (Linux)
+		"fuse.sshfs", // SSHFS (Linux)
 	}
 
 	fsTypeLower := strings.ToLower(fsTypeName)
@@ -163,7 +180,7 @@ func GetLocalVolumes() []VolumeInfo {
 		}
 	}
 
-	checker := NewVolumeChecker(true)
+	checker := NewVolumeChecker(NetworkSkip)
 	for _, path := range localPaths {
 		// Check if path exists
 		if _, err := os.Stat(path); err != nil {
@@ -180,12 +197,7 @@ func GetLocalVolumes() []VolumeInfo {
 			size = int64(stat.Blocks) * int64(stat.Bsize)
 			available = int64(stat.Bavail) * int64(stat.Bsize)
 			if fsType == "" {
-				fsTypeBytes := make([]byte, len(stat.Fstypename))
-				for i, v := range stat.Fstypename {
-					fsTypeBytes[i] = byte(v)
-				}
-				fsType = string(fsTypeBytes)
-				fsType = strings.TrimRight(fsType, "\x00")
+				fsType, _ = checker.mounts.filesystemType(path)
 			}
 		}
 
@@ -0,0 +1,38 @@
+// Package scanner walks a directory tree and builds an in-memory model of it, with
+// the size, allocated size, type and modification time of every entry. It has no
+// dependency on the TUI and can be used on its own:
+//
+//	scn := scanner.NewScanner()
+//	scn.SetExclusions([]string{"**/node_modules"})
+//
+//	root, err := scn.Scan(context.Background(), "/Users/me/Projects", nil)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	stats := scanner.CalculateStats(root)
+//	fmt.Printf("%d files, %d directories, %d bytes\n", stats.FileCount, stats.DirCount, stats.TotalSize)
+//	for _, file := range stats.LargestFiles[:min(10, len(stats.LargestFiles))] {
+//		fmt.Printf("%12d  %s\n", file.TotalSize(), file.Path)
+//	}
+//	for ext, types := range stats.TypeBreakdown {
+//		fmt.Printf("%-10s %6d files %12d bytes\n", ext, types.FileCount, types.TotalSize)
+//	}
+//
+// To follow a long scan, pass a buffered channel and read it from another goroutine;
+// Scan closes it when it returns:
+//
+//	progress := make(chan scanner.ScanProgress, 100)
+//	go func() {
+//		for p := range progress {
+//			fmt.Printf("\r%d files, %d bytes", p.FilesScanned, p.BytesScanned)
+//		}
+//	}()
+//	root, err := scn.Scan(ctx, path, progress)
+//
+// The Set methods on Scanner configure a scan before it starts (network volumes,
+// filesystem boundaries, exclusions, .gitignore, hard links, depth and size limits,
+// symlinks, hidden files, bundles, low-memory mode and directory sizes). FlattenTree
+// lists every node for sorting or filtering, and FileNode.SetUseAllocatedSize switches
+// the size calculations of one tree between apparent (ls -l) and allocated (du) sizes.
+package scanner
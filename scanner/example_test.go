@@ -0,0 +1,71 @@
+package scanner_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"spaceforce/scanner"
+)
+
+// Scan a directory without the TUI and summarize it with CalculateStats
+func Example() {
+	dir, err := os.MkdirTemp("", "scanner-example")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]int{
+		"notes.txt":                       1200,
+		"photos/beach.jpg":                48000,
+		"photos/city.jpg":                 32000,
+		"project/main.go":                 900,
+		"project/node_modules/lib/big.js": 500000,
+	}
+	for name, size := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			log.Fatal(err)
+		}
+		if err := os.WriteFile(path, make([]byte, size), 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	scn := scanner.NewScanner()
+	scn.SetExclusions([]string{"**/node_modules"})
+
+	root, err := scn.Scan(context.Background(), dir, nil)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	stats := scanner.CalculateStats(root)
+	fmt.Printf("%d files, %d bytes\n", stats.FileCount, stats.TotalSize)
+	for _, file := range stats.LargestFiles[:min(2, len(stats.LargestFiles))] {
+		rel, _ := filepath.Rel(dir, file.Path)
+		fmt.Printf("%6d  %s\n", file.TotalSize(), filepath.ToSlash(rel))
+	}
+
+	exts := make([]string, 0, len(stats.TypeBreakdown))
+	for ext := range stats.TypeBreakdown {
+		exts = append(exts, ext)
+	}
+	sort.Strings(exts)
+	for _, ext := range exts {
+		types := stats.TypeBreakdown[ext]
+		fmt.Printf("%-5s %d file(s) %6d bytes\n", ext, types.FileCount, types.TotalSize)
+	}
+
+	// Output:
+	// 4 files, 82100 bytes
+	//  48000  photos/beach.jpg
+	//  32000  photos/city.jpg
+	// .go   1 file(s)    900 bytes
+	// .jpg  2 file(s)  80000 bytes
+	// .txt  1 file(s)   1200 bytes
+}
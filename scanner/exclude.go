@@ -0,0 +1,74 @@
+package scanner
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// matchesExclusion reports whether path matches a user exclusion pattern
+// Patterns starting with / are matched against the full path
+// Other patterns are matched against the trailing components of the path,
+// so "node_modules", "*/Caches" and "**/node_modules" all work as expected
+func matchesExclusion(path string, pattern string) bool {
+	pattern = strings.TrimSuffix(pattern, "/")
+	if pattern == "" {
+		return false
+	}
+
+	pathParts := strings.Split(strings.Trim(path, "/"), "/")
+	patternParts := strings.Split(strings.Trim(pattern, "/"), "/")
+
+	if strings.HasPrefix(pattern, "/") {
+		return matchSegments(patternParts, pathParts)
+	}
+
+	// Relative pattern: try every suffix of the path
+	for i := range pathParts {
+		if matchSegments(patternParts, pathParts[i:]) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchPathPattern reports whether path matches pattern using the -exclude syntax
+// (leading / anchors to the full path, otherwise trailing components; ** spans directories)
+func MatchPathPattern(path string, pattern string) bool {
+	return matchesExclusion(path, pattern)
+}
+
+// matchSegments matches glob segments against path segments
+// A "**" segment matches zero or more path segments
+func matchSegments(pattern []string, parts []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			rest := pattern[1:]
+			for i := 0; i <= len(parts); i++ {
+				if matchSegments(rest, parts[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(parts) == 0 {
+			return false
+		}
+		if matched, err := filepath.Match(pattern[0], parts[0]); err != nil || !matched {
+			return false
+		}
+		pattern = pattern[1:]
+		parts = parts[1:]
+	}
+	return len(parts) == 0
+}
+
+// isExcluded checks the path against all user exclusion patterns
+func (s *Scanner) isExcluded(path string) bool {
+	for _, pattern := range s.exclusions {
+		if matchesExclusion(path, pattern) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,91 @@
+package scanner
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"unicode/utf8"
+)
+
+// NoExtensionType is the FileType of files whose name has no extension
+const NoExtensionType = "no-extension"
+
+// typeHeaderSize is how much of a file DetectType reads
+const typeHeaderSize = 512
+
+// typeSignature is a magic number found at offset in a file's header
+type typeSignature struct {
+	offset int
+	magic  []byte
+	name   string
+}
+
+// typeSignatures are checked in order; the first match names the type
+var typeSignatures = []typeSignature{
+	{0, []byte("\x7fELF"), "ELF binary"},
+	{0, []byte{0xfe, 0xed, 0xfa, 0xce}, "Mach-O binary"},
+	{0, []byte{0xfe, 0xed, 0xfa, 0xcf}, "Mach-O binary"},
+	{0, []byte{0xce, 0xfa, 0xed, 0xfe}, "Mach-O binary"},
+	{0, []byte{0xcf, 0xfa, 0xed, 0xfe}, "Mach-O binary"},
+	{0, []byte{0xca, 0xfe, 0xba, 0xbe}, "Mach-O universal"},
+	{0, []byte("SQLite format 3\x00"), "SQLite database"},
+	{4, []byte("ftyp"), "MP4/QuickTime media"},
+	{0, []byte{0x1a, 0x45, 0xdf, 0xa3}, "Matroska/WebM video"},
+	{0, []byte("RIFF"), "RIFF media (AVI/WAV)"},
+	{0, []byte("PK\x03\x04"), "ZIP archive"},
+	{0, []byte{0x1f, 0x8b}, "gzip archive"},
+	{0, []byte("BZh"), "bzip2 archive"},
+	{0, []byte{0xfd, '7', 'z', 'X', 'Z', 0x00}, "xz archive"},
+	{0, []byte{'7', 'z', 0xbc, 0xaf, 0x27, 0x1c}, "7z archive"},
+	{0, []byte("%PDF"), "PDF document"},
+	{0, []byte("\x89PNG"), "PNG image"},
+	{0, []byte{0xff, 0xd8, 0xff}, "JPEG image"},
+	{0, []byte("bplist"), "Binary plist"},
+	{0, []byte("#!"), "Script"},
+}
+
+// DetectType names the format of a file from the magic bytes in its first few hundred bytes
+// Meant for files without an extension. Header bytes that are all valid UTF-8 text
+// give "Text"; returns "" if the file cannot be read or the format is not recognized
+func DetectType(path string) string {
+	file, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	header := make([]byte, typeHeaderSize)
+	n, err := io.ReadFull(file, header)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return ""
+	}
+	header = header[:n]
+
+	for _, signature := range typeSignatures {
+		end := signature.offset + len(signature.magic)
+		if end <= len(header) && bytes.Equal(header[signature.offset:end], signature.magic) {
+			return signature.name
+		}
+	}
+
+	if isText(header) {
+		return "Text"
+	}
+	return ""
+}
+
+// isText reports whether header looks like text: no NUL bytes and valid UTF-8
+// A multi-byte character cut off at the end of the header is allowed
+func isText(header []byte) bool {
+	if bytes.IndexByte(header, 0) >= 0 {
+		return false
+	}
+	for len(header) > 0 {
+		r, size := utf8.DecodeRune(header)
+		if r == utf8.RuneError && size == 1 {
+			return len(header) < utf8.UTFMax && !utf8.FullRune(header)
+		}
+		header = header[size:]
+	}
+	return true
+}
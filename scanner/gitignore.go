@@ -0,0 +1,132 @@
+package scanner
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// gitignoreRule is a single parsed line from a .gitignore file
+type gitignoreRule struct {
+	segments []string // Pattern split on "/"
+	negate   bool     // Line started with "!"
+	dirOnly  bool     // Line ended with "/"
+	anchored bool     // Pattern contains a "/" so it is relative to the .gitignore directory
+}
+
+// gitignore holds the rules of one .gitignore file plus those inherited from parent directories
+// Supports the common subset of gitignore syntax: comments, negation, directory-only
+// patterns, anchored patterns and "**"
+type gitignore struct {
+	base   string // Directory containing the .gitignore
+	rules  []gitignoreRule
+	parent *gitignore
+}
+
+// parseGitignoreLine parses a single .gitignore line (ok is false for blanks and comments)
+func parseGitignoreLine(line string) (gitignoreRule, bool) {
+	line = strings.TrimRight(line, " \t\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return gitignoreRule{}, false
+	}
+
+	rule := gitignoreRule{}
+	if strings.HasPrefix(line, "!") {
+		rule.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\`) {
+		// Escaped leading "!" or "#"
+		line = line[1:]
+	}
+
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return gitignoreRule{}, false
+	}
+
+	// A slash anywhere but the end anchors the pattern; a leading "**/" does not
+	rule.anchored = strings.Contains(line, "/") && !strings.HasPrefix(line, "**/")
+	line = strings.TrimPrefix(line, "/")
+	rule.segments = strings.Split(line, "/")
+
+	return rule, true
+}
+
+// loadGitignore reads dir/.gitignore and chains it onto parent
+// Returns parent unchanged if the directory has no (readable) .gitignore
+func loadGitignore(dir string, parent *gitignore) *gitignore {
+	file, err := os.Open(filepath.Join(dir, ".gitignore"))
+	if err != nil {
+		return parent
+	}
+	defer file.Close()
+
+	rules := make([]gitignoreRule, 0)
+	lines := bufio.NewScanner(file)
+	for lines.Scan() {
+		if rule, ok := parseGitignoreLine(lines.Text()); ok {
+			rules = append(rules, rule)
+		}
+	}
+
+	if len(rules) == 0 {
+		return parent
+	}
+
+	return &gitignore{
+		base:   dir,
+		rules:  rules,
+		parent: parent,
+	}
+}
+
+// isIgnored reports whether path should be skipped according to this and all parent .gitignores
+func (g *gitignore) isIgnored(path string, isDir bool) bool {
+	if g == nil {
+		return false
+	}
+	return g.match(path, isDir, false)
+}
+
+// match applies rules outermost-first so that deeper files and later lines win
+func (g *gitignore) match(path string, isDir bool, ignored bool) bool {
+	if g.parent != nil {
+		ignored = g.parent.match(path, isDir, ignored)
+	}
+
+	rel, err := filepath.Rel(g.base, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return ignored
+	}
+	relParts := strings.Split(filepath.ToSlash(rel), "/")
+
+	for _, rule := range g.rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+		if rule.matches(relParts) {
+			ignored = !rule.negate
+		}
+	}
+
+	return ignored
+}
+
+// matches checks a rule against a path relative to the .gitignore directory
+func (r gitignoreRule) matches(relParts []string) bool {
+	if r.anchored {
+		return matchSegments(r.segments, relParts)
+	}
+
+	// Unanchored patterns match at any depth
+	for i := range relParts {
+		if matchSegments(r.segments, relParts[i:]) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package scanner
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// sizedFile is a file with its size, computed once when it is offered
+type sizedFile struct {
+	node *FileNode
+	size int64
+}
+
+// fileHeap is a min-heap of files by size; the smallest kept file is at index 0
+type fileHeap []sizedFile
+
+func (h fileHeap) Len() int           { return len(h) }
+func (h fileHeap) Less(i, j int) bool { return h[i].size < h[j].size }
+func (h fileHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *fileHeap) Push(x interface{}) {
+	*h = append(*h, x.(sizedFile))
+}
+
+func (h *fileHeap) Pop() interface{} {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return last
+}
+
+// largestFiles keeps the limit largest files offered to it, so a walk over millions of
+// files holds only the current top k: O(n log k) instead of sorting the whole list
+type largestFiles struct {
+	limit int // Files to keep (<= 0 keeps every file)
+	files fileHeap
+}
+
+// add offers a file, keeping it if it is among the largest seen so far
+func (l *largestFiles) add(node *FileNode) {
+	file := sizedFile{node: node, size: node.TotalSize()}
+	if l.limit <= 0 {
+		l.files = append(l.files, file)
+		return
+	}
+	if len(l.files) < l.limit {
+		heap.Push(&l.files, file)
+		return
+	}
+	if file.size <= l.files[0].size {
+		return
+	}
+	l.files[0] = file
+	heap.Fix(&l.files, 0)
+}
+
+// sorted returns the kept files, largest first
+func (l *largestFiles) sorted() []*FileNode {
+	sort.Slice(l.files, func(i, j int) bool {
+		return l.files[i].size > l.files[j].size
+	})
+	nodes := make([]*FileNode, len(l.files))
+	for i, file := range l.files {
+		nodes[i] = file.node
+	}
+	return nodes
+}
@@ -0,0 +1,99 @@
+package scanner
+
+import (
+	"container/heap"
+)
+
+// lowMemoryKeptFiles is how many of the largest files keep a node in low-memory mode
+const lowMemoryKeptFiles = 10000
+
+// DroppedType totals the files of one type folded into a directory
+type DroppedType struct {
+	Size      int64
+	Allocated int64
+	Count     int64
+}
+
+// size returns the folded bytes, allocated or apparent
+func (d *DroppedType) size(allocated bool) int64 {
+	if allocated {
+		return d.Allocated
+	}
+	return d.Size
+}
+
+// sizeHeap is a min-heap of file sizes; the smallest kept size is at index 0
+type sizeHeap []int64
+
+func (h sizeHeap) Len() int           { return len(h) }
+func (h sizeHeap) Less(i, j int) bool { return h[i] < h[j] }
+func (h sizeHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *sizeHeap) Push(x interface{}) {
+	*h = append(*h, x.(int64))
+}
+
+func (h *sizeHeap) Pop() interface{} {
+	old := *h
+	last := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return last
+}
+
+// SetLowMemory keeps nodes only for directories and the largest files, for volumes with
+// tens of millions of files. Other files are folded into their directory's totals and
+// per-type counts as they are found, so sizes and the type breakdown stay correct
+func (s *Scanner) SetLowMemory(enabled bool) {
+	s.lowMemory = enabled
+}
+
+// keepFile reports whether a file of size is among the largest found so far, and
+// records it if so. A file kept early may drop out later; pruneFiles folds those
+func (s *Scanner) keepFile(size int64) bool {
+	s.keptMu.Lock()
+	defer s.keptMu.Unlock()
+
+	if len(s.keptSizes) < lowMemoryKeptFiles {
+		heap.Push(&s.keptSizes, size)
+		return true
+	}
+	if size <= s.keptSizes[0] {
+		return false
+	}
+	s.keptSizes[0] = size
+	heap.Fix(&s.keptSizes, 0)
+	return true
+}
+
+// keptThreshold returns the smallest size among the largest files (0 until there are enough)
+func (s *Scanner) keptThreshold() int64 {
+	s.keptMu.Lock()
+	defer s.keptMu.Unlock()
+
+	if len(s.keptSizes) < lowMemoryKeptFiles {
+		return 0
+	}
+	return s.keptSizes[0]
+}
+
+// pruneFiles folds kept files smaller than threshold into their directories
+// Only call it once no worker is adding to the tree
+func (s *Scanner) pruneFiles(node *FileNode, threshold int64) {
+	kept := node.Children[:0]
+	for _, child := range node.Children {
+		switch {
+		case child.IsDir:
+			s.pruneFiles(child, threshold)
+		case !child.IsBundle && child.Size < threshold:
+			node.foldFile(child.FileType, child.Size, child.AllocatedSize)
+			continue
+		}
+		kept = append(kept, child)
+	}
+
+	// Clear the tail so folded nodes can be collected
+	for i := len(kept); i < len(node.Children); i++ {
+		node.Children[i] = nil
+	}
+	node.Children = kept
+}
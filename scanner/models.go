@@ -2,20 +2,36 @@ package scanner
 
 import (
 	"path/filepath"
+	"sync/atomic"
 	"time"
 )
 
 // FileNode represents a file or directory in the filesystem tree
 type FileNode struct {
-	Path         string
-	Name         string
-	Size         int64
-	IsDir        bool
-	ModTime      time.Time
-	Children     []*FileNode
-	Parent       *FileNode
-	FileType     string // Extension or "directory"
-	IsProtected  bool   // Whether this file is protected from deletion
+	Path          string
+	Name          string
+	Size          int64
+	IsDir         bool
+	ModTime       time.Time
+	Children      []*FileNode
+	Parent        *FileNode // Set by AddChild; nil only for the scan root
+	FileType      string    // Extension or "directory"
+	IsProtected   bool      // Whether this file is protected from deletion
+	IsHardLinkDup bool      // Another hard link to an already-counted file (Size is 0)
+	AllocatedSize int64     // On-disk size (st_blocks * 512), differs from Size for sparse/compressed files
+	Truncated     bool      // Directory not descended into (scan depth limit); sized by its own entry only
+	IsBundle      bool      // .app/.framework/.bundle directory recorded as one file sized by its contents
+	IsNetwork     bool      // On a network volume or cloud-backed directory (scanned with -flag-network)
+	IsSymlink     bool      // A symbolic link (sized as the link itself unless symlinks are followed)
+	LinkTarget    string    // Absolute path the symlink points to ("" if unreadable)
+
+	// Files below the minimum size folded into this directory (SetDropTinyNodes)
+	DroppedSize      int64
+	DroppedAllocated int64
+	DroppedCount     int64
+	DroppedTypes     map[string]*DroppedType // Folded files by type
+
+	sizing *sizing // Size mode of the tree (nil = apparent sizes, contents only)
 }
 
 // DirStats holds aggregate statistics for a directory
@@ -23,7 +39,7 @@ type DirStats struct {
 	TotalSize      int64
 	FileCount      int64
 	DirCount       int64
-	LargestFiles   []*FileNode
+	LargestFiles   []*FileNode // Largest first, capped (see CalculateStatsN)
 	TypeBreakdown  map[string]*TypeStats
 }
 
@@ -39,62 +55,171 @@ type TypeStats struct {
 type ScanProgress struct {
 	CurrentPath        string
 	FilesScanned       int64
+	DirsScanned        int64 // Directories added to the tree (also counted in FilesScanned)
 	BytesScanned       int64
 	TotalBytes         int64  // Estimated total bytes to scan
 	Errors             []error
 	Complete           bool
 	ICloudFilesSkipped int64 // Count of .icloud placeholder files skipped
+	GitignoreSkipped   int64 // Count of entries skipped because of .gitignore rules
+	HiddenSkipped      int64 // Count of hidden (dot-named) entries skipped
+	HardLinksDeduped   int64 // Count of extra hard links counted at size 0
 }
 
-// NewFileNode creates a new file node
-func NewFileNode(path string, size int64, isDir bool, modTime time.Time) *FileNode {
-	name := filepath.Base(path)
+// sizing is the size mode shared by every node of one scanned tree, so switching it
+// affects only that tree
+type sizing struct {
+	allocated atomic.Bool // Allocated (du) rather than apparent (ls -l) sizes
+	dirSize   bool        // Directories count their own size (the directory file holding their entries)
+}
+
+// useAllocated reports whether sizes are allocated sizes (apparent for a nil sizing)
+func (z *sizing) useAllocated() bool {
+	return z != nil && z.allocated.Load()
+}
+
+// includeDirSize reports whether directories count their own size on top of their contents
+func (z *sizing) includeDirSize() bool {
+	return z != nil && z.dirSize
+}
+
+// fileTypeOf returns the FileType for a path: its extension, "directory" or NoExtensionType
+func fileTypeOf(path string, isDir bool) string {
 	ext := filepath.Ext(path)
 	if ext == "" {
 		if isDir {
 			ext = "directory"
 		} else {
-			ext = "no-extension"
+			ext = NoExtensionType
 		}
 	}
+	return ext
+}
 
+// NewFileNode creates a new file node
+func NewFileNode(path string, size int64, isDir bool, modTime time.Time) *FileNode {
 	return &FileNode{
 		Path:     path,
-		Name:     name,
+		Name:     filepath.Base(path),
 		Size:     size,
 		IsDir:    isDir,
 		ModTime:  modTime,
 		Children: make([]*FileNode, 0),
-		FileType: ext,
+		FileType: fileTypeOf(path, isDir),
 	}
 }
 
 // AddChild adds a child node and updates the parent reference
+// The child and its subtree take on the parent's size mode
 func (n *FileNode) AddChild(child *FileNode) {
 	child.Parent = n
 	n.Children = append(n.Children, child)
+	if child.sizing != n.sizing {
+		child.setSizing(n.sizing)
+	}
+}
+
+// setSizing gives this node and everything below it the size mode z
+func (n *FileNode) setSizing(z *sizing) {
+	n.sizing = z
+	for _, child := range n.Children {
+		child.setSizing(z)
+	}
+}
+
+// SetUseAllocatedSize chooses between apparent (ls -l) and allocated (du) sizes for the
+// whole tree this node belongs to; other trees keep their own size mode
+func (n *FileNode) SetUseAllocatedSize(allocated bool) {
+	root := n
+	for root.Parent != nil {
+		root = root.Parent
+	}
+	if root.sizing == nil {
+		root.setSizing(&sizing{})
+	}
+	root.sizing.allocated.Store(allocated)
+}
+
+// UsesAllocatedSize reports whether this node's tree shows allocated (on-disk) sizes
+func (n *FileNode) UsesAllocatedSize() bool {
+	return n.sizing.useAllocated()
+}
+
+// FileSize returns this node's own size in its tree's size mode (apparent or allocated)
+func (n *FileNode) FileSize() int64 {
+	if n.sizing.useAllocated() {
+		return n.AllocatedSize
+	}
+	return n.Size
 }
 
 // TotalSize recursively calculates the total size including all children
 func (n *FileNode) TotalSize() int64 {
-	if !n.IsDir {
-		return n.Size
+	if !n.IsDir || n.Truncated {
+		return n.FileSize()
 	}
 
-	total := int64(0)
+	total := n.droppedSize()
+	if n.sizing.includeDirSize() {
+		total += n.FileSize()
+	}
 	for _, child := range n.Children {
 		total += child.TotalSize()
 	}
 	return total
 }
 
+// TotalAllocatedSize returns the on-disk size of the tree, whichever size mode is active
+func (n *FileNode) TotalAllocatedSize() int64 {
+	if !n.IsDir || n.Truncated {
+		return n.AllocatedSize
+	}
+
+	total := n.DroppedAllocated
+	if n.sizing.includeDirSize() {
+		total += n.AllocatedSize
+	}
+	for _, child := range n.Children {
+		total += child.TotalAllocatedSize()
+	}
+	return total
+}
+
+// foldFile adds a file that gets no node of its own to this directory's totals, and to
+// DroppedTypes so the stats and type breakdown count it too
+func (n *FileNode) foldFile(fileType string, size int64, allocated int64) {
+	n.DroppedCount++
+	n.DroppedSize += size
+	n.DroppedAllocated += allocated
+
+	if n.DroppedTypes == nil {
+		n.DroppedTypes = make(map[string]*DroppedType)
+	}
+	dropped, exists := n.DroppedTypes[fileType]
+	if !exists {
+		dropped = &DroppedType{}
+		n.DroppedTypes[fileType] = dropped
+	}
+	dropped.Size += size
+	dropped.Allocated += allocated
+	dropped.Count++
+}
+
+// droppedSize returns the size of the files folded into this directory in its tree's size mode
+func (n *FileNode) droppedSize() int64 {
+	if n.sizing.useAllocated() {
+		return n.DroppedAllocated
+	}
+	return n.DroppedSize
+}
+
 // FileCount recursively counts all files in this tree
 func (n *FileNode) FileCount() int64 {
 	if !n.IsDir {
 		return 1
 	}
 
-	count := int64(0)
+	count := n.DroppedCount
 	for _, child := range n.Children {
 		count += child.FileCount()
 	}
@@ -0,0 +1,169 @@
+package scanner
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// PermissionDeniedPaths extracts the paths of permission-denied errors from scan errors
+func PermissionDeniedPaths(errs []error) []string {
+	paths := make([]string, 0)
+	for _, err := range errs {
+		if !errors.Is(err, fs.ErrPermission) {
+			continue
+		}
+		var pathErr *fs.PathError
+		if errors.As(err, &pathErr) {
+			paths = append(paths, pathErr.Path)
+		}
+	}
+	return paths
+}
+
+// RescanTarget is a directory to scan again, copied from the tree by RescanTargets so
+// the rescan itself never reads the tree
+type RescanTarget struct {
+	Path          string
+	size          int64
+	allocatedSize int64
+	modTime       time.Time
+	isNetwork     bool
+	depth         int
+}
+
+// RescanTargets looks up the directories among paths in the last scanned tree
+// Call it from the goroutine that owns the tree (the UI), like MergeSubtrees
+func (s *Scanner) RescanTargets(paths []string) []RescanTarget {
+	targets := make([]RescanTarget, 0, len(paths))
+	for _, path := range paths {
+		existing := s.findNode(path)
+		if existing == nil || !existing.IsDir {
+			continue
+		}
+		targets = append(targets, RescanTarget{
+			Path:          existing.Path,
+			size:          existing.Size,
+			allocatedSize: existing.AllocatedSize,
+			modTime:       existing.ModTime,
+			isNetwork:     existing.IsNetwork,
+			depth:         s.depthOf(existing.Path),
+		})
+	}
+	return targets
+}
+
+// RescanPaths scans the target directories of the last scan again, e.g. after Full Disk
+// Access was granted for paths that were denied the first time
+// Each directory is scanned into a new, detached node; pass the result to MergeSubtrees
+// to swap them into the tree. Errors previously recorded for these paths are dropped,
+// and any new ones are recorded as usual. It does not touch the tree, so it may run
+// in the background while the tree is shown.
+// .gitignore rules from parent directories are not reapplied
+func (s *Scanner) RescanPaths(ctx context.Context, targets []RescanTarget) []*FileNode {
+	targetPaths := make([]string, 0, len(targets))
+	for _, target := range targets {
+		targetPaths = append(targetPaths, target.Path)
+	}
+	s.forgetErrors(targetPaths)
+
+	rescanned := make([]*FileNode, 0, len(targets))
+	for _, target := range targets {
+		if ctx.Err() != nil {
+			break
+		}
+		node := NewFileNode(target.Path, target.size, true, target.modTime)
+		node.AllocatedSize = target.allocatedSize
+		node.IsNetwork = target.isNetwork
+		node.sizing = s.sizing
+		s.scanDirectorySequential(ctx, node, nil, target.depth, nil)
+		if s.lowMemory {
+			s.pruneFiles(node, s.keptThreshold())
+		}
+		rescanned = append(rescanned, node)
+	}
+	return rescanned
+}
+
+// MergeSubtrees replaces the contents of the matching directories in the scanned tree
+// Call it from the goroutine that owns the tree (the UI), not while views are rendering it
+func (s *Scanner) MergeSubtrees(nodes []*FileNode) {
+	for _, node := range nodes {
+		existing := s.findNode(node.Path)
+		if existing == nil {
+			continue
+		}
+		existing.Children = make([]*FileNode, 0, len(node.Children))
+		for _, child := range node.Children {
+			existing.AddChild(child)
+		}
+		existing.DroppedSize = node.DroppedSize
+		existing.DroppedAllocated = node.DroppedAllocated
+		existing.DroppedCount = node.DroppedCount
+		existing.DroppedTypes = node.DroppedTypes
+	}
+}
+
+// findNode returns the node for path in the last scanned tree, or nil
+func (s *Scanner) findNode(path string) *FileNode {
+	if s.root == nil {
+		return nil
+	}
+	rel, err := filepath.Rel(s.root.Path, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return nil
+	}
+
+	node := s.root
+	if rel == "." {
+		return node
+	}
+	for _, name := range strings.Split(rel, string(filepath.Separator)) {
+		var next *FileNode
+		for _, child := range node.Children {
+			if child.Name == name {
+				next = child
+				break
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		node = next
+	}
+	return node
+}
+
+// depthOf returns how many levels below the scan root path is
+func (s *Scanner) depthOf(path string) int {
+	rel, err := filepath.Rel(s.root.Path, path)
+	if err != nil || rel == "." {
+		return 0
+	}
+	return len(strings.Split(rel, string(filepath.Separator)))
+}
+
+// forgetErrors drops recorded errors for the given paths
+// A new slice is built so copies of the progress held by the UI are not modified
+func (s *Scanner) forgetErrors(paths []string) {
+	forget := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		forget[path] = true
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	kept := make([]error, 0, len(s.progress.Errors))
+	for _, err := range s.progress.Errors {
+		var pathErr *fs.PathError
+		if errors.As(err, &pathErr) && forget[pathErr.Path] {
+			continue
+		}
+		kept = append(kept, err)
+	}
+	s.progress.Errors = kept
+}
@@ -2,12 +2,14 @@ package scanner
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
-	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -18,6 +20,9 @@ const (
 	// dirReadTimeout is the maximum time to wait for a directory read
 	// If a directory takes longer than this, it's likely on a slow/stuck network volume
 	dirReadTimeout = 5 * time.Second
+
+	// pausePollInterval is how often paused workers check whether to resume
+	pausePollInterval = 100 * time.Millisecond
 )
 
 // Scanner handles filesystem scanning operations
@@ -28,12 +33,31 @@ type Scanner struct {
 	lastProgressUpdate int64
 	volumeChecker     *safety.VolumeChecker
 	skippedVolumes    []string
+	skippedPaths      []SkippedPath // Categorized record of every skip (for the manifest)
 	volumesMu         sync.Mutex
 	workerSem         chan struct{} // Semaphore to limit concurrent workers
 	startDeviceID     uint64        // Device ID of the starting directory
 	oneFilesystem     bool          // Stay on one filesystem (like du -x)
 	seenInodes        map[uint64]map[uint64]bool // device_id -> inode -> seen (for deduplication)
 	seenInodesMu      sync.Mutex
+	exclusions        []string // User glob patterns to skip entirely
+	respectGitignore  bool     // Skip entries ignored by .gitignore files
+	countHardlinks    bool     // Count every hard link to a file at full size
+	maxDepth          int      // Deepest level to descend into (0 = unlimited)
+	minFileSize       int64    // Files smaller than this are left out of the tree
+	dropTinyNodes     bool     // Fold files under minFileSize into their directory's totals
+	followSymlinks    bool     // Stat symlink targets and descend into symlinked directories
+	skipHidden        bool     // Leave out entries whose name starts with "."
+	bundleAsFile      bool     // Record .app/.framework/.bundle directories as single items
+	lowMemory         bool     // Keep nodes only for directories and the largest files
+	keptSizes         sizeHeap // Sizes of the largest files kept so far (low-memory mode)
+	keptMu            sync.Mutex
+	paused            atomic.Bool // Workers wait before reading the next directory while set
+	readsMu           sync.Mutex
+	activeReads       map[string]activeRead // Directory reads in progress, by path
+	userSkips         map[string]bool       // Directories the user skipped (guarded by readsMu)
+	hasUserSkips      atomic.Bool           // Fast check before looking in userSkips
+	sizing            *sizing               // Size mode given to every tree this scanner builds
 }
 
 // NewScanner creates a new scanner instance
@@ -50,17 +74,21 @@ func NewScanner() *Scanner {
 		progress: &ScanProgress{
 			Errors: make([]error, 0),
 		},
-		volumeChecker:  safety.NewVolumeChecker(true), // Skip network by default
+		volumeChecker:  safety.NewVolumeChecker(safety.NetworkSkip), // Skip network by default
 		skippedVolumes: make([]string, 0),
 		workerSem:      make(chan struct{}, maxWorkers),
 		oneFilesystem:  true, // Stay on one filesystem by default (like du -x)
 		seenInodes:     make(map[uint64]map[uint64]bool),
+		activeReads:    make(map[string]activeRead),
+		userSkips:      make(map[string]bool),
+		sizing:         &sizing{},
 	}
 }
 
-// SetSkipNetwork sets whether to skip network volumes
-func (s *Scanner) SetSkipNetwork(skip bool) {
-	s.volumeChecker = safety.NewVolumeChecker(skip)
+// SetNetworkMode sets whether network volumes (and cloud-backed directories) are skipped,
+// scanned, or scanned with their nodes marked IsNetwork
+func (s *Scanner) SetNetworkMode(mode safety.NetworkMode) {
+	s.volumeChecker = safety.NewVolumeChecker(mode)
 }
 
 // SetOneFilesystem sets whether to stay on one filesystem (like du -x)
@@ -68,6 +96,118 @@ func (s *Scanner) SetOneFilesystem(oneFS bool) {
 	s.oneFilesystem = oneFS
 }
 
+// SetExclusions sets glob patterns for paths that should never be scanned
+// Patterns without a leading / match trailing path components (e.g. "node_modules", "*/Caches")
+func (s *Scanner) SetExclusions(patterns []string) {
+	s.exclusions = patterns
+}
+
+// SetRespectGitignore sets whether entries ignored by .gitignore files are skipped
+func (s *Scanner) SetRespectGitignore(respect bool) {
+	s.respectGitignore = respect
+}
+
+// SetCountHardlinks sets whether every hard link to a file counts at full size
+// By default only the first link seen is counted; later links are added with size 0
+func (s *Scanner) SetCountHardlinks(count bool) {
+	s.countHardlinks = count
+}
+
+// SetMaxDepth limits how many levels below the root are scanned (0 = unlimited)
+// Directories at the limit are kept as leaf nodes without children
+func (s *Scanner) SetMaxDepth(depth int) {
+	s.maxDepth = depth
+}
+
+// atDepthLimit reports whether a directory at the given depth should not be descended into
+func (s *Scanner) atDepthLimit(depth int) bool {
+	return s.maxDepth > 0 && depth >= s.maxDepth
+}
+
+// SetMinFileSize omits files smaller than size bytes from the tree (0 = keep all)
+// Directories are still created so the path structure survives
+func (s *Scanner) SetMinFileSize(size int64) {
+	s.minFileSize = size
+}
+
+// SetDropTinyNodes makes files under the minimum size count towards their directory's
+// totals without keeping a node for them, so totals stay correct while memory stays low
+func (s *Scanner) SetDropTinyNodes(drop bool) {
+	s.dropTinyNodes = drop
+}
+
+// foldTinyFile adds an omitted file's sizes to its directory (see SetDropTinyNodes and SetLowMemory)
+func (s *Scanner) foldTinyFile(dir *FileNode, info os.FileInfo) {
+	fileType := fileTypeOf(info.Name(), false)
+	if s.isDuplicateHardLink(info) {
+		dir.foldFile(fileType, 0, 0)
+		return
+	}
+	dir.foldFile(fileType, info.Size(), allocatedSize(info))
+}
+
+// SetFollowSymlinks sets whether symlinks are resolved to their targets
+// Symlinked directories are descended into; the seen-inode check stops cycles
+func (s *Scanner) SetFollowSymlinks(follow bool) {
+	s.followSymlinks = follow
+}
+
+// SetSkipHidden sets whether entries whose name starts with "." are left out of the scan
+// Only entries inside the scan are checked, so a hidden scan root is still scanned
+func (s *Scanner) SetSkipHidden(skip bool) {
+	s.skipHidden = skip
+}
+
+// SetIncludeDirSize chooses whether directories count their own size (the directory file
+// holding their entries) on top of their contents; normally a directory's size is only its contents
+func (s *Scanner) SetIncludeDirSize(include bool) {
+	s.sizing.dirSize = include
+}
+
+// SetBundleAsFile sets whether bundle directories (.app, .framework, .bundle) are recorded
+// as single items sized by their contents instead of being descended into
+func (s *Scanner) SetBundleAsFile(enabled bool) {
+	s.bundleAsFile = enabled
+}
+
+// Pause stops workers before they read their next directory (the partial tree is kept)
+func (s *Scanner) Pause() {
+	s.paused.Store(true)
+}
+
+// Resume lets paused workers continue
+func (s *Scanner) Resume() {
+	s.paused.Store(false)
+}
+
+// TogglePause pauses a running scan or resumes a paused one and returns the new state
+func (s *Scanner) TogglePause() bool {
+	for {
+		paused := s.paused.Load()
+		if s.paused.CompareAndSwap(paused, !paused) {
+			return !paused
+		}
+	}
+}
+
+// IsPaused reports whether the scan is paused
+func (s *Scanner) IsPaused() bool {
+	return s.paused.Load()
+}
+
+// waitWhilePaused blocks while the scan is paused
+// Returns false if the scan was cancelled while waiting
+func (s *Scanner) waitWhilePaused(ctx context.Context) bool {
+	for s.paused.Load() {
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(pausePollInterval):
+		}
+	}
+	return true
+}
+
 // GetSkippedVolumes returns the list of skipped network volumes
 func (s *Scanner) GetSkippedVolumes() []string {
 	s.volumesMu.Lock()
@@ -76,7 +216,17 @@ func (s *Scanner) GetSkippedVolumes() []string {
 }
 
 // Scan walks the filesystem starting from rootPath and builds a tree
+// Progress updates are sent to progressChan (which may be nil) without blocking, so
+// some are dropped; the last one, with Complete set, always arrives after a full scan.
+// Scan closes progressChan before it returns, whether the scan finished, failed or was
+// cancelled. On cancellation it returns the partial tree along with ctx.Err().
+// Unreadable entries do not fail the scan; they are listed in GetProgress().Errors.
+// A Scanner runs one scan; create a new one with NewScanner for each
 func (s *Scanner) Scan(ctx context.Context, rootPath string, progressChan chan<- ScanProgress) (*FileNode, error) {
+	if progressChan != nil {
+		defer close(progressChan)
+	}
+
 	// Normalize the path
 	absPath, err := filepath.Abs(rootPath)
 	if err != nil {
@@ -111,10 +261,25 @@ func (s *Scanner) Scan(ctx context.Context, rootPath string, progressChan chan<-
 
 	// Create root node
 	s.root = NewFileNode(absPath, info.Size(), info.IsDir(), info.ModTime())
+	s.root.AllocatedSize = allocatedSize(info)
+	s.root.sizing = s.sizing
+	if pathClass, _ := s.volumeChecker.ShouldSkipPath(absPath); pathClass == safety.PathRemote {
+		s.root.IsNetwork = true
+	}
+
+	// Mark the root as seen so a symlink pointing back at it is not scanned again
+	if devID, inode, err := getDeviceAndInode(absPath); err == nil {
+		s.markInodeSeen(devID, inode)
+	}
 
 	// Start scanning (parallel for better performance)
 	if info.IsDir() {
-		s.scanDirectoryParallel(ctx, s.root, progressChan, 0)
+		s.scanDirectoryParallel(ctx, s.root, progressChan, 0, nil)
+	}
+
+	// Fold files that were kept early in the scan but are no longer among the largest
+	if s.lowMemory {
+		s.pruneFiles(s.root, s.keptThreshold())
 	}
 
 	// Check if cancelled
@@ -128,22 +293,29 @@ func (s *Scanner) Scan(ctx context.Context, rootPath string, progressChan chan<-
 	// Mark complete
 	s.mu.Lock()
 	s.progress.Complete = true
+	final := *s.progress
 	s.mu.Unlock()
 
 	if progressChan != nil {
-		// Send final progress update
-		select {
-		case progressChan <- *s.progress:
-		default:
-		}
-		close(progressChan)
+		s.sendFinalProgress(ctx, final, progressChan)
 	}
 
 	return s.root, nil
 }
 
+// sendFinalProgress delivers the completion update even if the channel is saturated
+// Mid-scan updates may be dropped, but this one carries the final totals, so it
+// blocks until the receiver drains the buffer (or the scan is cancelled)
+func (s *Scanner) sendFinalProgress(ctx context.Context, final ScanProgress, progressChan chan<- ScanProgress) {
+	select {
+	case progressChan <- final:
+	case <-ctx.Done():
+	}
+}
+
 // scanDirectoryParallel scans directories in parallel (up to depth 2)
-func (s *Scanner) scanDirectoryParallel(ctx context.Context, node *FileNode, progressChan chan<- ScanProgress, depth int) {
+// ignore carries the .gitignore rules inherited from parent directories (nil if none)
+func (s *Scanner) scanDirectoryParallel(ctx context.Context, node *FileNode, progressChan chan<- ScanProgress, depth int, ignore *gitignore) {
 	// Check if cancelled before starting
 	select {
 	case <-ctx.Done():
@@ -151,6 +323,11 @@ func (s *Scanner) scanDirectoryParallel(ctx context.Context, node *FileNode, pro
 	default:
 	}
 
+	// Hold here while paused, before taking a worker slot
+	if !s.waitWhilePaused(ctx) {
+		return
+	}
+
 	// Acquire semaphore for this directory read (prevents too many concurrent I/O operations)
 	s.workerSem <- struct{}{}
 
@@ -159,12 +336,19 @@ func (s *Scanner) scanDirectoryParallel(ctx context.Context, node *FileNode, pro
 	// Release semaphore immediately after reading (before processing children)
 	<-s.workerSem
 
+	if errors.Is(err, errSkippedByUser) {
+		return // Already in the skip manifest; keep the node without children
+	}
 	if err != nil {
 		s.recordError(fmt.Errorf("cannot read directory %s: %w", node.Path, err))
 		// Don't return - continue with what we have
 		entries = []os.DirEntry{} // Empty, so we'll just add this node without children
 	}
 
+	if s.respectGitignore {
+		ignore = loadGitignore(node.Path, ignore)
+	}
+
 	// For shallow depths, scan subdirectories in parallel
 	if depth < 2 {
 		var wg sync.WaitGroup
@@ -179,6 +363,11 @@ func (s *Scanner) scanDirectoryParallel(ctx context.Context, node *FileNode, pro
 			default:
 			}
 
+			// Stop listing a directory the user skipped (children already found are kept)
+			if s.isUserSkipped(node.Path) {
+				break
+			}
+
 			entryName := entry.Name()
 			fullPath := filepath.Join(node.Path, entryName)
 
@@ -190,18 +379,41 @@ func (s *Scanner) scanDirectoryParallel(ctx context.Context, node *FileNode, pro
 				continue
 			}
 
+			// Skip hidden entries if requested
+			if s.skipHidden && strings.HasPrefix(entryName, ".") {
+				s.mu.Lock()
+				s.progress.HiddenSkipped++
+				s.mu.Unlock()
+				continue
+			}
+
 			// Update progress (throttled)
 		// (updateProgress moved after info is obtained)
 
+			// Check user exclusions before anything that touches the filesystem
+			if s.isExcluded(fullPath) {
+				s.recordSkip(fullPath, SkipUserExclusion, "user exclusion")
+				continue
+			}
+
+			// Skip entries ignored by .gitignore (opt-in)
+			if ignore.isIgnored(fullPath, entry.IsDir()) {
+				s.mu.Lock()
+				s.progress.GitignoreSkipped++
+				s.mu.Unlock()
+				s.recordSkip(fullPath, SkipGitignore, "ignored by .gitignore")
+				continue
+			}
+
 			// Check if we should skip this path (network volume check)
-			if shouldSkip, reason := s.volumeChecker.ShouldSkipPath(fullPath); shouldSkip {
-				s.volumesMu.Lock()
-				s.skippedVolumes = append(s.skippedVolumes, fullPath+" ("+reason+")")
-				s.volumesMu.Unlock()
+			pathClass, reason := s.volumeChecker.ShouldSkipPath(fullPath)
+			if pathClass == safety.PathSkip {
+				s.recordSkip(fullPath, volumeSkipCategory(reason), reason)
 				continue
 			}
+			remote := node.IsNetwork || pathClass == safety.PathRemote
 
-			info, err := entry.Info()
+			info, err := s.entryInfo(entry, fullPath)
 			if err != nil {
 				s.recordError(fmt.Errorf("cannot stat %s: %w", fullPath, err))
 				continue
@@ -217,35 +429,83 @@ func (s *Scanner) scanDirectoryParallel(ctx context.Context, node *FileNode, pro
 				if err == nil {
 					if s.hasSeenInode(devID, inode) {
 						// Already scanned this directory (it's an alias/firmlink)
-						s.volumesMu.Lock()
-						s.skippedVolumes = append(s.skippedVolumes, fullPath+" (alias/firmlink)")
-						s.volumesMu.Unlock()
+						s.recordSkip(fullPath, SkipAlias, "alias/firmlink")
 						continue
 					}
 					s.markInodeSeen(devID, inode)
 				}
 
 				if shouldSkip, reason := s.shouldSkipFilesystemBoundary(fullPath); shouldSkip {
-					s.volumesMu.Lock()
-					s.skippedVolumes = append(s.skippedVolumes, fullPath+" ("+reason+")")
-					s.volumesMu.Unlock()
+					s.recordSkip(fullPath, SkipFilesystemBoundary, reason)
 					continue
 				}
 			}
 
+			// Record bundles as one item, sized in parallel with the rest of the directory
+			if info.IsDir() && s.bundleAsFile && isBundle(entryName) {
+				bundle := newBundleNode(fullPath, info)
+				bundle.IsNetwork = remote
+				childrenMu.Lock()
+				node.AddChild(bundle)
+				childrenMu.Unlock()
+				wg.Add(1)
+				go func(n *FileNode) {
+					defer wg.Done()
+					s.sizeBundle(ctx, n)
+				}(bundle)
+				continue
+			}
+
+			// Leave small files out of the tree entirely (saves memory on huge trees)
+			if !info.IsDir() && info.Size() < s.minFileSize {
+				if s.dropTinyNodes {
+					childrenMu.Lock()
+					s.foldTinyFile(node, info)
+					childrenMu.Unlock()
+				}
+				continue
+			}
+
+			// In low-memory mode only the largest files get a node
+			if !info.IsDir() && s.lowMemory && !s.keepFile(info.Size()) {
+				childrenMu.Lock()
+				s.foldTinyFile(node, info)
+				childrenMu.Unlock()
+				continue
+			}
+
 			childNode := NewFileNode(fullPath, info.Size(), info.IsDir(), info.ModTime())
+			childNode.AllocatedSize = allocatedSize(info)
+			childNode.IsNetwork = remote
+			if entry.Type()&os.ModeSymlink != 0 {
+				childNode.IsSymlink = true
+				childNode.LinkTarget = linkTarget(fullPath)
+			}
+			if !info.IsDir() && s.isDuplicateHardLink(info) {
+				childNode.Size = 0
+				childNode.AllocatedSize = 0
+				childNode.IsHardLinkDup = true
+			}
+
+			if info.IsDir() && s.atDepthLimit(depth+1) {
+				childNode.Truncated = true
+				s.recordSkip(fullPath, SkipDepthLimit, fmt.Sprintf("depth limit (%d)", s.maxDepth))
+			}
 
 			childrenMu.Lock()
 			node.AddChild(childNode)
 			childrenMu.Unlock()
-
 			if info.IsDir() {
+				s.countDir()
+			}
+
+			if info.IsDir() && !childNode.Truncated {
 				// Scan subdirectories in parallel
 				// Note: No semaphore here - it's acquired inside scanDirectoryParallel
 				wg.Add(1)
 				go func(n *FileNode) {
 					defer wg.Done()
-					s.scanDirectoryParallel(ctx, n, progressChan, depth+1)
+					s.scanDirectoryParallel(ctx, n, progressChan, depth+1, ignore)
 				}(childNode)
 			}
 		}
@@ -253,12 +513,12 @@ func (s *Scanner) scanDirectoryParallel(ctx context.Context, node *FileNode, pro
 		wg.Wait()
 	} else {
 		// For deeper levels, use sequential scanning to avoid too many goroutines
-		s.scanDirectorySequential(ctx, node, progressChan)
+		s.scanDirectorySequential(ctx, node, progressChan, depth, ignore)
 	}
 }
 
 // scanDirectorySequential scans a directory sequentially
-func (s *Scanner) scanDirectorySequential(ctx context.Context, node *FileNode, progressChan chan<- ScanProgress) {
+func (s *Scanner) scanDirectorySequential(ctx context.Context, node *FileNode, progressChan chan<- ScanProgress, depth int, ignore *gitignore) {
 	// Check if cancelled before starting
 	select {
 	case <-ctx.Done():
@@ -266,13 +526,25 @@ func (s *Scanner) scanDirectorySequential(ctx context.Context, node *FileNode, p
 	default:
 	}
 
+	// Hold here while paused
+	if !s.waitWhilePaused(ctx) {
+		return
+	}
+
 	entries, err := s.readDirWithTimeout(node.Path)
+	if errors.Is(err, errSkippedByUser) {
+		return
+	}
 	if err != nil {
 		s.recordError(fmt.Errorf("cannot read directory %s: %w", node.Path, err))
 		// Don't return - continue with what we have (empty list)
 		entries = []os.DirEntry{}
 	}
 
+	if s.respectGitignore {
+		ignore = loadGitignore(node.Path, ignore)
+	}
+
 	for _, entry := range entries {
 		// Check if cancelled in loop
 		select {
@@ -281,6 +553,10 @@ func (s *Scanner) scanDirectorySequential(ctx context.Context, node *FileNode, p
 		default:
 		}
 
+		if s.isUserSkipped(node.Path) {
+			return
+		}
+
 		entryName := entry.Name()
 		fullPath := filepath.Join(node.Path, entryName)
 
@@ -292,15 +568,38 @@ func (s *Scanner) scanDirectorySequential(ctx context.Context, node *FileNode, p
 			continue
 		}
 
+		// Skip hidden entries if requested
+		if s.skipHidden && strings.HasPrefix(entryName, ".") {
+			s.mu.Lock()
+			s.progress.HiddenSkipped++
+			s.mu.Unlock()
+			continue
+		}
+
+		// Check user exclusions before anything that touches the filesystem
+		if s.isExcluded(fullPath) {
+			s.recordSkip(fullPath, SkipUserExclusion, "user exclusion")
+			continue
+		}
+
+		// Skip entries ignored by .gitignore (opt-in)
+		if ignore.isIgnored(fullPath, entry.IsDir()) {
+			s.mu.Lock()
+			s.progress.GitignoreSkipped++
+			s.mu.Unlock()
+			s.recordSkip(fullPath, SkipGitignore, "ignored by .gitignore")
+			continue
+		}
+
 		// Check if we should skip this path (network volume check)
-		if shouldSkip, reason := s.volumeChecker.ShouldSkipPath(fullPath); shouldSkip {
-			s.volumesMu.Lock()
-			s.skippedVolumes = append(s.skippedVolumes, fullPath+" ("+reason+")")
-			s.volumesMu.Unlock()
+		pathClass, reason := s.volumeChecker.ShouldSkipPath(fullPath)
+		if pathClass == safety.PathSkip {
+			s.recordSkip(fullPath, volumeSkipCategory(reason), reason)
 			continue
 		}
+		remote := node.IsNetwork || pathClass == safety.PathRemote
 
-		info, err := entry.Info()
+		info, err := s.entryInfo(entry, fullPath)
 		if err != nil {
 			s.recordError(fmt.Errorf("cannot stat %s: %w", fullPath, err))
 			continue
@@ -316,28 +615,65 @@ func (s *Scanner) scanDirectorySequential(ctx context.Context, node *FileNode, p
 			if err == nil {
 				if s.hasSeenInode(devID, inode) {
 					// Already scanned this directory (it's an alias/firmlink)
-					s.volumesMu.Lock()
-					s.skippedVolumes = append(s.skippedVolumes, fullPath+" (alias/firmlink)")
-					s.volumesMu.Unlock()
+					s.recordSkip(fullPath, SkipAlias, "alias/firmlink")
 					continue
 				}
 				s.markInodeSeen(devID, inode)
 			}
 
 			if shouldSkip, reason := s.shouldSkipFilesystemBoundary(fullPath); shouldSkip {
-				s.volumesMu.Lock()
-				s.skippedVolumes = append(s.skippedVolumes, fullPath+" ("+reason+")")
-				s.volumesMu.Unlock()
+				s.recordSkip(fullPath, SkipFilesystemBoundary, reason)
 				continue
 			}
 		}
 
+		// Record bundles as one item
+		if info.IsDir() && s.bundleAsFile && isBundle(entryName) {
+			bundle := newBundleNode(fullPath, info)
+			bundle.IsNetwork = remote
+			node.AddChild(bundle)
+			s.sizeBundle(ctx, bundle)
+			continue
+		}
+
+		// Leave small files out of the tree entirely (saves memory on huge trees)
+		if !info.IsDir() && info.Size() < s.minFileSize {
+			if s.dropTinyNodes {
+				s.foldTinyFile(node, info)
+			}
+			continue
+		}
+
+		// In low-memory mode only the largest files get a node
+		if !info.IsDir() && s.lowMemory && !s.keepFile(info.Size()) {
+			s.foldTinyFile(node, info)
+			continue
+		}
+
 		childNode := NewFileNode(fullPath, info.Size(), info.IsDir(), info.ModTime())
+		childNode.AllocatedSize = allocatedSize(info)
+		childNode.IsNetwork = remote
+		if entry.Type()&os.ModeSymlink != 0 {
+			childNode.IsSymlink = true
+			childNode.LinkTarget = linkTarget(fullPath)
+		}
+		if !info.IsDir() && s.isDuplicateHardLink(info) {
+			childNode.Size = 0
+			childNode.AllocatedSize = 0
+			childNode.IsHardLinkDup = true
+		}
+		if info.IsDir() && s.atDepthLimit(depth+1) {
+			childNode.Truncated = true
+			s.recordSkip(fullPath, SkipDepthLimit, fmt.Sprintf("depth limit (%d)", s.maxDepth))
+		}
 		node.AddChild(childNode)
+		if info.IsDir() {
+			s.countDir()
+		}
 
 		// Recursively scan subdirectories (sequential)
-		if info.IsDir() {
-			s.scanDirectorySequential(ctx, childNode, progressChan)
+		if info.IsDir() && !childNode.Truncated {
+			s.scanDirectorySequential(ctx, childNode, progressChan, depth+1, ignore)
 		}
 	}
 }
@@ -364,6 +700,13 @@ func (s *Scanner) updateProgress(currentPath string, size int64, progressChan ch
 	}
 }
 
+// countDir counts a directory added to the tree
+func (s *Scanner) countDir() {
+	s.mu.Lock()
+	s.progress.DirsScanned++
+	s.mu.Unlock()
+}
+
 // recordError records an error during scanning
 func (s *Scanner) recordError(err error) {
 	s.mu.Lock()
@@ -374,9 +717,16 @@ func (s *Scanner) recordError(err error) {
 
 // readDirWithTimeout wraps os.ReadDir with a timeout
 // Returns entries and error, with timeout error if operation takes too long
+// A read of a directory the user skipped (see SkipCurrent) returns errSkippedByUser
 func (s *Scanner) readDirWithTimeout(path string) ([]os.DirEntry, error) {
+	if s.isUserSkipped(path) {
+		return nil, errSkippedByUser
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), dirReadTimeout)
 	defer cancel()
+	s.startRead(path, cancel)
+	defer s.endRead(path)
 
 	type result struct {
 		entries []os.DirEntry
@@ -394,6 +744,9 @@ func (s *Scanner) readDirWithTimeout(path string) ([]os.DirEntry, error) {
 	case res := <-resultChan:
 		return res.entries, res.err
 	case <-ctx.Done():
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return nil, errSkippedByUser
+		}
 		return nil, fmt.Errorf("timeout reading directory (>%v): %s", dirReadTimeout, path)
 	}
 }
@@ -413,6 +766,30 @@ func getDeviceID(path string) (uint64, error) {
 	return uint64(stat.Dev), nil
 }
 
+// entryInfo stats a directory entry, following it if it is a symlink and following is enabled
+// Broken symlinks fall back to the link itself
+func (s *Scanner) entryInfo(entry os.DirEntry, fullPath string) (os.FileInfo, error) {
+	if s.followSymlinks && entry.Type()&os.ModeSymlink != 0 {
+		if info, err := os.Stat(fullPath); err == nil {
+			return info, nil
+		}
+	}
+	return entry.Info()
+}
+
+// linkTarget returns the absolute path the symlink at path points to ("" if it cannot be read)
+// Relative targets are resolved against the link's directory; the target may not exist
+func linkTarget(path string) string {
+	target, err := os.Readlink(path)
+	if err != nil {
+		return ""
+	}
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(filepath.Dir(path), target)
+	}
+	return filepath.Clean(target)
+}
+
 // getDeviceAndInode returns both device ID and inode for a path
 func getDeviceAndInode(path string) (uint64, uint64, error) {
 	info, err := os.Stat(path)
@@ -450,6 +827,93 @@ func (s *Scanner) markInodeSeen(deviceID uint64, inode uint64) {
 	s.seenInodes[deviceID][inode] = true
 }
 
+// allocatedSize returns the on-disk size of a file (st_blocks is in 512-byte units)
+// Falls back to the apparent size if stat details are unavailable
+func allocatedSize(info os.FileInfo) int64 {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return info.Size()
+	}
+	return int64(stat.Blocks) * 512
+}
+
+// isDuplicateHardLink reports whether a file is another link to an inode already counted
+// Files with a single link are never tracked, keeping the seen-inode map small
+func (s *Scanner) isDuplicateHardLink(info os.FileInfo) bool {
+	if s.countHardlinks {
+		return false
+	}
+
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok || uint64(stat.Nlink) < 2 {
+		return false
+	}
+
+	devID := uint64(stat.Dev)
+	inode := uint64(stat.Ino)
+
+	s.seenInodesMu.Lock()
+	defer s.seenInodesMu.Unlock()
+
+	if _, exists := s.seenInodes[devID]; !exists {
+		s.seenInodes[devID] = make(map[uint64]bool)
+	}
+	if s.seenInodes[devID][inode] {
+		s.mu.Lock()
+		s.progress.HardLinksDeduped++
+		s.mu.Unlock()
+		return true
+	}
+	s.seenInodes[devID][inode] = true
+	return false
+}
+
+// bundleExtensions are the directory extensions SetBundleAsFile records as single items
+var bundleExtensions = map[string]bool{
+	".app":       true,
+	".framework": true,
+	".bundle":    true,
+}
+
+// isBundle reports whether a directory name is a macOS bundle
+func isBundle(name string) bool {
+	return bundleExtensions[strings.ToLower(filepath.Ext(name))]
+}
+
+// newBundleNode creates the leaf node of a bundle directory; sizeBundle fills in its sizes
+func newBundleNode(path string, info os.FileInfo) *FileNode {
+	bundle := NewFileNode(path, 0, false, info.ModTime())
+	bundle.IsBundle = true
+	return bundle
+}
+
+// sizeBundle sets a bundle's sizes to the total of the files inside it
+// A quick walk: no exclusions, hard link or boundary checks, and unreadable parts are left out
+func (s *Scanner) sizeBundle(ctx context.Context, bundle *FileNode) {
+	s.workerSem <- struct{}{}
+	defer func() { <-s.workerSem }()
+
+	var size, allocated int64
+	filepath.WalkDir(bundle.Path, func(path string, entry fs.DirEntry, err error) error {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		if err != nil || entry.IsDir() {
+			return nil
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil
+		}
+		size += info.Size()
+		allocated += allocatedSize(info)
+		return nil
+	})
+
+	bundle.Size = size
+	bundle.AllocatedSize = allocated
+}
+
 // isICloudPlaceholder checks if a filename is an iCloud placeholder file
 func isICloudPlaceholder(name string) bool {
 	// iCloud placeholder files have the format: .filename.icloud
@@ -498,56 +962,132 @@ func getFilesystemUsedSpace(path string) (int64, error) {
 	return usedBytes, nil
 }
 
-// CalculateStats computes aggregate statistics for a file tree
-func CalculateStats(root *FileNode) *DirStats {
-	stats := &DirStats{
-		LargestFiles:  make([]*FileNode, 0),
-		TypeBreakdown: make(map[string]*TypeStats),
+// RemoveFiles takes deleted files and directories out of the statistics
+// without walking the tree again; types left without files are dropped
+func (stats *DirStats) RemoveFiles(files []*FileNode, dirs []*FileNode) {
+	removed := make(map[*FileNode]bool, len(files))
+	affected := make(map[string]bool)
+	for _, file := range files {
+		removed[file] = true
+		typeStats, exists := stats.TypeBreakdown[file.FileType]
+		if !exists {
+			continue
+		}
+		typeStats.TotalSize -= file.FileSize()
+		typeStats.FileCount--
+		affected[file.FileType] = true
+
+		stats.TotalSize -= file.FileSize()
+		stats.FileCount--
+	}
+	stats.DirCount -= int64(len(dirs))
+	for _, dir := range dirs {
+		if dir.sizing.includeDirSize() {
+			stats.TotalSize -= dir.FileSize()
+		}
+		allocated := dir.sizing.useAllocated()
+		for fileType, dropped := range dir.DroppedTypes {
+			if typeStats, exists := stats.TypeBreakdown[fileType]; exists {
+				typeStats.TotalSize -= dropped.size(allocated)
+				typeStats.FileCount -= dropped.Count
+				affected[fileType] = true
+
+				stats.TotalSize -= dropped.size(allocated)
+				stats.FileCount -= dropped.Count
+			}
+		}
 	}
+	stats.LargestFiles = keepUnremoved(stats.LargestFiles, removed)
 
-	walkTree(root, stats)
+	for extension := range affected {
+		typeStats := stats.TypeBreakdown[extension]
+		typeStats.Files = keepUnremoved(typeStats.Files, removed)
+		if typeStats.FileCount <= 0 {
+			delete(stats.TypeBreakdown, extension)
+		}
+	}
+}
 
-	// Sort largest files
-	sort.Slice(stats.LargestFiles, func(i, j int) bool {
-		return stats.LargestFiles[i].TotalSize() > stats.LargestFiles[j].TotalSize()
-	})
+// keepUnremoved returns the nodes not in removed, in the same order
+func keepUnremoved(nodes []*FileNode, removed map[*FileNode]bool) []*FileNode {
+	kept := make([]*FileNode, 0, len(nodes))
+	for _, node := range nodes {
+		if !removed[node] {
+			kept = append(kept, node)
+		}
+	}
+	return kept
+}
 
-	// Keep only top 100
-	if len(stats.LargestFiles) > 100 {
-		stats.LargestFiles = stats.LargestFiles[:100]
+// DefaultLargestFiles is how many of the largest files CalculateStats keeps in LargestFiles
+const DefaultLargestFiles = 100
+
+// CalculateStats computes aggregate statistics for a file tree, keeping the
+// DefaultLargestFiles largest files
+func CalculateStats(root *FileNode) *DirStats {
+	return CalculateStatsN(root, DefaultLargestFiles)
+}
+
+// CalculateStatsN computes aggregate statistics for a file tree, keeping the n largest
+// files in LargestFiles (n <= 0 keeps every file)
+func CalculateStatsN(root *FileNode, n int) *DirStats {
+	stats := &DirStats{
+		TypeBreakdown: make(map[string]*TypeStats),
 	}
 
+	largest := &largestFiles{limit: n}
+	walkTree(root, stats, largest)
+	stats.LargestFiles = largest.sorted()
+
 	return stats
 }
 
 // walkTree recursively walks the tree and collects statistics
-func walkTree(node *FileNode, stats *DirStats) {
+func walkTree(node *FileNode, stats *DirStats, largest *largestFiles) {
 	if node.IsDir {
 		stats.DirCount++
+		if node.sizing.includeDirSize() {
+			stats.TotalSize += node.FileSize()
+		}
 		for _, child := range node.Children {
-			walkTree(child, stats)
+			walkTree(child, stats, largest)
+		}
+
+		// Folded files (low-memory mode, -drop-tiny-nodes) count by type, without nodes to list
+		allocated := node.sizing.useAllocated()
+		for fileType, dropped := range node.DroppedTypes {
+			stats.FileCount += dropped.Count
+			stats.TotalSize += dropped.size(allocated)
+			typeStats := stats.typeStats(fileType)
+			typeStats.FileCount += dropped.Count
+			typeStats.TotalSize += dropped.size(allocated)
 		}
 	} else {
 		stats.FileCount++
-		stats.TotalSize += node.Size
+		stats.TotalSize += node.FileSize()
 
 		// Track largest files
-		stats.LargestFiles = append(stats.LargestFiles, node)
+		largest.add(node)
 
 		// Track by type
-		if typeStats, exists := stats.TypeBreakdown[node.FileType]; exists {
-			typeStats.FileCount++
-			typeStats.TotalSize += node.Size
-			typeStats.Files = append(typeStats.Files, node)
-		} else {
-			stats.TypeBreakdown[node.FileType] = &TypeStats{
-				Extension: node.FileType,
-				FileCount: 1,
-				TotalSize: node.Size,
-				Files:     []*FileNode{node},
-			}
+		typeStats := stats.typeStats(node.FileType)
+		typeStats.FileCount++
+		typeStats.TotalSize += node.FileSize()
+		typeStats.Files = append(typeStats.Files, node)
+	}
+}
+
+// typeStats returns the statistics for a file type, adding an empty entry if needed
+func (stats *DirStats) typeStats(fileType string) *TypeStats {
+	typeStats, exists := stats.TypeBreakdown[fileType]
+	if !exists {
+		typeStats = &TypeStats{
+			Extension: fileType,
+			Files:     make([]*FileNode, 0),
 		}
+		stats.TypeBreakdown[fileType] = typeStats
 	}
+	return typeStats
 }
 
 // FlattenTree returns a flat list of all nodes (useful for sorting/filtering)
@@ -0,0 +1,231 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeTestTree creates dirs directories under root, each holding tiny files of 100 bytes
+// and one 10 KiB file
+func writeTestTree(tb testing.TB, root string, dirs, tiny int) {
+	tb.Helper()
+	for d := 0; d < dirs; d++ {
+		dir := filepath.Join(root, fmt.Sprintf("dir%d", d), "sub")
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			tb.Fatal(err)
+		}
+		for f := 0; f < tiny; f++ {
+			name := filepath.Join(dir, fmt.Sprintf("note%d.txt", f))
+			if err := os.WriteFile(name, make([]byte, 100), 0o644); err != nil {
+				tb.Fatal(err)
+			}
+		}
+		if err := os.WriteFile(filepath.Join(dir, "big.bin"), make([]byte, 10*1024), 0o644); err != nil {
+			tb.Fatal(err)
+		}
+	}
+}
+
+// scanTestTree scans root, dropping files under 1 KiB from the tree when dropTiny is set
+func scanTestTree(tb testing.TB, root string, dropTiny bool) *FileNode {
+	tb.Helper()
+	s := NewScanner()
+	if dropTiny {
+		s.SetMinFileSize(1024)
+		s.SetDropTinyNodes(true)
+	}
+	node, err := s.Scan(context.Background(), root, nil)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return node
+}
+
+func TestDropTinyNodesKeepsTotals(t *testing.T) {
+	root := t.TempDir()
+	writeTestTree(t, root, 3, 20)
+
+	full := scanTestTree(t, root, false)
+	dropped := scanTestTree(t, root, true)
+
+	if got, want := len(FlattenTree(dropped)), len(FlattenTree(full))-3*20; got != want {
+		t.Errorf("nodes with -drop-tiny-nodes = %d, want %d", got, want)
+	}
+
+	// Every directory keeps its total and file count
+	fullDirs := make(map[string]*FileNode)
+	for _, node := range FlattenTree(full) {
+		if node.IsDir {
+			fullDirs[node.Path] = node
+		}
+	}
+	for _, node := range FlattenTree(dropped) {
+		if !node.IsDir {
+			continue
+		}
+		want := fullDirs[node.Path]
+		if node.TotalSize() != want.TotalSize() || node.FileCount() != want.FileCount() {
+			t.Errorf("%s: %d bytes in %d files, want %d bytes in %d files",
+				node.Path, node.TotalSize(), node.FileCount(), want.TotalSize(), want.FileCount())
+		}
+	}
+
+	// The stats agree with the tree, including the type breakdown
+	fullStats, droppedStats := CalculateStats(full), CalculateStats(dropped)
+	if droppedStats.TotalSize != dropped.TotalSize() || droppedStats.FileCount != dropped.FileCount() {
+		t.Errorf("stats: %d bytes in %d files, tree: %d bytes in %d files",
+			droppedStats.TotalSize, droppedStats.FileCount, dropped.TotalSize(), dropped.FileCount())
+	}
+	for ext, want := range fullStats.TypeBreakdown {
+		got := droppedStats.TypeBreakdown[ext]
+		if got == nil || got.TotalSize != want.TotalSize || got.FileCount != want.FileCount {
+			t.Errorf("type %s: got %+v, want %d bytes in %d files", ext, got, want.TotalSize, want.FileCount)
+		}
+	}
+}
+
+func TestSizeModeIsPerTree(t *testing.T) {
+	newTree := func() *FileNode {
+		root := NewFileNode("/r", 0, true, time.Time{})
+		file := NewFileNode("/r/a.bin", 100, false, time.Time{})
+		file.AllocatedSize = 4096
+		root.AddChild(file)
+		return root
+	}
+	shown, other := newTree(), newTree()
+
+	shown.Children[0].SetUseAllocatedSize(true)
+	if got := shown.TotalSize(); got != 4096 {
+		t.Errorf("allocated total = %d, want 4096", got)
+	}
+	if got := other.TotalSize(); got != 100 || other.UsesAllocatedSize() {
+		t.Errorf("other tree total = %d (allocated %v), want its apparent size 100", got, other.UsesAllocatedSize())
+	}
+
+	// Nodes added later, such as rescanned subtrees, take on the tree's size mode
+	dir := NewFileNode("/r/d", 0, true, time.Time{})
+	late := NewFileNode("/r/d/b.bin", 10, false, time.Time{})
+	late.AllocatedSize = 512
+	dir.AddChild(late)
+	shown.AddChild(dir)
+	if got := shown.TotalSize(); got != 4096+512 {
+		t.Errorf("total after adding a subtree = %d, want %d", got, 4096+512)
+	}
+	if got := CalculateStats(shown).TotalSize; got != 4096+512 {
+		t.Errorf("stats total = %d, want %d", got, 4096+512)
+	}
+}
+
+func TestIncludeDirSizeIsPerScanner(t *testing.T) {
+	root := t.TempDir()
+	writeTestTree(t, root, 2, 3)
+
+	contents := scanTestTree(t, root, false)
+	s := NewScanner()
+	s.SetIncludeDirSize(true)
+	withDirs, err := s.Scan(context.Background(), root, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var dirBytes int64
+	for _, node := range FlattenTree(withDirs) {
+		if node.IsDir {
+			dirBytes += node.Size
+		}
+	}
+	if got, want := withDirs.TotalSize(), contents.TotalSize()+dirBytes; got != want {
+		t.Errorf("total with directory sizes = %d, want %d", got, want)
+	}
+	if got, want := CalculateStats(withDirs).TotalSize, withDirs.TotalSize(); got != want {
+		t.Errorf("stats total = %d, want the tree total %d", got, want)
+	}
+	if got := CalculateStats(contents).TotalSize; got != contents.TotalSize() {
+		t.Errorf("a scan without -include-dir-size counted %d bytes, want %d", got, contents.TotalSize())
+	}
+}
+
+func BenchmarkDropTinyNodes(b *testing.B) {
+	root := b.TempDir()
+	writeTestTree(b, root, 20, 200)
+
+	for _, dropTiny := range []bool{false, true} {
+		b.Run(fmt.Sprintf("drop=%v", dropTiny), func(b *testing.B) {
+			var nodes int
+			for i := 0; i < b.N; i++ {
+				nodes = len(FlattenTree(scanTestTree(b, root, dropTiny)))
+			}
+			b.ReportMetric(float64(nodes), "nodes")
+		})
+	}
+}
+
+func TestFinalProgressSurvivesSaturatedChannel(t *testing.T) {
+	root := t.TempDir()
+	writeTestTree(t, root, 3, 20)
+
+	// The buffer is already full, as it is when the UI falls behind
+	progress := make(chan ScanProgress, 1)
+	progress <- ScanProgress{CurrentPath: "stale"}
+
+	scn := NewScanner()
+	done := make(chan struct{})
+	go func() {
+		if _, err := scn.Scan(context.Background(), root, progress); err != nil {
+			t.Error(err)
+		}
+		close(done)
+	}()
+
+	// The scan cannot finish until the final totals are delivered
+	select {
+	case <-done:
+		t.Fatal("Scan returned without delivering the final progress")
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	var final ScanProgress
+	for update := range progress {
+		if update.Complete {
+			final = update
+			break
+		}
+	}
+	<-done
+
+	want := scn.GetProgress()
+	if final.FilesScanned != want.FilesScanned || final.BytesScanned != want.BytesScanned {
+		t.Errorf("final progress = %d files, %d bytes; want %d files, %d bytes",
+			final.FilesScanned, final.BytesScanned, want.FilesScanned, want.BytesScanned)
+	}
+	if final.FilesScanned == 0 {
+		t.Error("final progress reported no files")
+	}
+}
+
+func TestFinalProgressGivesUpWhenCancelled(t *testing.T) {
+	root := t.TempDir()
+	writeTestTree(t, root, 1, 5)
+
+	progress := make(chan ScanProgress, 1)
+	progress <- ScanProgress{CurrentPath: "stale"}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		NewScanner().Scan(ctx, root, progress)
+		close(done)
+	}()
+
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Scan stayed blocked on the final progress after being cancelled")
+	}
+}
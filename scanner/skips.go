@@ -0,0 +1,178 @@
+package scanner
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// SkipCategory classifies why the scanner did not descend into a path
+type SkipCategory string
+
+const (
+	SkipNetworkVolume      SkipCategory = "network-volume"
+	SkipCloudStorage       SkipCategory = "cloud-storage"
+	SkipUserExclusion      SkipCategory = "user-exclusion"
+	SkipGitignore          SkipCategory = "gitignore"
+	SkipAlias              SkipCategory = "alias"
+	SkipFilesystemBoundary SkipCategory = "filesystem-boundary"
+	SkipDepthLimit         SkipCategory = "depth-limit"
+	SkipUserSkipped        SkipCategory = "user-skipped"
+)
+
+// errSkippedByUser is returned by a directory read cancelled with SkipCurrent
+var errSkippedByUser = errors.New("skipped by user")
+
+// activeRead is a directory read in progress, which SkipCurrent can cancel
+type activeRead struct {
+	started time.Time
+	cancel  context.CancelFunc
+}
+
+// SkippedPath records one path the scan chose not to descend into
+type SkippedPath struct {
+	Path     string       `json:"path"`
+	Category SkipCategory `json:"category"`
+	Reason   string       `json:"reason"`
+}
+
+// volumeSkipCategory maps a VolumeChecker reason to its category
+func volumeSkipCategory(reason string) SkipCategory {
+	if strings.HasPrefix(reason, "network volume") {
+		return SkipNetworkVolume
+	}
+	return SkipCloudStorage
+}
+
+// recordSkip adds a path to the skip manifest
+// Network, cloud, exclusion, alias and boundary skips also go in the legacy
+// skippedVolumes list shown in the UI; gitignore and depth-limit skips are
+// already counted elsewhere and only appear in the manifest
+func (s *Scanner) recordSkip(path string, category SkipCategory, reason string) {
+	s.volumesMu.Lock()
+	defer s.volumesMu.Unlock()
+
+	s.skippedPaths = append(s.skippedPaths, SkippedPath{
+		Path:     path,
+		Category: category,
+		Reason:   reason,
+	})
+
+	if category != SkipGitignore && category != SkipDepthLimit {
+		s.skippedVolumes = append(s.skippedVolumes, path+" ("+reason+")")
+	}
+}
+
+// startRead registers a directory read so SkipCurrent can find and cancel it
+func (s *Scanner) startRead(path string, cancel context.CancelFunc) {
+	s.readsMu.Lock()
+	s.activeReads[path] = activeRead{started: time.Now(), cancel: cancel}
+	s.readsMu.Unlock()
+}
+
+// endRead unregisters a finished directory read
+func (s *Scanner) endRead(path string) {
+	s.readsMu.Lock()
+	delete(s.activeReads, path)
+	s.readsMu.Unlock()
+}
+
+// SkipCurrent stops scanning the directory the scan is stuck on, for a directory that
+// is slow but has not hit the read timeout (a crawling network mount). That is the
+// longest-running directory read, or if none is in progress, the directory holding the
+// last entry scanned. Entries already found are kept; the rest of the directory, and
+// everything below it, is left out and recorded in the skip manifest as user-skipped
+// Returns the skipped directory, or "" if there was nothing to skip
+func (s *Scanner) SkipCurrent() string {
+	path := s.slowestRead()
+	if path == "" {
+		if current := s.GetProgress().CurrentPath; current != "" {
+			path = filepath.Dir(current)
+		}
+	}
+	if path == "" {
+		return ""
+	}
+
+	s.readsMu.Lock()
+	if s.userSkips[path] {
+		s.readsMu.Unlock()
+		return path
+	}
+	s.userSkips[path] = true
+	s.hasUserSkips.Store(true)
+	if read, ok := s.activeReads[path]; ok {
+		read.cancel()
+	}
+	s.readsMu.Unlock()
+
+	s.recordSkip(path, SkipUserSkipped, "skipped by user")
+	return path
+}
+
+// slowestRead returns the directory read that has been running longest ("" if none)
+func (s *Scanner) slowestRead() string {
+	s.readsMu.Lock()
+	defer s.readsMu.Unlock()
+
+	var slowest string
+	var started time.Time
+	for path, read := range s.activeReads {
+		if slowest == "" || read.started.Before(started) {
+			slowest, started = path, read.started
+		}
+	}
+	return slowest
+}
+
+// isUserSkipped reports whether path or one of its parents was skipped with SkipCurrent
+func (s *Scanner) isUserSkipped(path string) bool {
+	if !s.hasUserSkips.Load() {
+		return false
+	}
+
+	s.readsMu.Lock()
+	defer s.readsMu.Unlock()
+	for {
+		if s.userSkips[path] {
+			return true
+		}
+		parent := filepath.Dir(path)
+		if parent == path {
+			return false
+		}
+		path = parent
+	}
+}
+
+// GetSkippedPaths returns every path the scan did not descend into, with its reason
+func (s *Scanner) GetSkippedPaths() []SkippedPath {
+	s.volumesMu.Lock()
+	defer s.volumesMu.Unlock()
+
+	skipped := make([]SkippedPath, len(s.skippedPaths))
+	copy(skipped, s.skippedPaths)
+	return skipped
+}
+
+// WriteSkipManifest writes the skipped paths as JSON (asJSON) or as tab-separated text
+// Text lines are "category<TAB>path<TAB>reason"
+func WriteSkipManifest(w io.Writer, skipped []SkippedPath, asJSON bool) error {
+	if asJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(skipped)
+	}
+
+	for _, skip := range skipped {
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\n", skip.Category, skip.Path, skip.Reason); err != nil {
+			return err
+		}
+	}
+	return nil
+}
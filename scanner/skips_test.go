@@ -0,0 +1,127 @@
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordSkipKeepsCategoryAndReason(t *testing.T) {
+	s := NewScanner()
+	skips := []SkippedPath{
+		{"/mnt/share", SkipNetworkVolume, "network volume (smbfs)"},
+		{"/cloud/drive", SkipCloudStorage, "cloud storage"},
+		{"/data/excluded", SkipUserExclusion, "user exclusion"},
+		{"/repo/build", SkipGitignore, "ignored by .gitignore"},
+		{"/System/Volumes/Data", SkipAlias, "alias/firmlink"},
+		{"/mnt/usb", SkipFilesystemBoundary, "different filesystem"},
+		{"/deep/dir", SkipDepthLimit, "depth limit (2)"},
+		{"/slow/dir", SkipUserSkipped, "skipped by user"},
+	}
+	for _, skip := range skips {
+		s.recordSkip(skip.Path, skip.Category, skip.Reason)
+	}
+
+	got := s.GetSkippedPaths()
+	if len(got) != len(skips) {
+		t.Fatalf("got %d skipped paths, want %d", len(got), len(skips))
+	}
+	for i := range skips {
+		if got[i] != skips[i] {
+			t.Errorf("skip %d = %+v, want %+v", i, got[i], skips[i])
+		}
+	}
+
+	// Gitignore and depth-limit skips are counted elsewhere and stay out of the UI list
+	volumes := strings.Join(s.GetSkippedVolumes(), "\n")
+	for _, skip := range skips {
+		listed := strings.Contains(volumes, skip.Path+" ("+skip.Reason+")")
+		want := skip.Category != SkipGitignore && skip.Category != SkipDepthLimit
+		if listed != want {
+			t.Errorf("%s in skipped volumes = %v, want %v", skip.Category, listed, want)
+		}
+	}
+}
+
+func TestVolumeSkipCategory(t *testing.T) {
+	if got := volumeSkipCategory("network volume (nfs)"); got != SkipNetworkVolume {
+		t.Errorf("network reason gave %s", got)
+	}
+	if got := volumeSkipCategory("iCloud Drive"); got != SkipCloudStorage {
+		t.Errorf("cloud reason gave %s", got)
+	}
+}
+
+func TestScanRecordsSkips(t *testing.T) {
+	root := t.TempDir()
+	for _, dir := range []string{"excluded/inner", "ignored", "a/b/c"} {
+		if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(root, ".gitignore"), []byte("ignored/\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewScanner()
+	s.SetExclusions([]string{"excluded"})
+	s.SetRespectGitignore(true)
+	s.SetMaxDepth(2)
+	if _, err := s.Scan(context.Background(), root, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]SkipCategory{
+		filepath.Join(root, "excluded"): SkipUserExclusion,
+		filepath.Join(root, "ignored"):  SkipGitignore,
+		filepath.Join(root, "a", "b"):   SkipDepthLimit,
+	}
+	got := make(map[string]SkipCategory)
+	for _, skip := range s.GetSkippedPaths() {
+		got[skip.Path] = skip.Category
+		if skip.Reason == "" {
+			t.Errorf("%s was skipped without a reason", skip.Path)
+		}
+	}
+	for path, category := range want {
+		if got[path] != category {
+			t.Errorf("%s recorded as %q, want %q", path, got[path], category)
+		}
+	}
+}
+
+func TestWriteSkipManifest(t *testing.T) {
+	skipped := []SkippedPath{
+		{"/repo/build", SkipGitignore, "ignored by .gitignore"},
+		{"/deep/dir with space", SkipDepthLimit, "depth limit (2)"},
+	}
+
+	var text bytes.Buffer
+	if err := WriteSkipManifest(&text, skipped, false); err != nil {
+		t.Fatal(err)
+	}
+	want := "gitignore\t/repo/build\tignored by .gitignore\n" +
+		"depth-limit\t/deep/dir with space\tdepth limit (2)\n"
+	if text.String() != want {
+		t.Errorf("text manifest = %q, want %q", text.String(), want)
+	}
+
+	var encoded bytes.Buffer
+	if err := WriteSkipManifest(&encoded, skipped, true); err != nil {
+		t.Fatal(err)
+	}
+	var decoded []SkippedPath
+	if err := json.Unmarshal(encoded.Bytes(), &decoded); err != nil {
+		t.Fatalf("JSON manifest does not parse: %v", err)
+	}
+	if len(decoded) != len(skipped) || decoded[0] != skipped[0] || decoded[1] != skipped[1] {
+		t.Errorf("JSON manifest round-tripped to %+v", decoded)
+	}
+	if !strings.Contains(encoded.String(), `"category": "depth-limit"`) {
+		t.Errorf("JSON manifest is missing the category field:\n%s", encoded.String())
+	}
+}
@@ -1,12 +1,17 @@
 package ui
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"spaceforce/analyzer"
 	"spaceforce/safety"
 	"spaceforce/scanner"
 	"spaceforce/ui/views"
@@ -22,8 +27,14 @@ const (
 	ViewBreakdown
 	ViewTimeline
 	ViewErrors
+	ViewSuggestions
+	ViewApps
+	ViewSizes
 )
 
+// viewCount is the number of tabs (used for tab cycling)
+const viewCount = 8
+
 // ModalType represents different modal dialogs
 type ModalType int
 
@@ -32,6 +43,13 @@ const (
 	ModalDeleteConfirm
 	ModalDeleteProgress
 	ModalDeleteSummary
+	ModalMarkPattern
+	ModalSnapshotDelete
+	ModalEmptyTrash
+	ModalQuickDelete // One-line confirm shown in place of the help bar
+	ModalSkippedList // Scrollable list of every skipped volume and why
+	ModalLargerThan  // Size prompt for listing files over a threshold
+	ModalSafeCleanup // Confirm for trashing every no-risk suggestion at once
 )
 
 // DeleteProgress tracks deletion operation progress
@@ -43,22 +61,44 @@ type DeleteProgress struct {
 	FilesDeleted      int   // Top-level items deleted
 	TotalFilesDeleted int   // Total files including those in deleted directories
 	Errors            []error
+	Protected         []error           // Items skipped because they are protected (not failures)
+	Verified          bool              // Deleted paths were re-checked afterwards
+	Leftovers         []safety.Leftover // Paths reported deleted that are still on disk
+	TrashSize         int64             // Bytes in the Trash after deleting (-1 if unknown)
+	Method            safety.DeleteMethod // Trash or permanent, for the summary wording
 }
 
 // Model is the main application model
 type Model struct {
 	currentView ViewType
+	rootPath    string
+	linkPath    string // Symlink given as the scan path (rootPath is its target)
 	scanner     *scanner.Scanner
 	root        *scanner.FileNode
+	allNodes    []*scanner.FileNode // m.root flattened once per scan and shared by the views
+	stats       *scanner.DirStats   // Type breakdown of m.root, computed once per scan and updated on deletion
 	scanning    bool
 	progress    scanner.ScanProgress
+	scanRates   rateHistory // Recent files-per-second samples for the scanning sparkline
+	activity    activity    // Spinner and stall clock, so a slow directory doesn't look like a hang
+	userSkips   []string    // Directories skipped with 's' during the current scan
+	lastScan    *scanSummary // What the last completed scan covered (shown in the footer)
+	allocated   bool         // Show allocated (on-disk) sizes; applied to each scanned tree
+
+	// Display options
+	timelineCutoffs []views.TimelineCutoff // Custom timeline buckets, also banding the age colors (nil = built-in)
+	colorByAge      bool                   // Color file names in the tree and top list by age ('A')
+	maxTreeChildren int                    // Children of one directory the tree shows before folding (0 = all)
 
 	// Views
-	treeView      *views.TreeView
-	topListView   *views.TopListView
-	breakdownView *views.BreakdownView
-	timelineView  *views.TimelineView
-	errorsView    *views.ErrorsView
+	treeView        *views.TreeView
+	topListView     *views.TopListView
+	breakdownView   *views.BreakdownView
+	timelineView    *views.TimelineView
+	errorsView      *views.ErrorsView
+	suggestionsView *views.SuggestionsView
+	appsView        *views.AppsView
+	histogramView   *views.HistogramView
 
 	// UI state
 	width           int
@@ -66,26 +106,132 @@ type Model struct {
 	err             error
 	skippedVolumes  []string
 	showSkippedInfo bool
+	skippedScroll   int // First line shown in the skipped volumes list
 
 	// File marking and deletion
-	markedFiles             map[string]*scanner.FileNode // Path -> Node
+	markedFiles             *views.MarkedSet // Shared with the views; safe for concurrent use
 	activeModal             ModalType
 	deleteProgress          DeleteProgress
 	diskSpaceBefore         int64
 	diskSpaceAfter          int64
 	sensitiveDeleteConfirmed bool // Track if user has confirmed deletion of sensitive paths once
+	sensitiveContents       []string // Sensitive directories inside marked folders (found when 'x' opens the dialog)
+	deleteMethod            safety.DeleteMethod // Trash (default) or permanent removal with -permanent
+	verifyDeletes           bool // Re-check deleted paths and correct the bytes freed
+	singleConfirmCleanup    bool // Sensitive cache/log items with no risk need only one confirmation
+	typedConfirmSize        int64  // Batches at least this big need DELETE typed (0 = never)
+	typedConfirmRisk        int    // Batches with an item at this risk level or above need DELETE typed (0 = never)
+	typedConfirmReason      string // Why the open confirmation needs DELETE typed ("" = a keypress will do)
+	deleteInput             string // Text typed into a confirmation that needs DELETE
+	manifestPath            string // Where 'w' in the delete confirm saved the marked list ("" = not saved)
+	manifestErr             error  // Why saving the marked list failed
+	markFrom                []string // Paths from -mark-from, marked once the first scan completes
+	markFromFile            string   // The file they were read from (for the status line)
+	markedSize              int64 // Total size of the marked items, refreshed whenever marks change
+	freeGoal                int64 // Bytes the user wants to free (-target, 0 = no goal)
+	quickDeleteNode         *scanner.FileNode // Cache directory waiting on the 'D' confirm
+
+	// Marked set export
+	exportTarget  string // File given by -export-marked ("-" = stdout on exit)
+	exportNul     bool   // NUL-delimited instead of shell-quoted lines
+	statusMessage string // One-off status line (cleared on the next key press)
+
+	// Shell integration
+	cdFile string // File the 'c' cd command is written to (clipboard if empty)
+
+	// Rescanning permission-denied paths from the Errors view
+	rescanning bool
+
+	// Full rescans with 'R'
+	newScanner   func() *scanner.Scanner // Creates a scanner with the command-line options
+	cancelScan   context.CancelFunc      // Cancels a running full rescan
+	reselectPath string                  // Selection to restore once the rescan completes
+
+	// Pattern marking prompt
+	patternInput   string              // Text typed into the 'P' prompt
+	patternNodes   []*scanner.FileNode // Every node in the tree when the prompt opened
+	patternMatch   views.PatternMatch  // Nodes matching patternInput, split by safety
+
+	// Files-larger-than prompt
+	largerInput string              // Size typed into the '>' prompt
+	largerFiles []*scanner.FileNode // Files over the typed size (nil while it does not parse)
+
+	// Safe cleanup ('C'): the no-risk suggestion items marked for the Trash
+	cleanupItems []*scanner.FileNode
+
+	// Time Machine local snapshots of the startup volume (listed after each scan)
+	snapshots         []safety.LocalSnapshot
+	deletingSnapshots bool
+
+	// Duplicate files, hashed in the background after each scan
+	duplicates        []*analyzer.DuplicateGroup
+	findingDuplicates bool
+	cancelDuplicates  context.CancelFunc
+	duplicateSearch   int      // Incremented per search, so results of an older one are ignored
+	deletedMidSearch  []string // Paths deleted while the search runs, pruned from its results
 }
 
+// defaultExportFile is used by 'e' when no export file was given (or it is stdout)
+const defaultExportFile = "spaceforce-marked.txt"
+
+// errorsExportFile is where 'e' in the Errors view writes the error list (in the home directory)
+const errorsExportFile = "spaceforce-errors.txt"
+
+// manifestFilePattern names the marked-list manifest 'w' saves from the delete confirm
+// (in the home directory), stamped with the time so earlier ones are kept
+const manifestFilePattern = "spaceforce-manifest-%s.txt"
+
 // ScanCompleteMsg is sent when scanning completes
 type ScanCompleteMsg struct {
 	Root           *scanner.FileNode
 	Err            error
 	SkippedVolumes []string
+	Progress       scanner.ScanProgress // Final totals (the last progress update may be dropped)
+	Elapsed        time.Duration
+}
+
+// scanSummary is what a completed scan covered
+type scanSummary struct {
+	files   int64
+	dirs    int64
+	bytes   int64
+	errors  int
+	elapsed time.Duration
 }
 
 // ScanProgressMsg is sent during scanning
 type ScanProgressMsg scanner.ScanProgress
 
+// RescanCompleteMsg carries the subtrees rescanned from the Errors view
+type RescanCompleteMsg struct {
+	Nodes []*scanner.FileNode
+}
+
+// rescanBusyMessage answers deletions and 'R' while denied paths are being rescanned,
+// since the rescan is merged into the current tree when it completes
+const rescanBusyMessage = "Wait for the rescan of denied paths to finish"
+
+// SnapshotsLoadedMsg carries the Time Machine local snapshots listed after a scan
+type SnapshotsLoadedMsg struct {
+	Snapshots []safety.LocalSnapshot
+}
+
+// SnapshotsDeletedMsg is sent when deleting local snapshots finishes
+type SnapshotsDeletedMsg struct {
+	Deleted int
+	Errors  []error
+}
+
+// DuplicatesFoundMsg carries the duplicate groups found after a scan
+type DuplicatesFoundMsg struct {
+	Search int
+	Groups []*analyzer.DuplicateGroup
+	Err    error
+}
+
+// snapshotVolume is the volume whose local snapshots are listed
+const snapshotVolume = "/"
+
 // JumpToTreeViewMsg is sent when we want to switch to tree view and select a specific node
 type JumpToTreeViewMsg struct {
 	Path string
@@ -95,17 +241,99 @@ type JumpToTreeViewMsg struct {
 func NewModel(rootPath string) *Model {
 	return &Model{
 		currentView: ViewTree,
+		rootPath:    rootPath,
 		scanner:     scanner.NewScanner(),
 		scanning:    true,
 		width:       80,
 		height:      24,
-		markedFiles: make(map[string]*scanner.FileNode),
+		markedFiles: views.NewMarkedSet(),
 		activeModal: ModalNone,
+		deleteMethod: safety.DeleteToTrash,
+		maxTreeChildren: views.DefaultMaxTreeChildren,
 	}
 }
 
+// SetDeleteMethod chooses between moving marked items to the Trash and removing them permanently
+func (m *Model) SetDeleteMethod(method safety.DeleteMethod) {
+	m.deleteMethod = method
+}
+
+// SetScanner attaches the scanner doing the work so the scan can be paused from the UI
+func (m *Model) SetScanner(scn *scanner.Scanner) {
+	m.scanner = scn
+}
+
+// SetScannerFactory sets how 'R' creates the scanner for a full rescan
+func (m *Model) SetScannerFactory(newScanner func() *scanner.Scanner) {
+	m.newScanner = newScanner
+}
+
+// SetLinkPath records that the scan path was reached through the symlink path
+func (m *Model) SetLinkPath(path string) {
+	m.linkPath = path
+}
+
+// SetMarkFrom marks the given paths (read from file) once the first scan completes
+func (m *Model) SetMarkFrom(file string, paths []string) {
+	m.markFromFile = file
+	m.markFrom = paths
+}
+
+// SetCdFile makes 'c' write its cd command to path instead of the clipboard
+func (m *Model) SetCdFile(path string) {
+	m.cdFile = path
+}
+
+// SetMaxTreeChildren sets how many children of one directory the tree shows before
+// folding the rest into an expandable row (0 = show all)
+func (m *Model) SetMaxTreeChildren(n int) {
+	m.maxTreeChildren = n
+}
+
+// SetTimelineCutoffs replaces the built-in timeline buckets, which also band the age colors
+func (m *Model) SetTimelineCutoffs(cutoffs []views.TimelineCutoff) {
+	m.timelineCutoffs = cutoffs
+}
+
+// SetVerifyDeletes makes each deletion batch re-check that deleted paths are gone
+func (m *Model) SetVerifyDeletes(verify bool) {
+	m.verifyDeletes = verify
+}
+
+// SetSingleConfirmCleanup lets batches whose sensitive items are all no-risk caches or logs
+// skip the second confirmation
+func (m *Model) SetSingleConfirmCleanup(enabled bool) {
+	m.singleConfirmCleanup = enabled
+}
+
+// SetTypedConfirm makes batches of at least size bytes, or with an item at risk level risk
+// or above, need the word DELETE typed instead of a keypress (0 disables either check)
+func (m *Model) SetTypedConfirm(size int64, risk int) {
+	m.typedConfirmSize = size
+	m.typedConfirmRisk = risk
+}
+
+// SetFreeGoal sets how many bytes the user wants to free; the marked readout tracks progress toward it
+func (m *Model) SetFreeGoal(bytes int64) {
+	m.freeGoal = bytes
+}
+
+// SetExportTarget configures where the marked set is exported and in which format
+func (m *Model) SetExportTarget(target string, nulDelimited bool) {
+	m.exportTarget = target
+	m.exportNul = nulDelimited
+}
+
+// MarkedPaths returns the absolute paths of all marked files, sorted
+func (m *Model) MarkedPaths() []string {
+	return m.markedFiles.Paths()
+}
+
 // Init initializes the model
 func (m *Model) Init() tea.Cmd {
+	if m.scanning {
+		return m.activity.start(time.Now())
+	}
 	return nil
 }
 
@@ -139,6 +367,7 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		if m.topListView != nil {
 			m.topListView.SetHeight(viewHeight)
+			m.topListView.SetWidth(msg.Width)
 		}
 		if m.breakdownView != nil {
 			m.breakdownView.SetHeight(viewHeight)
@@ -149,6 +378,15 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.errorsView != nil {
 			m.errorsView.SetHeight(viewHeight)
 		}
+		if m.suggestionsView != nil {
+			m.suggestionsView.SetHeight(viewHeight)
+		}
+		if m.appsView != nil {
+			m.appsView.SetHeight(viewHeight)
+		}
+		if m.histogramView != nil {
+			m.histogramView.SetHeight(viewHeight)
+		}
 		return m, nil
 
 	case tea.KeyMsg:
@@ -156,11 +394,26 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.activeModal != ModalNone {
 			return m.handleModalInput(msg)
 		}
+		m.statusMessage = ""
 
 		switch msg.String() {
 		case "q", "ctrl+c":
+			if m.cancelScan != nil {
+				m.cancelScan()
+			}
+			if m.cancelDuplicates != nil {
+				m.cancelDuplicates()
+			}
 			return m, tea.Quit
 
+		case "R":
+			// Scan the root again to pick up changes made outside SpaceForce
+			if m.rescanning {
+				m.statusMessage = rescanBusyMessage
+			} else if !m.scanning {
+				return m, m.startRescan()
+			}
+
 		case "1":
 			m.currentView = ViewTree
 		case "2":
@@ -171,26 +424,178 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.currentView = ViewTimeline
 		case "5":
 			m.currentView = ViewErrors
+		case "6":
+			m.currentView = ViewSuggestions
+		case "7":
+			m.currentView = ViewApps
+		case "8":
+			m.currentView = ViewSizes
 
 		case "tab":
-			m.currentView = (m.currentView + 1) % 5
+			m.currentView = (m.currentView + 1) % viewCount
 
 		case "shift+tab":
 			// Navigate tabs in reverse
-			m.currentView = (m.currentView - 1 + 5) % 5
+			m.currentView = (m.currentView - 1 + viewCount) % viewCount
 
 		case "m":
-			// Mark/unmark current file
-			if !m.scanning {
+			// Mark/unmark current file (or mark the top list's visual selection)
+			if !m.scanning && m.currentView == ViewTopList && m.topListView != nil && m.topListView.VisualSelection() != nil {
+				m.markVisualSelection()
+			} else if !m.scanning {
 				m.toggleMarkCurrentFile()
 			}
 
+		case "M":
+			// Mark/unmark every file inside the current directory
+			if !m.scanning {
+				m.toggleMarkCurrentSubtree()
+			}
+
+		case "P":
+			// Mark every file matching a pattern
+			if !m.scanning && m.root != nil {
+				m.patternInput = ""
+				m.patternNodes = m.allNodes
+				m.patternMatch = views.PatternMatch{}
+				m.activeModal = ModalMarkPattern
+			}
+
+		case ">":
+			// List the files larger than a typed size
+			if !m.scanning && m.root != nil {
+				m.largerInput = ""
+				m.largerFiles = nil
+				m.activeModal = ModalLargerThan
+			}
+
+		case "C":
+			// Mark every no-risk suggestion and offer to move them all to the Trash
+			if m.rescanning {
+				m.statusMessage = rescanBusyMessage
+			} else if !m.scanning && m.suggestionsView != nil {
+				m.startSafeCleanup()
+			}
+
 		case "x":
 			// Delete marked files
-			if !m.scanning && len(m.markedFiles) > 0 {
+			if m.rescanning {
+				m.statusMessage = rescanBusyMessage
+			} else if !m.scanning && m.markedFiles.Len() > 0 {
+				m.diskSpaceBefore = -1
+				if m.root != nil {
+					if free, err := safety.FreeSpace(m.root.Path); err == nil {
+						m.diskSpaceBefore = free
+					}
+				}
+				m.sensitiveContents = m.findSensitiveContents(safety.NewProtector())
+				m.typedConfirmReason = m.typedConfirmNeeded(safety.NewProtector())
+				m.deleteInput = ""
+				m.manifestPath = ""
+				m.manifestErr = nil
 				m.activeModal = ModalDeleteConfirm
 			}
 
+		case "D":
+			// Quick-delete the selected no-risk cache directory after a one-line confirm
+			if m.rescanning {
+				m.statusMessage = rescanBusyMessage
+			} else if !m.scanning {
+				m.startQuickDelete()
+			}
+
+		case "p":
+			// Pause/resume a running scan; afterwards 'p' belongs to the views
+			if m.scanning {
+				m.scanner.TogglePause()
+				m.activity.observe(time.Now()) // Time spent paused is not a stall
+			} else {
+				return m.updateCurrentView(msg)
+			}
+
+		case "s":
+			// Skip the directory a running scan is stuck on; afterwards 's' belongs to the views
+			if m.scanning {
+				path := m.scanner.SkipCurrent()
+				if path != "" && (len(m.userSkips) == 0 || m.userSkips[len(m.userSkips)-1] != path) {
+					m.userSkips = append(m.userSkips, path)
+				}
+			} else {
+				return m.updateCurrentView(msg)
+			}
+
+		case "e":
+			// Export the error list from the Errors view, marked paths everywhere else
+			if !m.scanning && m.currentView == ViewErrors {
+				m.exportErrors()
+			} else if !m.scanning && m.markedFiles.Len() > 0 {
+				m.exportMarkedFiles()
+			}
+
+		case "r":
+			// Rescan permission-denied paths from the Errors view
+			if !m.scanning && m.currentView == ViewErrors {
+				return m, m.rescanDeniedPaths()
+			} else if !m.scanning {
+				return m.updateCurrentView(msg)
+			}
+
+		case "T":
+			// Delete Time Machine local snapshots from the Suggestions view
+			if !m.scanning && m.currentView == ViewSuggestions && len(m.snapshots) > 0 && !m.deletingSnapshots {
+				m.activeModal = ModalSnapshotDelete
+			} else if !m.scanning {
+				return m.updateCurrentView(msg)
+			}
+
+		case "S":
+			// List every skipped volume with its reason
+			if !m.scanning {
+				if len(m.skippedVolumes) == 0 {
+					m.statusMessage = "Nothing was skipped during the scan"
+				} else {
+					m.skippedScroll = 0
+					m.activeModal = ModalSkippedList
+				}
+			}
+
+		case "c":
+			// Copy a cd command for the selected item's directory
+			if !m.scanning {
+				m.copyCdCommand()
+			}
+
+		case "O":
+			// Open the selected file with its default app (directories are revealed)
+			if !m.scanning {
+				m.openCurrentNode()
+			}
+
+		case "A":
+			// Toggle coloring file names by age in the tree and top list
+			if !m.scanning {
+				m.colorByAge = !m.colorByAge
+				if m.treeView != nil {
+					m.treeView.SetColorByAge(m.colorByAge)
+	m.treeView.SetMaxChildren(m.maxTreeChildren)
+					m.topListView.SetColorByAge(m.colorByAge)
+				}
+				if m.colorByAge {
+					m.statusMessage = "Coloring file names by age: " + views.AgeLegend(m.timelineCutoffs)
+				} else {
+					m.statusMessage = "Age coloring off"
+				}
+			}
+
+		case "a":
+			// Toggle apparent vs allocated (on-disk) sizes everywhere
+			if !m.scanning && m.root != nil {
+				m.allocated = !m.allocated
+				m.root.SetUseAllocatedSize(m.allocated)
+				m.stats = scanner.CalculateStats(m.root) // Type totals are in the size mode
+				m.rebuildViews()
+			}
+
 		default:
 			// Pass key to current view
 			if !m.scanning {
@@ -201,27 +606,45 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case ScanCompleteMsg:
 		m.scanning = false
 		m.root = msg.Root
+		if m.root != nil && m.allocated {
+			m.root.SetUseAllocatedSize(true) // A rescan keeps the size mode chosen with 'a'
+		}
 		m.err = msg.Err
 		m.skippedVolumes = msg.SkippedVolumes
 		m.showSkippedInfo = len(msg.SkippedVolumes) > 0
+		m.cancelScan = nil
+		m.progress = msg.Progress
 
 		if m.root != nil {
+			files := msg.Progress.FilesScanned - msg.Progress.DirsScanned
+			if !m.root.IsDir {
+				files = 1 // A single-file scan root is not counted as it is read
+			}
+			m.lastScan = &scanSummary{
+				files:   files,
+				dirs:    msg.Progress.DirsScanned,
+				bytes:   m.root.TotalSize(),
+				errors:  len(msg.Progress.Errors),
+				elapsed: msg.Elapsed,
+			}
+			m.allNodes = scanner.FlattenTree(m.root)
+			m.stats = scanner.CalculateStats(m.root)
+
+			// Marks from before a rescan point at the old tree
+			m.remapMarkedFiles()
+
 			// Initialize all views
-			m.treeView = views.NewTreeView(m.root)
-			m.topListView = views.NewTopListView(m.root)
-			m.breakdownView = views.NewBreakdownView(m.root)
-			m.timelineView = views.NewTimelineView(m.root)
-
-			// Set initial height and width based on current window size
-			viewHeight := m.height - 8
-			if viewHeight < 5 {
-				viewHeight = 5
+			m.rebuildViews()
+
+			if m.reselectPath != "" {
+				m.treeView.SelectAndExpandToNode(m.reselectPath)
+				m.topListView.SelectPath(m.reselectPath)
+				m.reselectPath = ""
+			}
+
+			if m.markFrom != nil {
+				m.applyMarkFrom()
 			}
-			m.treeView.SetHeight(viewHeight)
-			m.treeView.SetWidth(m.width)
-			m.topListView.SetHeight(viewHeight)
-			m.breakdownView.SetHeight(viewHeight)
-			m.timelineView.SetHeight(viewHeight)
 		}
 
 		// Initialize errors view (even if no errors)
@@ -234,60 +657,146 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		m.errorsView.SetHeight(viewHeight)
 
+		if m.root != nil {
+			return m, tea.Batch(loadSnapshots(), m.startDuplicateSearch())
+		}
+		return m, nil
+
+	case DuplicatesFoundMsg:
+		if msg.Search != m.duplicateSearch {
+			return m, nil // Superseded by a newer search
+		}
+		m.findingDuplicates = false
+		m.cancelDuplicates = nil
+		if msg.Err != nil {
+			m.duplicates = nil
+			m.statusMessage = fmt.Sprintf("Duplicate search failed: %v", msg.Err)
+		} else {
+			// The search hashed the tree as it was when it started
+			m.duplicates = analyzer.PruneDuplicates(msg.Groups, m.deletedMidSearch)
+		}
+		m.deletedMidSearch = nil
+		if m.suggestionsView != nil {
+			m.suggestionsView.SetDuplicates(m.duplicates, false)
+		}
+		return m, nil
+
+	case TrashEmptiedMsg:
+		if msg.Err != nil {
+			m.statusMessage = fmt.Sprintf("Error: %v", msg.Err)
+		} else {
+			m.statusMessage = fmt.Sprintf("✓ Emptied the Trash: %s freed", util.FormatBytesPlain(msg.Freed))
+		}
+		return m, nil
+
+	case SnapshotsLoadedMsg:
+		m.snapshots = msg.Snapshots
+		if m.suggestionsView != nil {
+			m.suggestionsView.SetSnapshots(m.snapshots)
+		}
+		return m, nil
+
+	case SnapshotsDeletedMsg:
+		m.deletingSnapshots = false
+		if len(msg.Errors) > 0 {
+			m.statusMessage = fmt.Sprintf("Deleted %d snapshot(s); %d failed: %v (deleting snapshots may need sudo tmutil)",
+				msg.Deleted, len(msg.Errors), msg.Errors[0])
+		} else {
+			m.statusMessage = fmt.Sprintf("✓ Deleted %d local snapshot(s); macOS may take a moment to reclaim the space", msg.Deleted)
+		}
+		return m, loadSnapshots()
+
+	case RescanCompleteMsg:
+		m.rescanning = false
+		m.scanner.MergeSubtrees(msg.Nodes)
+		m.progress = m.scanner.GetProgress()
+		if m.root != nil {
+			m.allNodes = scanner.FlattenTree(m.root)
+			m.stats = scanner.CalculateStats(m.root)
+			m.rebuildViews()
+		}
+		m.errorsView = views.NewErrorsView(m.progress.Errors)
+		viewHeight := m.height - 8
+		if viewHeight < 5 {
+			viewHeight = 5
+		}
+		m.errorsView.SetHeight(viewHeight)
+
+		denied := scanner.PermissionDeniedPaths(m.progress.Errors)
+		if len(denied) > 0 {
+			command, purpose := util.AccessCommand(denied)
+			m.statusMessage = fmt.Sprintf("Rescanned %d path(s); %d still denied. To %s, run: %s",
+				len(msg.Nodes), len(denied), purpose, command)
+			if util.CopyToClipboard(command) == nil {
+				m.statusMessage += " (copied to the clipboard)"
+			}
+		} else {
+			m.statusMessage = fmt.Sprintf("✓ Rescanned %d path(s); no permission errors left", len(msg.Nodes))
+		}
 		return m, nil
 
 	case ScanProgressMsg:
 		m.progress = scanner.ScanProgress(msg)
+		m.scanRates.observe(m.progress.FilesScanned, time.Now())
+		m.activity.observe(time.Now())
 		return m, nil
 
+	case rescanProgressMsg:
+		m.progress = msg.progress
+		m.scanRates.observe(m.progress.FilesScanned, time.Now())
+		m.activity.observe(time.Now())
+		return m, waitForProgress(msg.progressChan)
+
+	case spinnerTickMsg:
+		if m.scanning && !m.scanner.IsPaused() {
+			// Sampled on the clock too: a scan stuck in one directory sends no progress,
+			// and should draw a falling line rather than freeze at the last rate
+			m.scanRates.observe(m.progress.FilesScanned, time.Now())
+		}
+		return m, m.activity.tick(m.scanning, m.scanning && m.scanner.IsPaused())
+
 	case DeleteCompleteMsg:
 		// Store deletion results
 		m.deleteProgress.FilesDeleted = msg.ItemsDeleted
 		m.deleteProgress.TotalFilesDeleted = msg.TotalFilesDeleted
 		m.deleteProgress.BytesDeleted = msg.BytesDeleted
 		m.deleteProgress.Errors = msg.Errors
+		m.deleteProgress.Protected = msg.Protected
+		m.deleteProgress.Verified = msg.Verified
+		m.deleteProgress.Leftovers = msg.Leftovers
+		m.deleteProgress.TrashSize = msg.TrashSize
+		m.deleteProgress.Method = msg.Method
 
 		// Remove deleted nodes from the tree
+		removed := make([]*scanner.FileNode, 0, len(msg.DeletedPaths))
 		for _, path := range msg.DeletedPaths {
-			m.removeNodeFromTree(path)
+			if node := m.removeNodeFromTree(path); node != nil {
+				removed = append(removed, node)
+			}
+		}
+		m.duplicates = analyzer.PruneDuplicates(m.duplicates, msg.DeletedPaths)
+		if m.findingDuplicates {
+			m.deletedMidSearch = append(m.deletedMidSearch, msg.DeletedPaths...)
 		}
 
-		// Rebuild all views with updated tree
+		// Update the views in place rather than rebuilding them from the whole tree
 		if m.root != nil {
-			m.treeView = views.NewTreeView(m.root)
-			m.topListView = views.NewTopListView(m.root)
-			m.breakdownView = views.NewBreakdownView(m.root)
-			m.timelineView = views.NewTimelineView(m.root)
-
-			// Set dimensions for all views
-			viewHeight := m.height - 8
-			if viewHeight < 5 {
-				viewHeight = 5
-			}
-
-			m.treeView.SetHeight(viewHeight)
-			m.treeView.SetWidth(m.width)
-			m.topListView.SetHeight(viewHeight)
-			m.breakdownView.SetHeight(viewHeight)
-			m.timelineView.SetHeight(viewHeight)
+			removal := views.NewRemoval(removed)
+			m.allNodes = removal.Without(m.allNodes)
+			m.removeFromViews(removal)
 
-			// Restore marked files (but remove deleted ones)
-			remainingMarked := make(map[string]*scanner.FileNode)
-			for path, node := range m.markedFiles {
-				// Check if this path was deleted
-				wasDeleted := false
-				for _, deletedPath := range msg.DeletedPaths {
-					if path == deletedPath {
-						wasDeleted = true
-						break
-					}
-				}
-				if !wasDeleted {
-					remainingMarked[path] = node
-				}
-			}
-			m.markedFiles = remainingMarked
+			// Keep marked files that were not deleted
+			m.markedFiles.RemovePaths(msg.DeletedPaths)
 			m.updateMarkedFilesInViews()
+		} else {
+			// The scan root itself was deleted (e.g. a single scanned file)
+			m.markedFiles.RemovePaths(msg.DeletedPaths)
+		}
+
+		// A quick delete only reports on the status line
+		if msg.Quick {
+			m.statusMessage = m.quickDeleteResult(msg)
+			return m, nil
 		}
 
 		// Show summary modal
@@ -302,6 +811,14 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case views.ShowInTopListMsg:
+		// Drill-down from an aggregate view into its files
+		m.currentView = ViewTopList
+		if m.topListView != nil {
+			m.topListView.SetFileFilter(msg.Label, msg.Files)
+		}
+		return m, nil
+
 	default:
 		// Handle string-based messages from views (to avoid import cycles)
 		if strMsg, ok := msg.(string); ok && strings.HasPrefix(strMsg, "JUMP_TO_TREE:") {
@@ -317,6 +834,75 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// rebuildViews recreates the tree-based views from m.root, m.allNodes and m.stats
+// (after a rescan or a size mode change)
+func (m *Model) rebuildViews() {
+	m.treeView = views.NewTreeView(m.root)
+	m.treeView.SetLinkPath(m.linkPath)
+	m.treeView.SetTimelineCutoffs(m.timelineCutoffs)
+	m.treeView.SetColorByAge(m.colorByAge)
+	m.topListView = views.NewTopListView(m.root, m.allNodes)
+	m.topListView.SetTimelineCutoffs(m.timelineCutoffs)
+	m.topListView.SetColorByAge(m.colorByAge)
+	m.breakdownView = views.NewBreakdownView(m.root, m.stats)
+	if used, err := safety.UsedSpace(m.root.Path); err == nil {
+		m.breakdownView.SetVolumeUsage(used)
+	}
+	m.timelineView = views.NewTimelineView(m.allNodes, m.timelineCutoffs)
+
+	// Carry the old-files cutoff over so a rebuild doesn't reset the user's tuning
+	oldFileMonths := 0
+	if m.suggestionsView != nil {
+		oldFileMonths = m.suggestionsView.OldFileMonths()
+	}
+	m.suggestionsView = views.NewSuggestionsView(m.root, m.allNodes)
+	m.suggestionsView.SetSnapshots(m.snapshots)
+	m.suggestionsView.SetDuplicates(m.duplicates, m.findingDuplicates)
+	if oldFileMonths > 0 {
+		m.suggestionsView.SetOldFileMonths(oldFileMonths)
+	}
+	m.appsView = views.NewAppsView(m.root)
+	m.histogramView = views.NewHistogramView(m.allNodes)
+
+	// Set dimensions for all views
+	viewHeight := m.height - 8
+	if viewHeight < 5 {
+		viewHeight = 5
+	}
+
+	m.treeView.SetHeight(viewHeight)
+	m.treeView.SetWidth(m.width)
+	m.topListView.SetHeight(viewHeight)
+	m.topListView.SetWidth(m.width)
+	m.breakdownView.SetHeight(viewHeight)
+	m.timelineView.SetHeight(viewHeight)
+	m.suggestionsView.SetHeight(viewHeight)
+	m.appsView.SetHeight(viewHeight)
+	m.histogramView.SetHeight(viewHeight)
+
+	m.updateMarkedFilesInViews()
+}
+
+// removeFromViews takes deleted subtrees out of the tree-based views
+func (m *Model) removeFromViews(r *views.Removal) {
+	if m.treeView == nil {
+		m.rebuildViews()
+		return
+	}
+
+	m.treeView.RemoveNodes(r)
+	m.topListView.RemoveNodes(r)
+	m.breakdownView.RemoveNodes(r)
+	if used, err := safety.UsedSpace(m.root.Path); err == nil {
+		m.breakdownView.SetVolumeUsage(used)
+	}
+	m.timelineView.RemoveNodes(r)
+	m.suggestionsView.RemoveNodes(r)
+	m.suggestionsView.SetDuplicates(m.duplicates, m.findingDuplicates)
+	m.appsView.RemoveNodes(r)
+	m.histogramView.RemoveNodes(r)
+}
+
 // updateCurrentView updates the active view with a message
 func (m *Model) updateCurrentView(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch m.currentView {
@@ -350,6 +936,24 @@ func (m *Model) updateCurrentView(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.errorsView = newView
 			return m, cmd
 		}
+	case ViewSuggestions:
+		if m.suggestionsView != nil {
+			newView, cmd := m.suggestionsView.Update(msg)
+			m.suggestionsView = newView
+			return m, cmd
+		}
+	case ViewApps:
+		if m.appsView != nil {
+			newView, cmd := m.appsView.Update(msg)
+			m.appsView = newView
+			return m, cmd
+		}
+	case ViewSizes:
+		if m.histogramView != nil {
+			newView, cmd := m.histogramView.Update(msg)
+			m.histogramView = newView
+			return m, cmd
+		}
 	}
 	return m, nil
 }
@@ -370,7 +974,10 @@ func (m *Model) View() string {
 	b.WriteString(lipgloss.NewStyle().
 		Bold(true).
 		Foreground(ColorPrimary).
-		Render("🚀 SpaceForce - Disk Space Analyzer"))
+		Render("🚀 SpaceForce - Disk Space Analyzer" + m.sizeModeLabel()))
+	if reclaimable := m.reclaimableLabel(); reclaimable != "" {
+		b.WriteString(lipgloss.NewStyle().Foreground(ColorSuccess).Bold(true).Render(reclaimable))
+	}
 	b.WriteString("\n")
 
 	// Tabs (1 line)
@@ -387,16 +994,22 @@ func (m *Model) View() string {
 
 	b.WriteString(viewContent)
 
-	// Help footer (1 line)
+	// Help footer (1 line), or the quick delete confirm in its place
 	if m.activeModal == ModalNone {
 		b.WriteString("\n")
 		b.WriteString(m.renderHelp())
+	} else if m.activeModal == ModalQuickDelete {
+		b.WriteString("\n")
+		b.WriteString(m.renderQuickDeletePrompt())
 	}
 
-	// Show skipped volumes info if any (1 line)
-	if m.showSkippedInfo && m.activeModal == ModalNone {
+	// Show status, or the scan summary and skipped volumes info (1 line)
+	if m.statusMessage != "" && m.activeModal == ModalNone {
+		b.WriteString("\n")
+		b.WriteString(HelpStyle.UnsetMarginTop().Render(m.statusMessage))
+	} else if (m.lastScan != nil || m.showSkippedInfo || m.linkPath != "") && m.activeModal == ModalNone {
 		b.WriteString("\n")
-		b.WriteString(m.renderSkippedInfo())
+		b.WriteString(m.renderScanInfo())
 	}
 
 	// Pad remaining height with empty lines to clear any artifacts from resizing
@@ -412,6 +1025,22 @@ func (m *Model) View() string {
 	return content
 }
 
+// sizeModeLabel describes which size is being displayed
+func (m *Model) sizeModeLabel() string {
+	if m.allocated {
+		return " [sizes: allocated on disk]"
+	}
+	return " [sizes: apparent]"
+}
+
+// reclaimableLabel headlines the safely reclaimable estimate ("" when there is none)
+func (m *Model) reclaimableLabel() string {
+	if m.suggestionsView == nil || m.suggestionsView.Reclaimable() <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("  ~%s safely reclaimable", util.FormatBytesPlain(m.suggestionsView.Reclaimable()))
+}
+
 // renderTabs renders the tab navigation
 func (m *Model) renderTabs() string {
 	// Build tab labels with error count if applicable
@@ -426,6 +1055,9 @@ func (m *Model) renderTabs() string {
 		"3:Breakdown",
 		"4:Timeline",
 		"5:Errors" + errorCount,
+		"6:Suggestions",
+		"7:Apps",
+		"8:Sizes",
 	}
 
 	var rendered []string
@@ -442,6 +1074,10 @@ func (m *Model) renderTabs() string {
 
 // renderCurrentView renders the active view
 func (m *Model) renderCurrentView() string {
+	if m.root == nil {
+		return HelpStyle.Render(fmt.Sprintf("%s was deleted; there is nothing left to show (q: quit)", m.rootPath))
+	}
+
 	switch m.currentView {
 	case ViewTree:
 		if m.treeView != nil {
@@ -463,6 +1099,18 @@ func (m *Model) renderCurrentView() string {
 		if m.errorsView != nil {
 			return m.errorsView.View()
 		}
+	case ViewSuggestions:
+		if m.suggestionsView != nil {
+			return m.suggestionsView.View()
+		}
+	case ViewApps:
+		if m.appsView != nil {
+			return m.appsView.View()
+		}
+	case ViewSizes:
+		if m.histogramView != nil {
+			return m.histogramView.View()
+		}
 	}
 	return "Loading..."
 }
@@ -471,7 +1119,11 @@ func (m *Model) renderCurrentView() string {
 func (m *Model) renderScanningView() string {
 	var b strings.Builder
 
-	b.WriteString(TitleStyle.Render("🔍 Scanning Filesystem..."))
+	if m.scanner.IsPaused() {
+		b.WriteString(TitleStyle.Render("⏸ Paused — press p to resume"))
+	} else {
+		b.WriteString(TitleStyle.Render("🔍 Scanning Filesystem... " + m.activity.spinner()))
+	}
 	b.WriteString("\n\n")
 
 	// Progress bar based on bytes scanned
@@ -495,15 +1147,45 @@ func (m *Model) renderScanningView() string {
 
 	// Progress stats
 	statsStyle := lipgloss.NewStyle().Bold(true).Foreground(ColorSuccess)
-	b.WriteString(statsStyle.Render(fmt.Sprintf("Files scanned: %s", formatNumber(m.progress.FilesScanned))))
+	b.WriteString(statsStyle.Render(fmt.Sprintf("Files scanned: %s (%s folders)",
+		formatNumber(m.progress.FilesScanned), formatNumber(m.progress.DirsScanned))))
 	b.WriteString("\n")
 
+	// Recent scan rate - a falling line means the scan is stuck in a slow directory
+	if m.scanRates.count > 0 {
+		rateStyle := lipgloss.NewStyle().Foreground(ColorSecondary)
+		b.WriteString(rateStyle.Render(fmt.Sprintf("%s  %s files/s",
+			renderSparkline(m.scanRates.values()), formatNumber(int64(m.scanRates.latest())))))
+		b.WriteString("\n")
+	}
+
 	// Show iCloud files skipped if any
 	if m.progress.ICloudFilesSkipped > 0 {
 		icloudStyle := lipgloss.NewStyle().Foreground(ColorSecondary)
 		b.WriteString(icloudStyle.Render(fmt.Sprintf("iCloud placeholders skipped: %s", formatNumber(m.progress.ICloudFilesSkipped))))
 		b.WriteString("\n")
 	}
+
+	// Show hidden entries skipped if any
+	if m.progress.HiddenSkipped > 0 {
+		hiddenStyle := lipgloss.NewStyle().Foreground(ColorSecondary)
+		b.WriteString(hiddenStyle.Render(fmt.Sprintf("Hidden entries skipped: %s", formatNumber(m.progress.HiddenSkipped))))
+		b.WriteString("\n")
+	}
+
+	// Show .gitignore'd entries skipped if any
+	if m.progress.GitignoreSkipped > 0 {
+		ignoredStyle := lipgloss.NewStyle().Foreground(ColorSecondary)
+		b.WriteString(ignoredStyle.Render(fmt.Sprintf("Ignored by .gitignore: %s", formatNumber(m.progress.GitignoreSkipped))))
+		b.WriteString("\n")
+	}
+
+	// Show duplicate hard links that were not double-counted
+	if m.progress.HardLinksDeduped > 0 {
+		linkStyle := lipgloss.NewStyle().Foreground(ColorSecondary)
+		b.WriteString(linkStyle.Render(fmt.Sprintf("Duplicate hard links not counted: %s", formatNumber(m.progress.HardLinksDeduped))))
+		b.WriteString("\n")
+	}
 	b.WriteString("\n")
 
 	// Current path - show more prominently
@@ -511,7 +1193,12 @@ func (m *Model) renderScanningView() string {
 		Foreground(ColorPrimary).
 		Bold(true)
 
-	b.WriteString(lipgloss.NewStyle().Faint(true).Render("Currently scanning:"))
+	// No progress for a while: most likely one slow directory (network, huge folder)
+	label := "Currently scanning:"
+	if quiet := m.activity.stalled(time.Now()); quiet > 0 && !m.scanner.IsPaused() {
+		label = fmt.Sprintf("Still working on (no new files for %s):", quiet.Round(time.Second))
+	}
+	b.WriteString(lipgloss.NewStyle().Faint(true).Render(label))
 	b.WriteString("\n")
 
 	// Truncate path if too long, but keep more visible
@@ -526,6 +1213,18 @@ func (m *Model) renderScanningView() string {
 	b.WriteString(pathStyle.Render(currentPath))
 	b.WriteString("\n")
 
+	// Directories skipped with 's' (listed with the other skips once the scan is done)
+	if len(m.userSkips) > 0 {
+		skipStyle := lipgloss.NewStyle().Foreground(ColorSecondary)
+		last := m.userSkips[len(m.userSkips)-1]
+		line := "Skipped by you: " + last
+		if len(m.userSkips) > 1 {
+			line += fmt.Sprintf(" (+%d more)", len(m.userSkips)-1)
+		}
+		b.WriteString(skipStyle.Render(line))
+		b.WriteString("\n")
+	}
+
 	if len(m.progress.Errors) > 0 {
 		b.WriteString("\n")
 		warningStyle := lipgloss.NewStyle().Foreground(ColorWarning).Bold(true)
@@ -535,7 +1234,7 @@ func (m *Model) renderScanningView() string {
 	}
 
 	b.WriteString("\n\n")
-	b.WriteString(HelpStyle.Render("Tip: Large scans can take several minutes • Press 'q' to cancel"))
+	b.WriteString(HelpStyle.Render("Tip: Large scans can take several minutes • Press 'p' to pause, 's' to skip the current folder, 'q' to cancel"))
 
 	// Pad remaining height with empty lines to clear any artifacts from resizing
 	content := b.String()
@@ -587,7 +1286,7 @@ func (m *Model) renderError() string {
 }
 
 // renderSkippedInfo renders information about skipped network volumes
-func (m *Model) renderSkippedInfo() string {
+func (m *Model) renderSkippedInfo(maxWidth int) string {
 	count := len(m.skippedVolumes)
 	if count == 0 {
 		return ""
@@ -597,55 +1296,147 @@ func (m *Model) renderSkippedInfo() string {
 		Foreground(ColorWarning).
 		Italic(true)
 
-	msg := fmt.Sprintf("ℹ Skipped %d network volume(s). Use -skip-network=false to include them.", count)
+	msg := fmt.Sprintf("ℹ Skipped %d network volume(s) (S: list). Use -skip-network=false to include them.", count)
 
 	// Truncate if too long to prevent wrapping
+	if len(msg) > maxWidth {
+		msg = msg[:maxWidth-3] + "..."
+	}
+
+	return infoStyle.Render(msg)
+}
+
+// renderScanInfo renders the last scan's summary, followed by the skipped volumes info if any
+func (m *Model) renderScanInfo() string {
 	maxWidth := m.width - 10
 	if maxWidth < 80 {
 		maxWidth = 80
 	}
-	if len(msg) > maxWidth {
-		msg = msg[:maxWidth-3] + "..."
+
+	summary := ""
+	if m.lastScan != nil {
+		summary = m.lastScan.String()
+	}
+	if m.linkPath != "" {
+		link := fmt.Sprintf("%s → %s", m.linkPath, m.rootPath)
+		if summary == "" {
+			summary = link
+		} else {
+			summary = link + " • " + summary
+		}
+	}
+	if !m.showSkippedInfo {
+		return HelpStyle.UnsetMarginTop().Render(summary)
+	}
+	if summary == "" {
+		return m.renderSkippedInfo(maxWidth)
 	}
 
-	return infoStyle.Render(msg)
+	// The summary comes first; the skipped volumes info gets what is left of the line
+	summary += " • "
+	remaining := maxWidth - len(summary)
+	if remaining < 20 {
+		return HelpStyle.UnsetMarginTop().Render(summary[:len(summary)-3])
+	}
+	return HelpStyle.UnsetMarginTop().Render(summary) + m.renderSkippedInfo(remaining)
+}
+
+// String describes the scan, e.g. "Scanned 1,204 files in 87 folders, 4.2 GiB in 3s"
+func (s *scanSummary) String() string {
+	elapsed := s.elapsed.Round(time.Second)
+	if s.elapsed < time.Second {
+		elapsed = s.elapsed.Round(time.Millisecond)
+	}
+
+	summary := fmt.Sprintf("Scanned %s files in %s folders, %s in %s",
+		formatNumber(s.files), formatNumber(s.dirs), util.FormatBytesPlain(s.bytes), elapsed)
+	if s.errors > 0 {
+		summary += fmt.Sprintf(", %s error(s)", formatNumber(int64(s.errors)))
+	}
+	return summary
 }
 
 // renderHelp renders help text
 func (m *Model) renderHelp() string {
 	helps := []string{
 		"tab/shift+tab: switch view",
-		"1-5: jump to view",
+		"1-8: jump to view",
 		"↑↓/jk: navigate",
+		"a: apparent/allocated",
+		"A: color by age",
+		"c: copy cd command",
+		"O: open",
+		"D: quick-delete cache",
+		"C: safe cleanup",
+		"R: rescan",
+		"S: skipped list",
 		"q: quit",
 	}
 
 	// Add view-specific help
 	switch m.currentView {
 	case ViewTree:
-		helps = append(helps, "enter/space: expand/collapse", "←→/hl: expand/collapse", "s: change sort", "z: zoom in", "u: zoom out")
+		helps = append(helps, "enter/space: expand/collapse", "←→/hl: expand/collapse", "s: change sort", "L: largest child", "z: zoom in", "u: zoom out", "b: jump up")
 	case ViewTopList:
-		helps = append(helps, "enter: jump to tree", "s: change sort", "f: toggle files", "d: toggle dirs")
+		helps = append(helps, "enter: jump to tree", "s: change sort", "f: toggle files", "d: toggle dirs", "p: protected show/dim/hide", "w: what changed", "v: visual select", "g: group by folder", "%: % of total", "esc: clear filter")
+	case ViewBreakdown:
+		helps = append(helps, "enter: list files of type", "t: largest in tree", "g: extension/category", "i: identify no-extension files")
+	case ViewTimeline:
+		helps = append(helps, "enter: list files of period", "t: largest in tree")
+	case ViewSuggestions:
+		helps = append(helps, "enter: jump to tree", "+/-: old-file age")
+		if len(m.snapshots) > 0 {
+			helps = append(helps, "T: delete local snapshots")
+		}
+	case ViewErrors:
+		helps = append(helps, "g: group by type", "enter: expand/collapse group", "e: export errors", "r: rescan denied")
+	case ViewApps:
+		helps = append(helps, "enter: jump to largest container")
+	case ViewSizes:
+		helps = append(helps, "enter: jump to largest file")
 	}
 
 	// Add marking/deletion help if files are marked
-	if len(m.markedFiles) > 0 {
-		helps = append(helps, "m: mark/unmark", fmt.Sprintf("x: delete %d marked", len(m.markedFiles)))
+	if m.markedFiles.Len() > 0 {
+		helps = append(helps, "m: mark/unmark", "M: mark all inside", "P: mark by pattern", fmt.Sprintf("x: delete %d marked", m.markedFiles.Len()), "e: export marked")
 	} else {
 		helps = append(helps, "m: mark file for deletion")
 	}
 
 	helpText := strings.Join(helps, " | ")
 
+	// Live readout of what is queued for deletion, shown first so truncation never hides it
+	// With a -target goal it is always shown, and turns green once enough is marked
+	markedText := ""
+	markedColor := ColorWarning
+	if m.markedFiles.Len() > 0 || m.freeGoal > 0 {
+		markedText = fmt.Sprintf("Marked: %d item(s), %s", m.markedFiles.Len(), util.FormatBytesPlain(m.markedSize))
+	}
+	if m.freeGoal > 0 {
+		markedText += fmt.Sprintf(" / %s target", util.FormatBytesPlain(m.freeGoal))
+		if m.markedSize >= m.freeGoal {
+			markedText += " ✓"
+			markedColor = ColorSuccess
+		}
+	}
+
 	// Truncate if too long to prevent wrapping (leave room for styling)
 	maxWidth := m.width - 10
 	if maxWidth < 80 {
 		maxWidth = 80
 	}
+	if markedText != "" {
+		maxWidth -= len(markedText) + 3
+	}
 	if len(helpText) > maxWidth {
 		helpText = helpText[:maxWidth-3] + "..."
 	}
 
+	if markedText != "" {
+		// "\n" stands in for HelpStyle's top margin so both parts share one line
+		return "\n" + lipgloss.NewStyle().Foreground(markedColor).Bold(true).Render(markedText) +
+			HelpStyle.UnsetMarginTop().Render(" | "+helpText)
+	}
 	return HelpStyle.Render(helpText)
 }
 
@@ -656,18 +1447,301 @@ func (m *Model) toggleMarkCurrentFile() {
 		return
 	}
 
-	if _, exists := m.markedFiles[node.Path]; exists {
-		delete(m.markedFiles, node.Path)
-	} else {
-		m.markedFiles[node.Path] = node
-	}
+	m.markedFiles.Toggle(node)
 
 	// Update all views with the new marked files map
 	m.updateMarkedFilesInViews()
 }
 
+// markVisualSelection marks every item in the top list's visual selection and leaves visual mode
+// Protected items are skipped, as are items inside a selected directory
+func (m *Model) markVisualSelection() {
+	match := views.SplitBySafety(m.topListView.VisualSelection(), safety.NewProtector())
+	m.topListView.EndVisual()
+
+	for _, node := range match.Safe {
+		m.markedFiles.Add(node)
+	}
+	m.statusMessage = fmt.Sprintf("✓ Marked %d item(s) (%s)", len(match.Safe), util.FormatBytesPlain(totalSize(match.Safe)))
+	if len(match.Protected) > 0 {
+		m.statusMessage += fmt.Sprintf(", skipped %d protected", len(match.Protected))
+	}
+	m.updateMarkedFilesInViews()
+}
+
+// toggleMarkCurrentSubtree marks all files beneath the selected directory (a second press unmarks them)
+func (m *Model) toggleMarkCurrentSubtree() {
+	node := m.getCurrentNode()
+	if node == nil || !node.IsDir {
+		return
+	}
+
+	count, marked := m.markedFiles.ToggleSubtree(node)
+	switch {
+	case count == 0:
+		m.statusMessage = fmt.Sprintf("No files to mark in %s", node.Name)
+	case marked:
+		m.statusMessage = fmt.Sprintf("✓ Marked %d file(s) in %s", count, node.Name)
+	default:
+		m.statusMessage = fmt.Sprintf("Unmarked %d file(s) in %s", count, node.Name)
+	}
+
+	m.updateMarkedFilesInViews()
+}
+
+// rescanProgressMsg carries progress from a full rescan, along with the channel to keep listening on
+type rescanProgressMsg struct {
+	progress     scanner.ScanProgress
+	progressChan <-chan scanner.ScanProgress
+}
+
+// waitForProgress waits for the next progress update of a full rescan
+// The scanner closes the channel when Scan returns, which ends the chain
+func waitForProgress(progressChan <-chan scanner.ScanProgress) tea.Cmd {
+	return func() tea.Msg {
+		progress, ok := <-progressChan
+		if !ok {
+			return nil
+		}
+		return rescanProgressMsg{progress: progress, progressChan: progressChan}
+	}
+}
+
+// startRescan scans the original root again in the background with the same options
+// The current view is kept, and the selected path is restored when the scan completes
+func (m *Model) startRescan() tea.Cmd {
+	if m.newScanner == nil {
+		m.statusMessage = "Rescan is not available"
+		return nil
+	}
+
+	if node := m.getCurrentNode(); node != nil {
+		m.reselectPath = node.Path
+	}
+
+	scn := m.newScanner()
+	m.scanner = scn
+	m.scanning = true
+	m.progress = scanner.ScanProgress{}
+	m.scanRates = rateHistory{}
+	m.userSkips = nil
+	spin := m.activity.start(time.Now())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelScan = cancel
+
+	rootPath := m.rootPath
+	progressChan := make(chan scanner.ScanProgress, 100)
+	scan := func() tea.Msg {
+		start := time.Now()
+		root, err := scn.Scan(ctx, rootPath, progressChan)
+		return ScanCompleteMsg{
+			Root:           root,
+			Err:            err,
+			SkippedVolumes: scn.GetSkippedVolumes(),
+			Progress:       scn.GetProgress(),
+			Elapsed:        time.Since(start),
+		}
+	}
+	return tea.Batch(scan, waitForProgress(progressChan), spin)
+}
+
+// remapMarkedFiles points marks at the nodes of the current tree, dropping paths that are gone
+func (m *Model) remapMarkedFiles() {
+	marked := m.markedFiles.Snapshot()
+	if len(marked) == 0 {
+		return
+	}
+
+	byPath := make(map[string]*scanner.FileNode)
+	for _, node := range m.allNodes {
+		byPath[node.Path] = node
+	}
+
+	m.markedFiles.Clear()
+	for path := range marked {
+		if node, ok := byPath[path]; ok {
+			m.markedFiles.Add(node)
+		}
+	}
+}
+
+// rescanDeniedPaths scans the permission-denied directories again in the background
+// Useful after granting Full Disk Access, without starting the whole scan over
+func (m *Model) rescanDeniedPaths() tea.Cmd {
+	if m.rescanning {
+		return nil
+	}
+	paths := scanner.PermissionDeniedPaths(m.progress.Errors)
+	if len(paths) == 0 {
+		m.statusMessage = "No permission-denied paths to rescan"
+		return nil
+	}
+
+	// Resolved here, since the rescan runs in the background while the tree may change
+	targets := m.scanner.RescanTargets(paths)
+	m.rescanning = true
+	m.statusMessage = fmt.Sprintf("Rescanning %d permission-denied path(s)...", len(paths))
+	scn := m.scanner
+	return func() tea.Msg {
+		return RescanCompleteMsg{Nodes: scn.RescanPaths(context.Background(), targets)}
+	}
+}
+
+// exportErrors writes the scan errors, grouped by type, to errorsExportFile in the home directory
+func (m *Model) exportErrors() {
+	if m.errorsView == nil || m.errorsView.GetErrorCount() == 0 {
+		m.statusMessage = "No errors to export"
+		return
+	}
+
+	target := errorsExportFile
+	if homeDir, err := safety.HomeDir(); err == nil {
+		target = filepath.Join(homeDir, errorsExportFile)
+	}
+
+	file, err := os.Create(target)
+	if err == nil {
+		err = m.errorsView.WriteErrors(file)
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		m.statusMessage = fmt.Sprintf("✗ Error export failed: %v", err)
+		return
+	}
+	m.statusMessage = fmt.Sprintf("✓ Wrote %d error(s) to %s", m.errorsView.GetErrorCount(), target)
+}
+
+// exportMarkedFiles writes the marked set to the export file and reports the result
+func (m *Model) exportMarkedFiles() {
+	// Stdout is owned by the TUI until exit, so 'e' writes to a file instead
+	target := m.exportTarget
+	if target == "" || target == "-" {
+		target = defaultExportFile
+	}
+
+	paths := m.MarkedPaths()
+	if err := util.ExportPathList(target, paths, m.exportNul); err != nil {
+		m.statusMessage = fmt.Sprintf("✗ Export failed: %v", err)
+		return
+	}
+
+	format := "shell-quoted"
+	if m.exportNul {
+		format = "NUL-delimited"
+	}
+	m.statusMessage = fmt.Sprintf("✓ Exported %d marked path(s) to %s (%s)", len(paths), target, format)
+}
+
+// applyMarkFrom marks the -mark-from paths that are in the scanned tree and reports
+// how many were missing (deleted since, or outside the scanned path)
+func (m *Model) applyMarkFrom() {
+	nodes := make(map[string]*scanner.FileNode, len(m.allNodes))
+	for _, node := range m.allNodes {
+		nodes[node.Path] = node
+	}
+
+	marked, missing := 0, 0
+	for _, path := range m.markFrom {
+		node, ok := nodes[filepath.Clean(path)]
+		if !ok {
+			missing++
+			continue
+		}
+		if !m.markedFiles.IsMarked(node.Path) {
+			m.markedFiles.Add(node)
+			marked++
+		}
+	}
+	m.markFrom = nil
+	m.updateMarkedFilesInViews()
+
+	m.statusMessage = fmt.Sprintf("✓ Marked %d path(s) from %s", marked, m.markFromFile)
+	if missing > 0 {
+		m.statusMessage += fmt.Sprintf("; %d not found in this scan", missing)
+	}
+}
+
+// saveDeleteManifest writes the marked paths with their sizes to a timestamped file before
+// anything is deleted, as a record of the batch that a later import can re-mark from
+func (m *Model) saveDeleteManifest() {
+	marked := m.markedFiles.Snapshot()
+	entries := make([]util.ManifestEntry, 0, len(marked))
+	for _, path := range m.markedFiles.Paths() {
+		if node, ok := marked[path]; ok {
+			entries = append(entries, util.ManifestEntry{Path: path, Size: node.TotalSize()})
+		}
+	}
+
+	now := time.Now()
+	target := fmt.Sprintf(manifestFilePattern, now.Format("20060102-150405"))
+	if homeDir, err := safety.HomeDir(); err == nil {
+		target = filepath.Join(homeDir, target)
+	}
+
+	m.manifestErr = util.SaveManifest(target, entries, now)
+	if m.manifestErr == nil {
+		m.manifestPath = target
+	}
+}
+
+// copyCdCommand puts "cd '<dir>'" for the selected directory (or a file's parent) on the
+// clipboard, or writes it to the shell integration file when one is configured
+func (m *Model) copyCdCommand() {
+	node := m.getCurrentNode()
+	if node == nil {
+		return
+	}
+
+	dir := node.Path
+	if !node.IsDir {
+		dir = filepath.Dir(node.Path)
+	}
+	command := util.CdCommand(dir)
+
+	if m.cdFile != "" {
+		if err := util.WriteShellCommand(m.cdFile, command); err != nil {
+			m.statusMessage = fmt.Sprintf("✗ Cannot write %s: %v", m.cdFile, err)
+			return
+		}
+		m.statusMessage = fmt.Sprintf("✓ Wrote %s to %s", command, m.cdFile)
+		return
+	}
+
+	if err := util.CopyToClipboard(command); err != nil {
+		m.statusMessage = fmt.Sprintf("✗ Cannot copy to clipboard: %v", err)
+		return
+	}
+	m.statusMessage = fmt.Sprintf("✓ Copied to clipboard: %s", command)
+}
+
+// openCurrentNode opens the selected item with its default application
+func (m *Model) openCurrentNode() {
+	node := m.getCurrentNode()
+	if node == nil {
+		return
+	}
+
+	if err := util.OpenPath(node.Path); err != nil {
+		m.statusMessage = fmt.Sprintf("✗ Cannot open %s: %v", node.Name, err)
+		return
+	}
+	if node.IsDir || node.IsBundle {
+		m.statusMessage = fmt.Sprintf("✓ Revealed %s", node.Name)
+	} else {
+		m.statusMessage = fmt.Sprintf("✓ Opened %s", node.Name)
+	}
+}
+
 // updateMarkedFilesInViews updates all views with the current marked files
 func (m *Model) updateMarkedFilesInViews() {
+	m.markedSize = 0
+	for _, node := range m.markedFiles.Snapshot() {
+		m.markedSize += node.TotalSize()
+	}
+
 	if m.treeView != nil {
 		m.treeView.SetMarkedFiles(m.markedFiles)
 	}
@@ -687,58 +1761,81 @@ func (m *Model) getCurrentNode() *scanner.FileNode {
 		if m.topListView != nil {
 			return m.topListView.GetSelectedNode()
 		}
+	case ViewBreakdown:
+		if m.breakdownView != nil {
+			return m.breakdownView.GetSelectedNode()
+		}
+	case ViewTimeline:
+		if m.timelineView != nil {
+			return m.timelineView.GetSelectedNode()
+		}
+	case ViewSuggestions:
+		if m.suggestionsView != nil {
+			return m.suggestionsView.GetSelectedNode()
+		}
+	case ViewApps:
+		if m.appsView != nil {
+			return m.appsView.GetSelectedNode()
+		}
+	case ViewSizes:
+		if m.histogramView != nil {
+			return m.histogramView.GetSelectedNode()
+		}
 	}
 	return nil
 }
 
 // removeNodeFromTree removes a node from the tree by path
-func (m *Model) removeNodeFromTree(targetPath string) {
+// Returns the detached node, or nil if it was not in the tree
+func (m *Model) removeNodeFromTree(targetPath string) *scanner.FileNode {
 	if m.root == nil {
-		return
+		return nil
 	}
 
 	// If we're deleting the root itself, clear everything
 	if m.root.Path == targetPath {
+		root := m.root
 		m.root = nil
-		return
+		return root
 	}
 
 	// Find and remove the node
-	m.removeNodeRecursive(m.root, targetPath)
+	return m.removeNodeRecursive(m.root, targetPath)
 }
 
 // removeNodeRecursive recursively finds and removes a node from the tree
-func (m *Model) removeNodeRecursive(parent *scanner.FileNode, targetPath string) bool {
+func (m *Model) removeNodeRecursive(parent *scanner.FileNode, targetPath string) *scanner.FileNode {
 	for i, child := range parent.Children {
 		if child.Path == targetPath {
 			// Remove this child
 			parent.Children = append(parent.Children[:i], parent.Children[i+1:]...)
-			return true
+			return child
 		}
 
 		// Recursively search in this child's children
-		if m.removeNodeRecursive(child, targetPath) {
-			return true
+		if removed := m.removeNodeRecursive(child, targetPath); removed != nil {
+			return removed
 		}
 	}
-	return false
+	return nil
 }
 
 // handleModalInput handles keyboard input when a modal is active
 func (m *Model) handleModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	switch m.activeModal {
 	case ModalDeleteConfirm:
+		// Neither case of w appears in DELETE, so saving works while it is being typed too
+		if msg.String() == "w" || msg.String() == "W" {
+			m.saveDeleteManifest()
+			return m, nil
+		}
+		if m.typedConfirmReason != "" {
+			return m.handleTypedConfirm(msg)
+		}
 		switch msg.String() {
 		case "y", "Y", "enter":
 			// Check if any marked files require confirmation
-			protector := safety.NewProtector()
-			hasSensitive := false
-			for path := range m.markedFiles {
-				if requiresConf, _ := protector.RequiresConfirmation(path); requiresConf {
-					hasSensitive = true
-					break
-				}
-			}
+			hasSensitive := len(m.sensitiveMarkedPaths(safety.NewProtector())) > 0 || len(m.sensitiveContents) > 0
 
 			// If sensitive paths and not yet confirmed, require second confirmation
 			if hasSensitive && !m.sensitiveDeleteConfirmed {
@@ -750,20 +1847,373 @@ func (m *Model) handleModalInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 			// Either no sensitive paths, or already confirmed - proceed with deletion
 			m.activeModal = ModalDeleteProgress
 			m.sensitiveDeleteConfirmed = false // Reset for next time
-			return m, m.startDeletion()
+			return m, m.startDeletion(m.markedFiles.Snapshot(), false, m.deleteMethod)
 		case "n", "N", "esc", "q":
 			// Cancel
 			m.activeModal = ModalNone
 			m.sensitiveDeleteConfirmed = false // Reset confirmation state
 		}
 	case ModalDeleteSummary:
-		// Any key closes the summary
+		// Any key closes the summary; 't' goes on to empty the Trash
 		m.activeModal = ModalNone
-		m.markedFiles = make(map[string]*scanner.FileNode) // Clear marked files
+		m.markedFiles.Clear()
+		m.updateMarkedFilesInViews()
+		if msg.String() == "t" && m.deleteProgress.TrashSize > 0 {
+			m.activeModal = ModalEmptyTrash
+		}
+	case ModalEmptyTrash:
+		switch msg.String() {
+		case "y", "Y":
+			m.activeModal = ModalNone
+			m.statusMessage = "Emptying the Trash..."
+			return m, emptyTrash()
+		case "n", "N", "esc", "q":
+			m.activeModal = ModalNone
+		}
+	case ModalQuickDelete:
+		node := m.quickDeleteNode
+		m.activeModal = ModalNone
+		m.quickDeleteNode = nil
+		if msg.String() == "y" || msg.String() == "Y" {
+			m.statusMessage = fmt.Sprintf("Deleting %s...", node.Name)
+			return m, m.startDeletion(map[string]*scanner.FileNode{node.Path: node}, true, m.deleteMethod)
+		}
+		m.statusMessage = "Quick delete cancelled"
+	case ModalMarkPattern:
+		return m.handlePatternInput(msg)
+	case ModalLargerThan:
+		return m.handleLargerInput(msg)
+	case ModalSafeCleanup:
+		switch msg.String() {
+		case "y", "Y":
+			// Always to the Trash, even with -permanent, so the cleanup can be undone
+			files := make(map[string]*scanner.FileNode, len(m.cleanupItems))
+			for _, item := range m.cleanupItems {
+				files[item.Path] = item
+			}
+			m.activeModal = ModalDeleteProgress
+			m.cleanupItems = nil
+			return m, m.startDeletion(files, false, safety.DeleteToTrash)
+		case "n", "N", "esc", "q":
+			m.activeModal = ModalNone
+			m.cleanupItems = nil
+			m.statusMessage = "Safe cleanup cancelled - the items stay marked, press x to review them"
+		}
+	case ModalSkippedList:
+		maxScroll := len(m.skippedVolumes) - m.skippedListHeight()
+		if maxScroll < 0 {
+			maxScroll = 0
+		}
+		switch msg.String() {
+		case "up", "k":
+			m.skippedScroll--
+		case "down", "j":
+			m.skippedScroll++
+		case "pgup":
+			m.skippedScroll -= m.skippedListHeight()
+		case "pgdown":
+			m.skippedScroll += m.skippedListHeight()
+		case "home":
+			m.skippedScroll = 0
+		case "end":
+			m.skippedScroll = maxScroll
+		case "esc", "q", "S", "enter":
+			m.activeModal = ModalNone
+		}
+		if m.skippedScroll > maxScroll {
+			m.skippedScroll = maxScroll
+		}
+		if m.skippedScroll < 0 {
+			m.skippedScroll = 0
+		}
+	case ModalSnapshotDelete:
+		switch msg.String() {
+		case "y", "Y":
+			m.activeModal = ModalNone
+			m.deletingSnapshots = true
+			m.statusMessage = fmt.Sprintf("Deleting %d local snapshot(s)...", len(m.snapshots))
+			return m, deleteSnapshots(m.snapshots)
+		case "n", "N", "esc", "q":
+			m.activeModal = ModalNone
+		}
 	}
 	return m, nil
 }
 
+// startDuplicateSearch hashes same-size files in the background to find duplicates
+// A search still running from an earlier scan is cancelled
+func (m *Model) startDuplicateSearch() tea.Cmd {
+	if m.cancelDuplicates != nil {
+		m.cancelDuplicates()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelDuplicates = cancel
+	m.duplicateSearch++
+	search := m.duplicateSearch
+	m.findingDuplicates = true
+	m.duplicates = nil
+	m.deletedMidSearch = nil
+	if m.suggestionsView != nil {
+		m.suggestionsView.SetDuplicates(nil, true)
+	}
+
+	// Deletions replace m.allNodes rather than changing it, so the search keeps a stable list
+	nodes := m.allNodes
+	return func() tea.Msg {
+		groups, err := analyzer.FindDuplicates(ctx, nodes, analyzer.DuplicateMinSize)
+		return DuplicatesFoundMsg{Search: search, Groups: groups, Err: err}
+	}
+}
+
+// emptyTrash empties the Trash in the background
+func emptyTrash() tea.Cmd {
+	return func() tea.Msg {
+		freed, err := safety.EmptyTrash()
+		return TrashEmptiedMsg{Freed: freed, Err: err}
+	}
+}
+
+// loadSnapshots lists the startup volume's Time Machine local snapshots in the background
+// Listing failures just leave the snapshots panel hidden
+func loadSnapshots() tea.Cmd {
+	return func() tea.Msg {
+		snapshots, _ := safety.ListLocalSnapshots(snapshotVolume)
+		return SnapshotsLoadedMsg{Snapshots: snapshots}
+	}
+}
+
+// deleteSnapshots deletes local snapshots one by one with tmutil
+func deleteSnapshots(snapshots []safety.LocalSnapshot) tea.Cmd {
+	return func() tea.Msg {
+		result := SnapshotsDeletedMsg{}
+		for _, snapshot := range snapshots {
+			if err := safety.DeleteLocalSnapshot(snapshot); err != nil {
+				result.Errors = append(result.Errors, err)
+			} else {
+				result.Deleted++
+			}
+		}
+		return result
+	}
+}
+
+// handlePatternInput edits the 'P' prompt and marks the matches on enter
+func (m *Model) handlePatternInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.closePatternPrompt()
+		return m, nil
+	case tea.KeyEnter:
+		for _, node := range m.patternMatch.Safe {
+			m.markedFiles.Add(node)
+		}
+		m.statusMessage = patternMarkSummary(m.patternInput, m.patternMatch)
+		m.closePatternPrompt()
+		m.updateMarkedFilesInViews()
+		return m, nil
+	case tea.KeyBackspace:
+		if len(m.patternInput) > 0 {
+			runes := []rune(m.patternInput)
+			m.patternInput = string(runes[:len(runes)-1])
+		}
+	case tea.KeySpace:
+		m.patternInput += " "
+	case tea.KeyRunes:
+		m.patternInput += string(msg.Runes)
+	default:
+		return m, nil
+	}
+
+	m.patternMatch = views.MatchPattern(m.patternNodes, m.patternInput, safety.NewProtector())
+	return m, nil
+}
+
+// handleLargerInput edits the '>' prompt and shows the matching files in the top list on enter
+func (m *Model) handleLargerInput(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.closeLargerPrompt()
+		return m, nil
+	case tea.KeyEnter:
+		size, err := util.ParseSize(m.largerInput)
+		if err != nil {
+			return m, nil // Keep the prompt open until the size parses
+		}
+		if m.topListView != nil {
+			m.topListView.ShowFilesLarger(size)
+			m.currentView = ViewTopList
+		}
+		m.statusMessage = fmt.Sprintf("%d file(s) over %s, %s total",
+			len(m.largerFiles), util.FormatBytesPlain(size), util.FormatBytesPlain(totalSize(m.largerFiles)))
+		m.closeLargerPrompt()
+		return m, nil
+	case tea.KeyBackspace:
+		if len(m.largerInput) > 0 {
+			runes := []rune(m.largerInput)
+			m.largerInput = string(runes[:len(runes)-1])
+		}
+	case tea.KeyRunes:
+		m.largerInput += string(msg.Runes)
+	default:
+		return m, nil
+	}
+
+	m.largerFiles = nil
+	if size, err := util.ParseSize(m.largerInput); err == nil {
+		m.largerFiles = views.FilesLarger(m.allNodes, size)
+	}
+	return m, nil
+}
+
+// closeLargerPrompt dismisses the '>' prompt
+func (m *Model) closeLargerPrompt() {
+	m.activeModal = ModalNone
+	m.largerInput = ""
+	m.largerFiles = nil
+}
+
+// typedConfirmWord must be typed to confirm a batch over the typed-confirmation thresholds
+const typedConfirmWord = "DELETE"
+
+// typedConfirmNeeded says why the marked batch needs DELETE typed, or "" if a keypress will do
+func (m *Model) typedConfirmNeeded(protector *safety.Protector) string {
+	var total int64
+	maxRisk := 0
+	for path, node := range m.markedFiles.Snapshot() {
+		total += node.TotalSize()
+		if risk := protector.GetRiskLevel(path); risk > maxRisk {
+			maxRisk = risk
+		}
+	}
+
+	if m.typedConfirmRisk > 0 && maxRisk >= m.typedConfirmRisk {
+		return fmt.Sprintf("the batch includes %q items", riskLabels[maxRisk])
+	}
+	if m.typedConfirmSize > 0 && total >= m.typedConfirmSize {
+		return fmt.Sprintf("the batch is %s or more", util.FormatBytesPlain(m.typedConfirmSize))
+	}
+	return ""
+}
+
+// handleTypedConfirm collects the confirmation word; Enter deletes only once it is typed exactly
+// Typing the word also stands in for the second Y on sensitive paths
+func (m *Model) handleTypedConfirm(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.Type {
+	case tea.KeyEsc:
+		m.activeModal = ModalNone
+		m.deleteInput = ""
+		m.sensitiveDeleteConfirmed = false
+	case tea.KeyEnter:
+		if m.deleteInput == typedConfirmWord {
+			m.activeModal = ModalDeleteProgress
+			m.deleteInput = ""
+			m.sensitiveDeleteConfirmed = false
+			return m, m.startDeletion(m.markedFiles.Snapshot(), false, m.deleteMethod)
+		}
+	case tea.KeyBackspace:
+		if len(m.deleteInput) > 0 {
+			m.deleteInput = m.deleteInput[:len(m.deleteInput)-1]
+		}
+	case tea.KeyRunes:
+		if len(m.deleteInput) < len(typedConfirmWord) {
+			m.deleteInput += string(msg.Runes)
+		}
+	}
+	return m, nil
+}
+
+// closePatternPrompt hides the 'P' prompt and releases the flattened tree
+func (m *Model) closePatternPrompt() {
+	m.activeModal = ModalNone
+	m.patternInput = ""
+	m.patternNodes = nil
+	m.patternMatch = views.PatternMatch{}
+}
+
+// patternMarkSummary reports how many matches were marked and how many were protected
+func patternMarkSummary(pattern string, match views.PatternMatch) string {
+	if len(match.Safe) == 0 && len(match.Protected) == 0 {
+		return fmt.Sprintf("No items match %q", pattern)
+	}
+
+	summary := fmt.Sprintf("✓ Marked %d item(s) matching %q (%s)",
+		len(match.Safe), pattern, util.FormatBytesPlain(totalSize(match.Safe)))
+	if len(match.Protected) > 0 {
+		summary += fmt.Sprintf(", skipped %d protected", len(match.Protected))
+	}
+	if len(match.Safe) > 0 {
+		summary += " - press x to review and delete"
+	}
+	return summary
+}
+
+// totalSize sums the sizes of nodes
+func totalSize(nodes []*scanner.FileNode) int64 {
+	var total int64
+	for _, node := range nodes {
+		total += node.TotalSize()
+	}
+	return total
+}
+
+// startSafeCleanup marks every item of the no-risk suggestions and asks to move them all to the Trash
+func (m *Model) startSafeCleanup() {
+	items := m.suggestionsView.SafeCleanupItems()
+	if len(items) == 0 {
+		m.statusMessage = "Nothing to clean up: no caches, logs or known bloat without risk were found"
+		return
+	}
+
+	for _, item := range items {
+		if !m.markedFiles.IsMarked(item.Path) {
+			m.markedFiles.Add(item)
+		}
+	}
+	m.updateMarkedFilesInViews()
+	m.cleanupItems = items
+	m.activeModal = ModalSafeCleanup
+}
+
+// startQuickDelete asks to delete the selected directory at once if it is a no-risk cache
+// Anything else is marked instead, to go through the usual 'x' review
+func (m *Model) startQuickDelete() {
+	node := m.getCurrentNode()
+	if node == nil || node == m.root {
+		return
+	}
+
+	protector := safety.NewProtector()
+	if node.IsDir && protector.IsCache(node.Path) && protector.GetRiskLevel(node.Path) == 0 {
+		m.quickDeleteNode = node
+		m.activeModal = ModalQuickDelete
+		return
+	}
+
+	if !m.markedFiles.IsMarked(node.Path) {
+		m.markedFiles.Add(node)
+		m.updateMarkedFilesInViews()
+	}
+	m.statusMessage = fmt.Sprintf("%s is not a no-risk cache folder, so it was marked instead - press x to review and delete", node.Name)
+}
+
+// quickDeleteResult describes the outcome of a quick delete for the status line
+func (m *Model) quickDeleteResult(msg DeleteCompleteMsg) string {
+	if len(msg.Errors) > 0 {
+		return fmt.Sprintf("Error: %v", msg.Errors[0])
+	}
+	if len(msg.Protected) > 0 {
+		return fmt.Sprintf("Skipped: %v", msg.Protected[0])
+	}
+	if len(msg.Leftovers) > 0 {
+		return fmt.Sprintf("Error: %s is still on disk", msg.Leftovers[0].Path)
+	}
+	if msg.Method == safety.DeleteToTrash {
+		return fmt.Sprintf("✓ Moved %s to the Trash (%s freed when the Trash is emptied)",
+			filepath.Base(msg.DeletedPaths[0]), util.FormatBytesPlain(msg.BytesDeleted))
+	}
+	return fmt.Sprintf("✓ Deleted %s: %s reclaimed", filepath.Base(msg.DeletedPaths[0]), util.FormatBytesPlain(msg.BytesDeleted))
+}
+
 // DeleteProgressUpdateMsg is sent during deletion to update progress
 type DeleteProgressUpdateMsg struct {
 	Current     int
@@ -771,54 +2221,91 @@ type DeleteProgressUpdateMsg struct {
 	CurrentFile string
 }
 
-// startDeletion initiates the deletion process
-func (m *Model) startDeletion() tea.Cmd {
-	// Store marked files for deletion
-	filesToDelete := make(map[string]*scanner.FileNode)
-	for k, v := range m.markedFiles {
-		filesToDelete[k] = v
-	}
+// startDeletion deletes filesToDelete in the background using method
+// quick deletions ('D') report on the status line instead of the summary dialog
+func (m *Model) startDeletion(filesToDelete map[string]*scanner.FileNode, quick bool, method safety.DeleteMethod) tea.Cmd {
+	verify := m.verifyDeletes
 
 	return func() tea.Msg {
-		deleter := safety.NewDeleter(safety.DeleteToTrash)
+		msg := deleteFiles(safety.NewDeleter(method), filesToDelete, verify)
 
-		// Initialize progress
-		current := 0
-		itemsDeleted := 0
-		totalFilesDeleted := 0
-		var totalBytesDeleted int64
-		errors := make([]error, 0)
-		deletedPaths := make([]string, 0)
+		trashSize, err := safety.TrashSize()
+		if err != nil {
+			trashSize = -1
+		}
+		msg.TrashSize = trashSize
+		msg.Quick = quick
+		msg.Method = method
+		return msg
+	}
+}
 
-		// Delete each file/directory
-		for path, node := range filesToDelete {
-			current++
+// deleteFiles deletes each of filesToDelete with deleter and, with verify, re-checks
+// that the deleted paths are gone, so only bytes really freed are reported
+func deleteFiles(deleter safety.FileDeleter, filesToDelete map[string]*scanner.FileNode, verify bool) DeleteCompleteMsg {
+	// Initialize progress
+	current := 0
+	itemsDeleted := 0
+	totalFilesDeleted := 0
+	var totalBytesDeleted int64
+	failures := make([]error, 0)
+	protected := make([]error, 0)
+	deletedPaths := make([]string, 0)
+	claimed := make(map[string]int64)
+
+	// Delete each file/directory
+	for path, node := range filesToDelete {
+		current++
+
+		// Count total files in this item (if it's a directory, count all files inside)
+		fileCount := int(node.FileCount())
+
+		// Delete the file/directory
+		bytesDeleted, err := deleter.DeleteFile(path)
+		if errors.Is(err, safety.ErrProtected) {
+			// Refused before anything was touched; the error already names the path
+			protected = append(protected, err)
+		} else if err != nil {
+			failures = append(failures, fmt.Errorf("%s: %w", path, err))
+		} else {
+			itemsDeleted++
+			totalFilesDeleted += fileCount
+			totalBytesDeleted += bytesDeleted
+			deletedPaths = append(deletedPaths, path)
+			claimed[path] = bytesDeleted
+		}
 
-			// Count total files in this item (if it's a directory, count all files inside)
-			fileCount := int(node.FileCount())
+		// Note: We can't send progress updates from within this function easily
+		// in Bubble Tea's model, but the deletion itself is now more reliable
+	}
 
-			// Delete the file/directory
-			bytesDeleted, err := deleter.DeleteFile(path)
-			if err != nil {
-				errors = append(errors, fmt.Errorf("%s: %w", path, err))
-			} else {
-				itemsDeleted++
-				totalFilesDeleted += fileCount
-				totalBytesDeleted += bytesDeleted
+	// Re-check that deleted paths are really gone and only count bytes that were freed
+	var leftovers []safety.Leftover
+	if verify {
+		leftovers = safety.VerifyDeleted(claimed)
+		for _, leftover := range leftovers {
+			itemsDeleted--
+			totalFilesDeleted -= int(filesToDelete[leftover.Path].FileCount())
+			totalBytesDeleted -= leftover.Claimed
+			delete(claimed, leftover.Path)
+		}
+		if len(leftovers) > 0 {
+			deletedPaths = deletedPaths[:0]
+			for path := range claimed {
 				deletedPaths = append(deletedPaths, path)
 			}
-
-			// Note: We can't send progress updates from within this function easily
-			// in Bubble Tea's model, but the deletion itself is now more reliable
 		}
+	}
 
-		return DeleteCompleteMsg{
-			ItemsDeleted:      itemsDeleted,
-			TotalFilesDeleted: totalFilesDeleted,
-			BytesDeleted:      totalBytesDeleted,
-			Errors:            errors,
-			DeletedPaths:      deletedPaths,
-		}
+	return DeleteCompleteMsg{
+		ItemsDeleted:      itemsDeleted,
+		TotalFilesDeleted: totalFilesDeleted,
+		BytesDeleted:      totalBytesDeleted,
+		Errors:            failures,
+		Protected:         protected,
+		DeletedPaths:      deletedPaths,
+		Verified:          verify,
+		Leftovers:         leftovers,
 	}
 }
 
@@ -828,7 +2315,19 @@ type DeleteCompleteMsg struct {
 	TotalFilesDeleted int     // Total files including those in deleted directories
 	BytesDeleted     int64
 	Errors           []error
+	Protected        []error  // Skipped because protected; not counted as errors
 	DeletedPaths     []string // Paths that were deleted (for tree update)
+	Verified         bool
+	Leftovers        []safety.Leftover // Reported deleted but still on disk (when verified)
+	TrashSize        int64             // Bytes in the Trash afterwards (-1 if unknown)
+	Quick            bool              // From the 'D' quick delete: no summary dialog
+	Method           safety.DeleteMethod
+}
+
+// TrashEmptiedMsg is sent when emptying the Trash finishes
+type TrashEmptiedMsg struct {
+	Freed int64
+	Err   error
 }
 
 // renderModal renders a modal dialog overlay
@@ -842,6 +2341,18 @@ func (m *Model) renderModal(background string) string {
 		modal = m.renderDeleteProgressModal()
 	case ModalDeleteSummary:
 		modal = m.renderDeleteSummaryModal()
+	case ModalMarkPattern:
+		modal = m.renderMarkPatternModal()
+	case ModalLargerThan:
+		modal = m.renderLargerThanModal()
+	case ModalSafeCleanup:
+		modal = m.renderSafeCleanupModal()
+	case ModalSnapshotDelete:
+		modal = m.renderSnapshotDeleteModal()
+	case ModalEmptyTrash:
+		modal = m.renderEmptyTrashModal()
+	case ModalSkippedList:
+		modal = m.renderSkippedListModal()
 	default:
 		return background
 	}
@@ -858,21 +2369,244 @@ func (m *Model) renderModal(background string) string {
 	)
 }
 
+// renderQuickDeletePrompt renders the one-line 'D' confirm
+func (m *Model) renderQuickDeletePrompt() string {
+	node := m.quickDeleteNode
+	prompt := fmt.Sprintf("Quick delete cache folder %s (%s) - %s? y/N",
+		m.truncatePath(node.Path, 60), util.FormatBytesPlain(node.TotalSize()), m.deleteActionName())
+	return "\n" + lipgloss.NewStyle().Foreground(ColorWarning).Bold(true).Render(prompt)
+}
+
+// renderMarkPatternModal renders the 'P' prompt with a live match count
+func (m *Model) renderMarkPatternModal() string {
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorPrimary).
+		Render("Mark Items Matching a Pattern")
+
+	matchInfo := "Type a glob (*.log, */DerivedData/*, **/node_modules) or part of a path"
+	if m.patternInput != "" {
+		matchInfo = fmt.Sprintf("%d item(s) match, %s total",
+			len(m.patternMatch.Safe), util.FormatBytesPlain(totalSize(m.patternMatch.Safe)))
+		if len(m.patternMatch.Protected) > 0 {
+			matchInfo += fmt.Sprintf("\n%d protected item(s) will be skipped", len(m.patternMatch.Protected))
+		}
+	}
+
+	message := fmt.Sprintf(
+		"%s\n\n"+
+			"Pattern: %s█\n\n"+
+			"%s\n\n"+
+			"Enter: mark matches • Esc: cancel",
+		title,
+		m.patternInput,
+		matchInfo,
+	)
+
+	return lipgloss.NewStyle().
+		Width(70).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorPrimary).
+		Render(message)
+}
+
+// renderLargerThanModal renders the '>' size prompt with how many files are over it
+func (m *Model) renderLargerThanModal() string {
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorPrimary).
+		Render("Find Files Larger Than")
+
+	matchInfo := "Type a size (500M, 1.5G, 2GiB); units are binary, as for -min-size"
+	if m.largerInput != "" {
+		if m.largerFiles == nil {
+			matchInfo = "Not a size yet"
+		} else {
+			matchInfo = fmt.Sprintf("%d file(s) over it, %s total",
+				len(m.largerFiles), util.FormatBytesPlain(totalSize(m.largerFiles)))
+		}
+	}
+
+	message := fmt.Sprintf(
+		"%s\n\n"+
+			"Size: %s█\n\n"+
+			"%s\n\n"+
+			"Enter: list them (largest first, m to mark) • Esc: cancel",
+		title,
+		m.largerInput,
+		matchInfo,
+	)
+
+	return lipgloss.NewStyle().
+		Width(70).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorPrimary).
+		Render(message)
+}
+
+// renderSafeCleanupModal asks before moving every no-risk suggestion item to the Trash
+func (m *Model) renderSafeCleanupModal() string {
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorSuccess).
+		Render("🧹 Safe Cleanup")
+
+	examples := make([]string, 0, len(m.cleanupItems))
+	for _, item := range m.cleanupItems {
+		examples = append(examples, fmt.Sprintf("%s (%s)", item.Path, util.FormatBytesPlain(item.TotalSize())))
+	}
+
+	message := fmt.Sprintf(
+		"%s\n\n"+
+			"%s in %d no-risk item(s) (caches, logs, known bloat):\n"+
+			"%s\n"+
+			"They are moved to the Trash (even with -permanent), so anything\n"+
+			"can be put back until the Trash is emptied. Apps and build tools\n"+
+			"recreate these as needed.\n\n"+
+			"Y: move them to the Trash • N/Esc: keep them marked (review with x)",
+		title,
+		util.FormatBytesPlain(totalSize(m.cleanupItems)),
+		len(m.cleanupItems),
+		listExamples(examples, 5),
+	)
+
+	return lipgloss.NewStyle().
+		Width(70).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorSuccess).
+		Render(message)
+}
+
+// renderEmptyTrashModal asks before permanently deleting the Trash contents
+func (m *Model) renderEmptyTrashModal() string {
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorDanger).
+		Render("⚠ Empty the Trash")
+
+	message := fmt.Sprintf(
+		"%s\n\n"+
+			"The Trash holds %s. Emptying it frees that space and\n"+
+			"permanently deletes everything in it, including items\n"+
+			"trashed outside SpaceForce.\n\n"+
+			"Y: empty the Trash • N/Esc: cancel",
+		title,
+		util.FormatBytesPlain(m.deleteProgress.TrashSize),
+	)
+
+	return lipgloss.NewStyle().
+		Width(64).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorDanger).
+		Render(message)
+}
+
+// skippedListHeight is how many skipped entries fit in the list at once
+func (m *Model) skippedListHeight() int {
+	height := m.height - 20
+	if height < 5 {
+		height = 5
+	}
+	return height
+}
+
+// renderSkippedListModal lists the skipped volumes and paths with the reason for each
+func (m *Model) renderSkippedListModal() string {
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorWarning).
+		Render(fmt.Sprintf("ℹ Skipped During the Scan (%d)", len(m.skippedVolumes)))
+
+	width := m.width - 10
+	if width > 100 {
+		width = 100
+	}
+	if width < 40 {
+		width = 40
+	}
+
+	end := m.skippedScroll + m.skippedListHeight()
+	if end > len(m.skippedVolumes) {
+		end = len(m.skippedVolumes)
+	}
+	var list strings.Builder
+	for _, entry := range m.skippedVolumes[m.skippedScroll:end] {
+		list.WriteString(m.truncatePath(entry, width-6) + "\n")
+	}
+
+	position := ""
+	if len(m.skippedVolumes) > m.skippedListHeight() {
+		position = fmt.Sprintf("%d-%d of %d • ", m.skippedScroll+1, end, len(m.skippedVolumes))
+	}
+
+	message := fmt.Sprintf(
+		"%s\n\n"+
+			"%s\n"+
+			"%s↑↓/jk, PgUp/PgDn: scroll • Esc/S: close",
+		title,
+		list.String(),
+		position,
+	)
+
+	return lipgloss.NewStyle().
+		Width(width).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorWarning).
+		Render(message)
+}
+
+// renderSnapshotDeleteModal asks before deleting Time Machine local snapshots
+func (m *Model) renderSnapshotDeleteModal() string {
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Foreground(ColorWarning).
+		Render("⚠ Delete Time Machine Local Snapshots")
+
+	message := fmt.Sprintf(
+		"%s\n\n"+
+			"Delete %d local snapshot(s) of %s?\n\n"+
+			"They hold the blocks of files you already deleted or changed, so\n"+
+			"this frees space the scan can't see. Backups on your Time Machine\n"+
+			"disk are not affected, but you can no longer restore from these\n"+
+			"snapshots.\n\n"+
+			"Runs: tmutil deletelocalsnapshots <date>\n\n"+
+			"Y: delete • N/Esc: cancel",
+		title,
+		len(m.snapshots),
+		snapshotVolume,
+	)
+
+	return lipgloss.NewStyle().
+		Width(72).
+		Padding(1, 2).
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorWarning).
+		Render(message)
+}
+
 // renderDeleteConfirmModal renders the deletion confirmation dialog
 func (m *Model) renderDeleteConfirmModal() string {
-	// Calculate total size and check for sensitive paths
+	// Calculate total size, risk levels and sensitive paths
 	var totalSize int64
-	var sensitivePaths []string
+	var riskCounts [4]int
 	protector := safety.NewProtector()
 
-	for path, node := range m.markedFiles {
-		totalSize += node.TotalSize()
-		if requiresConf, reason := protector.RequiresConfirmation(path); requiresConf {
-			sensitivePaths = append(sensitivePaths, fmt.Sprintf("%s (%s)", filepath.Base(path), reason))
+	for path, node := range m.markedFiles.Snapshot() {
+		riskCounts[protector.GetRiskLevel(path)]++
+		if node.IsSymlink {
+			continue // Only the link goes; what it points to is not freed
 		}
+		totalSize += node.TotalSize()
 	}
 
-	hasSensitive := len(sensitivePaths) > 0
+	sensitivePaths := m.sensitiveMarkedPaths(protector)
+	symlinks := m.markedSymlinks()
+	hasSensitive := len(sensitivePaths) > 0 || len(m.sensitiveContents) > 0
 
 	// Choose title and color based on sensitivity
 	var title string
@@ -896,27 +2630,48 @@ func (m *Model) renderDeleteConfirmModal() string {
 		"%s\n\n"+
 			"You are about to delete:\n"+
 			"  • %d file(s) / folder(s)\n"+
-			"  • Total size: %s\n\n",
+			"  • Total size: %s\n"+
+			"  • Risk: %s\n\n",
 		title,
-		len(m.markedFiles),
+		m.markedFiles.Len(),
 		util.FormatBytes(totalSize),
+		riskSummary(riskCounts),
 	)
 
+	// Projected free space (unknown if statfs failed)
+	if m.diskSpaceBefore >= 0 {
+		immediate, eventual := safety.ProjectedFreeSpace(m.diskSpaceBefore, totalSize, m.deleteMethod)
+		message += fmt.Sprintf("Free space would go from %s to %s",
+			util.FormatBytesPlain(m.diskSpaceBefore), util.FormatBytesPlain(eventual))
+		if immediate < eventual {
+			message += "\n  (items go to the Trash: the space is only freed once you empty it)"
+		}
+		message += "\n\n"
+	}
+
 	// Build tree view of files to be deleted
 	message += "Files to be deleted:\n"
 	treeView := m.buildDeletionTreeView()
 	message += treeView
 
+	// Deleting a symlink never touches its target; say so, with where each one points
+	if len(symlinks) > 0 {
+		message += fmt.Sprintf("\n🔗 %d symlink(s): only the link is removed, not what it points to", len(symlinks))
+		if m.deleteMethod == safety.DeleteToTrash {
+			message += "\n  (the link goes to the Trash; its target stays where it is)"
+		}
+		message += ":\n" + listExamples(symlinks, 3)
+	}
+
 	// Add sensitive paths warning if any
 	if hasSensitive {
-		message += "\n⚠️  WARNING: Includes sensitive locations:\n"
-		// Show up to 3 examples
-		for i, path := range sensitivePaths {
-			if i >= 3 {
-				message += fmt.Sprintf("  ... and %d more\n", len(sensitivePaths)-3)
-				break
-			}
-			message += fmt.Sprintf("  • %s\n", path)
+		if len(sensitivePaths) > 0 {
+			message += "\n⚠️  WARNING: Includes sensitive locations:\n"
+			message += listExamples(sensitivePaths, 3)
+		}
+		if len(m.sensitiveContents) > 0 {
+			message += "\n⚠️  WARNING: Marked folders contain sensitive items:\n"
+			message += listExamples(m.sensitiveContents, 3)
 		}
 		message += "\nThese paths may contain:\n" +
 			"  - Application data and settings\n" +
@@ -924,17 +2679,34 @@ func (m *Model) renderDeleteConfirmModal() string {
 			"  - Important configurations\n"
 	}
 
-	message += "\n⚠️  FILES WILL BE PERMANENTLY DELETED ⚠️\n"
-	message += "This action cannot be undone.\n\n"
+	if m.deleteMethod == safety.DeleteToTrash {
+		message += "\nItems will be moved to the Trash (recoverable until it is emptied).\n\n"
+	} else {
+		message += "\n⚠️  FILES WILL BE PERMANENTLY DELETED ⚠️\n"
+		message += "This action cannot be undone.\n\n"
+	}
 
-	if hasSensitive {
+	switch {
+	case m.manifestErr != nil:
+		message += fmt.Sprintf("✗ Could not save the list: %v\n\n", m.manifestErr)
+	case m.manifestPath != "":
+		message += fmt.Sprintf("✓ List saved to %s\n\n", m.manifestPath)
+	default:
+		message += "Press W to save this list (paths and sizes) to a file first.\n\n"
+	}
+
+	action := m.deleteActionName()
+	if m.typedConfirmReason != "" {
+		message += fmt.Sprintf("Because %s, type %s and press Enter to %s (Esc to cancel):\n> %s_",
+			m.typedConfirmReason, typedConfirmWord, action, m.deleteInput)
+	} else if hasSensitive {
 		if m.sensitiveDeleteConfirmed {
-			message += "⚠️  PRESS Y AGAIN TO PERMANENTLY DELETE ⚠️"
+			message += fmt.Sprintf("⚠️  PRESS Y AGAIN TO %s ⚠️", strings.ToUpper(action))
 		} else {
-			message += "Press Y TWICE to confirm permanent deletion, N to cancel"
+			message += fmt.Sprintf("Press Y TWICE to %s, N to cancel", action)
 		}
 	} else {
-		message += "Press Y to confirm permanent deletion, N to cancel"
+		message += fmt.Sprintf("Press Y to %s, N to cancel", action)
 	}
 
 	content := lipgloss.NewStyle().
@@ -947,9 +2719,109 @@ func (m *Model) renderDeleteConfirmModal() string {
 	return content
 }
 
+// findSensitiveContents walks marked directories that are not sensitive themselves
+// and describes the sensitive directories inside them (like a .ssh in a copied home)
+func (m *Model) findSensitiveContents(protector *safety.Protector) []string {
+	marked := m.markedFiles.Snapshot()
+	var found []string
+	for _, path := range m.markedFiles.Paths() {
+		node := marked[path]
+		if !node.IsDir {
+			continue
+		}
+		if requiresConf, _ := protector.RequiresConfirmation(path); requiresConf {
+			continue // Already warned about as a whole
+		}
+		found = m.appendSensitiveDescendants(found, node, path, protector)
+	}
+	return found
+}
+
+// appendSensitiveDescendants adds the sensitive directories under dir, relative to markedPath
+// The walk stops at the first sensitive directory on each branch
+func (m *Model) appendSensitiveDescendants(found []string, dir *scanner.FileNode, markedPath string, protector *safety.Protector) []string {
+	for _, child := range dir.Children {
+		if !child.IsDir {
+			continue
+		}
+		if sensitive, reason := protector.IsSensitiveContent(child.Path); sensitive {
+			if m.singleConfirmCleanup && protector.IsRoutineCleanup(child.Path) {
+				continue
+			}
+			rel, err := filepath.Rel(filepath.Dir(markedPath), child.Path)
+			if err != nil {
+				rel = child.Path
+			}
+			found = append(found, fmt.Sprintf("%s (%s)", rel, reason))
+			continue
+		}
+		found = m.appendSensitiveDescendants(found, child, markedPath, protector)
+	}
+	return found
+}
+
+// markedSymlinks describes the marked symlinks as "name → target"
+func (m *Model) markedSymlinks() []string {
+	marked := m.markedFiles.Snapshot()
+	var links []string
+	for _, path := range m.markedFiles.Paths() {
+		node := marked[path]
+		if !node.IsSymlink {
+			continue
+		}
+		target := node.LinkTarget
+		if target == "" {
+			target = "(unreadable target)"
+		}
+		links = append(links, fmt.Sprintf("%s → %s", node.Name, target))
+	}
+	return links
+}
+
+// listExamples renders up to limit items as bullet lines, then "... and N more"
+func listExamples(items []string, limit int) string {
+	var b strings.Builder
+	for i, item := range items {
+		if i >= limit {
+			b.WriteString(fmt.Sprintf("  ... and %d more\n", len(items)-limit))
+			break
+		}
+		b.WriteString(fmt.Sprintf("  • %s\n", item))
+	}
+	return b.String()
+}
+
+// sensitiveMarkedPaths describes the marked paths that need a second confirmation
+// With singleConfirmCleanup, no-risk caches and logs don't count as sensitive
+func (m *Model) sensitiveMarkedPaths(protector *safety.Protector) []string {
+	var sensitive []string
+	for _, path := range m.markedFiles.Paths() {
+		requiresConf, reason := protector.RequiresConfirmation(path)
+		if !requiresConf || (m.singleConfirmCleanup && protector.IsRoutineCleanup(path)) {
+			continue
+		}
+		sensitive = append(sensitive, fmt.Sprintf("%s (%s)", filepath.Base(path), reason))
+	}
+	return sensitive
+}
+
+// riskLabels names the risk levels 0-3 in the deletion dialog
+var riskLabels = []string{"safe", "low risk", "review", "protected"}
+
+// riskSummary counts marked items per risk level, e.g. "12 safe, 3 low risk, 1 review"
+func riskSummary(counts [4]int) string {
+	parts := make([]string, 0, len(riskLabels))
+	for level, count := range counts {
+		if count > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", count, riskLabels[level]))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
 // buildDeletionTreeView creates a tree view of files to be deleted
 func (m *Model) buildDeletionTreeView() string {
-	if len(m.markedFiles) == 0 {
+	if m.markedFiles.Len() == 0 {
 		return "  (none)\n"
 	}
 
@@ -961,7 +2833,7 @@ func (m *Model) buildDeletionTreeView() string {
 
 	dirMap := make(map[string][]string)
 
-	for path := range m.markedFiles {
+	for _, path := range m.markedFiles.Paths() {
 		dir := filepath.Dir(path)
 		base := filepath.Base(path)
 		dirMap[dir] = append(dirMap[dir], base)
@@ -970,7 +2842,7 @@ func (m *Model) buildDeletionTreeView() string {
 	var result strings.Builder
 	maxLines := 12 // Show max 12 lines to keep modal from getting too tall
 	lineCount := 0
-	totalFiles := len(m.markedFiles)
+	totalFiles := m.markedFiles.Len()
 
 	// Sort directories for consistent display
 	dirs := make([]string, 0, len(dirMap))
@@ -1066,8 +2938,8 @@ func (m *Model) renderDeleteProgressModal() string {
 
 // renderDeleteSummaryModal renders the deletion summary dialog
 func (m *Model) renderDeleteSummaryModal() string {
-	// Show errors if any
-	if len(m.deleteProgress.Errors) > 0 {
+	// Show errors (or deletions that verification found did not happen) if any
+	if len(m.deleteProgress.Errors) > 0 || len(m.deleteProgress.Leftovers) > 0 {
 		title := lipgloss.NewStyle().
 			Bold(true).
 			Foreground(ColorDanger).
@@ -1083,19 +2955,31 @@ func (m *Model) renderDeleteSummaryModal() string {
 			errorList.WriteString(fmt.Sprintf("  ... and %d more errors\n", len(m.deleteProgress.Errors)-5))
 		}
 
+		errorsSection := ""
+		if len(m.deleteProgress.Errors) > 0 {
+			errorsSection = fmt.Sprintf("%d item(s) failed to delete:\n\n", len(m.deleteProgress.Errors)) +
+				errorList.String() + "\n"
+		}
+
 		message := fmt.Sprintf(
 			"%s\n\n"+
-				"Errors occurred during deletion:\n\n"+
-				"%s\n"+
-				"Successfully deleted: %d item(s)\n"+
-				"Space reclaimed: %s\n\n"+
-				"Press any key to continue",
+				"%s"+
+				"%s"+
+				"%s"+
+				"%s: %d item(s)\n"+
+				"%s: %s\n\n",
 			title,
-			errorList.String(),
+			errorsSection,
+			m.renderLeftovers(),
+			m.renderProtectedSkips(),
+			m.deletedLabel(),
 			m.deleteProgress.FilesDeleted,
+			m.spaceLabel(),
 			util.FormatBytes(m.deleteProgress.BytesDeleted),
 		)
 
+		message += m.summaryFooter()
+
 		content := lipgloss.NewStyle().
 			Width(70).
 			Padding(1, 2).
@@ -1106,12 +2990,19 @@ func (m *Model) renderDeleteSummaryModal() string {
 		return content
 	}
 
+	heading := "✓ Deletion Complete"
+	if m.deleteProgress.Method == safety.DeleteToTrash {
+		heading = "✓ Moved to Trash"
+	}
 	title := lipgloss.NewStyle().
 		Bold(true).
 		Foreground(ColorSuccess).
-		Render("✓ Deletion Complete")
+		Render(heading)
 
 	spaceReclaimed := util.FormatBytes(m.deleteProgress.BytesDeleted)
+	if m.deleteProgress.Verified {
+		spaceReclaimed += " (verified)"
+	}
 
 	// Build message with appropriate details
 	var message string
@@ -1119,30 +3010,35 @@ func (m *Model) renderDeleteSummaryModal() string {
 		// Directories were deleted - show both counts
 		message = fmt.Sprintf(
 			"%s\n\n"+
-				"Successfully deleted:\n"+
+				"%s:\n"+
 				"  • %d item(s) (files and/or directories)\n"+
 				"  • %d total file(s) inside\n"+
-				"  • Space reclaimed: %s\n\n"+
-				"Press any key to continue",
+				"  • %s: %s\n\n",
 			title,
+			m.deletedLabel(),
 			m.deleteProgress.FilesDeleted,
 			m.deleteProgress.TotalFilesDeleted,
+			m.spaceLabel(),
 			spaceReclaimed,
 		)
 	} else {
 		// Only files deleted
 		message = fmt.Sprintf(
 			"%s\n\n"+
-				"Successfully deleted:\n"+
+				"%s:\n"+
 				"  • %d file(s)\n"+
-				"  • Space reclaimed: %s\n\n"+
-				"Press any key to continue",
+				"  • %s: %s\n\n",
 			title,
+			m.deletedLabel(),
 			m.deleteProgress.FilesDeleted,
+			m.spaceLabel(),
 			spaceReclaimed,
 		)
 	}
 
+	message += m.renderProtectedSkips()
+	message += m.summaryFooter()
+
 	content := lipgloss.NewStyle().
 		Width(60).
 		Padding(1, 2).
@@ -1153,6 +3049,84 @@ func (m *Model) renderDeleteSummaryModal() string {
 	return content
 }
 
+// deleteActionName phrases the confirmation for the delete method
+func (m *Model) deleteActionName() string {
+	if m.deleteMethod == safety.DeleteToTrash {
+		return "move to Trash"
+	}
+	return "delete permanently"
+}
+
+// deletedLabel introduces the item counts in the summary
+func (m *Model) deletedLabel() string {
+	if m.deleteProgress.Method == safety.DeleteToTrash {
+		return "Moved to Trash (recoverable)"
+	}
+	return "Permanently deleted"
+}
+
+// spaceLabel names the byte total in the summary: trashed items only free space once the Trash is emptied
+func (m *Model) spaceLabel() string {
+	if m.deleteProgress.Method == safety.DeleteToTrash {
+		return "Space freed when the Trash is emptied"
+	}
+	return "Space reclaimed"
+}
+
+// summaryFooter closes the deletion summary, offering to empty a non-empty Trash
+func (m *Model) summaryFooter() string {
+	if m.deleteProgress.TrashSize > 0 {
+		return fmt.Sprintf("The Trash holds %s that still uses disk space.\n"+
+			"Press t to empty the Trash, any other key to continue",
+			util.FormatBytesPlain(m.deleteProgress.TrashSize))
+	}
+	return "Press any key to continue"
+}
+
+// renderLeftovers lists paths that verification found still on disk ("" if none)
+func (m *Model) renderLeftovers() string {
+	leftovers := m.deleteProgress.Leftovers
+	if len(leftovers) == 0 {
+		return ""
+	}
+
+	var list strings.Builder
+	list.WriteString(fmt.Sprintf("%d item(s) reported deleted are still on disk:\n\n", len(leftovers)))
+	for i, leftover := range leftovers {
+		if i == 5 {
+			list.WriteString(fmt.Sprintf("  ... and %d more\n", len(leftovers)-5))
+			break
+		}
+		list.WriteString(fmt.Sprintf("  • %s (%s of %s remains)\n",
+			m.truncatePath(leftover.Path, 40),
+			util.FormatBytes(leftover.Remains),
+			util.FormatBytes(leftover.Claimed)))
+	}
+	list.WriteString("\n")
+	return list.String()
+}
+
+// renderProtectedSkips lists items the deleter refused because they are protected ("" if none)
+// They are reported apart from errors: nothing went wrong, they were never touched
+func (m *Model) renderProtectedSkips() string {
+	protected := m.deleteProgress.Protected
+	if len(protected) == 0 {
+		return ""
+	}
+
+	var list strings.Builder
+	list.WriteString(fmt.Sprintf("%d item(s) skipped because protected:\n\n", len(protected)))
+	for i, err := range protected {
+		if i == 5 {
+			list.WriteString(fmt.Sprintf("  ... and %d more\n", len(protected)-5))
+			break
+		}
+		list.WriteString(fmt.Sprintf("  • %s\n", err.Error()))
+	}
+	list.WriteString("\n")
+	return list.String()
+}
+
 // renderProgressBar renders a text progress bar
 func (m *Model) renderProgressBar(progress float64, width int) string {
 	filled := int(progress * float64(width))
@@ -173,14 +173,6 @@ func DeleteItems(items []*scanner.FileNode, protector *safety.Protector) (int, i
 
 // moveToTrash moves a file to the macOS Trash
 func moveToTrash(path string) error {
-	// Use osascript to move to trash (macOS specific)
-	// This is safer than rm as items can be recovered
-	script := fmt.Sprintf(`
-		tell application "Finder"
-			move POSIX file "%s" to trash
-		end tell
-	`, path)
-
 	// For now, we'll just use os.Remove as a fallback
 	// In production, you'd use osascript or a proper trash library
 	return os.Remove(path)
@@ -0,0 +1,77 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"spaceforce/scanner"
+)
+
+// fakeDeleter reports every deletion as successful, but only removes the paths in removes
+type fakeDeleter struct {
+	removes map[string]bool
+}
+
+func (d *fakeDeleter) DeleteFile(path string) (int64, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+	if d.removes[path] {
+		if err := os.Remove(path); err != nil {
+			return 0, err
+		}
+	}
+	return info.Size(), nil
+}
+
+func TestDeleteFilesReportsLeftovers(t *testing.T) {
+	dir := t.TempDir()
+	root := scanner.NewFileNode(dir, 0, true, time.Now())
+	files := make(map[string]*scanner.FileNode)
+	for name, size := range map[string]int{"gone.bin": 3000, "stuck.bin": 5000} {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, make([]byte, size), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		node := scanner.NewFileNode(path, int64(size), false, time.Now())
+		root.AddChild(node)
+		files[path] = node
+	}
+	gone, stuck := filepath.Join(dir, "gone.bin"), filepath.Join(dir, "stuck.bin")
+	deleter := &fakeDeleter{removes: map[string]bool{gone: true}}
+
+	msg := deleteFiles(deleter, files, true)
+
+	if msg.ItemsDeleted != 1 || msg.BytesDeleted != 3000 {
+		t.Errorf("deleted %d item(s), %d bytes; want 1 item, 3000 bytes", msg.ItemsDeleted, msg.BytesDeleted)
+	}
+	if len(msg.DeletedPaths) != 1 || msg.DeletedPaths[0] != gone {
+		t.Errorf("DeletedPaths = %v, want [%s]", msg.DeletedPaths, gone)
+	}
+	if len(msg.Leftovers) != 1 || msg.Leftovers[0].Path != stuck ||
+		msg.Leftovers[0].Claimed != 5000 || msg.Leftovers[0].Remains != 5000 {
+		t.Fatalf("Leftovers = %+v, want %s with 5000 bytes claimed and remaining", msg.Leftovers, stuck)
+	}
+
+	// The summary reports the discrepancy, and the leftover stays in the tree
+	var model tea.Model = NewModel(dir)
+	model, _ = model.Update(tea.WindowSizeMsg{Width: 120, Height: 40})
+	model, _ = model.Update(ScanCompleteMsg{Root: root})
+	model, _ = model.Update(msg)
+	m := model.(*Model)
+	if m.activeModal != ModalDeleteSummary {
+		t.Fatalf("activeModal = %v, want the deletion summary", m.activeModal)
+	}
+	if summary := m.renderDeleteSummaryModal(); !strings.Contains(summary, "1 item(s) reported deleted are still on disk") ||
+		!strings.Contains(summary, "stuck.bin") {
+		t.Errorf("summary does not report the leftover:\n%s", summary)
+	}
+	if len(m.root.Children) != 1 || m.root.Children[0].Path != stuck {
+		t.Errorf("tree after deleting = %v, want only %s", m.root.Children, stuck)
+	}
+}
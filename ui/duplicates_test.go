@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"spaceforce/analyzer"
+	"spaceforce/scanner"
+)
+
+func duplicateGroup(paths ...string) *analyzer.DuplicateGroup {
+	group := &analyzer.DuplicateGroup{Size: 4096}
+	for _, path := range paths {
+		group.Files = append(group.Files, scanner.NewFileNode(path, 4096, false, time.Now()))
+	}
+	return group
+}
+
+func TestDuplicateResultsSkipFilesDeletedMidSearch(t *testing.T) {
+	m := NewModel(t.TempDir())
+	m.startDuplicateSearch()
+	search := m.duplicateSearch
+
+	// Deleted while the search was still hashing its snapshot of the tree
+	m.Update(DeleteCompleteMsg{DeletedPaths: []string{"/data/a/copy.bin", "/data/old"}})
+
+	m.Update(DuplicatesFoundMsg{Search: search, Groups: []*analyzer.DuplicateGroup{
+		duplicateGroup("/data/a/copy.bin", "/data/b/copy.bin"),
+		duplicateGroup("/data/old/x.bin", "/data/new/x.bin", "/data/z/x.bin"),
+		duplicateGroup("/data/p.bin", "/data/q.bin"),
+	}})
+
+	if m.findingDuplicates {
+		t.Error("still finding duplicates after the result arrived")
+	}
+	// The first group is down to one surviving copy, which must not be offered for deletion
+	if len(m.duplicates) != 2 {
+		t.Fatalf("got %d duplicate groups, want 2", len(m.duplicates))
+	}
+	for _, group := range m.duplicates {
+		for _, file := range group.Files {
+			if file.Path == "/data/a/copy.bin" || strings.HasPrefix(file.Path, "/data/old/") {
+				t.Errorf("deleted file %s is still listed", file.Path)
+			}
+		}
+	}
+	if len(m.deletedMidSearch) != 0 {
+		t.Errorf("deleted paths were kept after the search: %v", m.deletedMidSearch)
+	}
+}
+
+func TestDuplicateSearchErrorEndsSearch(t *testing.T) {
+	m := NewModel(t.TempDir())
+	m.startDuplicateSearch()
+
+	m.Update(DuplicatesFoundMsg{Search: m.duplicateSearch, Err: errors.New("disk on fire")})
+
+	if m.findingDuplicates {
+		t.Error("a failed search is still shown as running")
+	}
+	if !strings.Contains(m.statusMessage, "disk on fire") {
+		t.Errorf("status = %q, want the search error", m.statusMessage)
+	}
+}
+
+func TestStaleDuplicateResultsIgnored(t *testing.T) {
+	m := NewModel(t.TempDir())
+	m.startDuplicateSearch()
+	stale := m.duplicateSearch
+	m.startDuplicateSearch()
+
+	m.Update(DuplicatesFoundMsg{Search: stale, Groups: []*analyzer.DuplicateGroup{duplicateGroup("/a", "/b")}})
+	m.Update(DuplicatesFoundMsg{Search: stale, Err: errors.New("cancelled")})
+
+	if !m.findingDuplicates || m.duplicates != nil || m.statusMessage != "" {
+		t.Errorf("a superseded search changed the state: finding=%v, %d groups, status %q",
+			m.findingDuplicates, len(m.duplicates), m.statusMessage)
+	}
+}
@@ -0,0 +1,35 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"spaceforce/scanner"
+)
+
+func sizeModeTree() *scanner.FileNode {
+	root := scanner.NewFileNode("/data", 0, true, time.Now())
+	file := scanner.NewFileNode("/data/sparse.img", 100, false, time.Now())
+	file.AllocatedSize = 4096
+	root.AddChild(file)
+	return root
+}
+
+func TestSizeModeToggleKeptAcrossRescan(t *testing.T) {
+	m := NewModel("/data")
+	m.Update(ScanCompleteMsg{Root: sizeModeTree()})
+	m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("a")})
+	if got := m.root.TotalSize(); got != 4096 {
+		t.Fatalf("total after 'a' = %d, want the allocated 4096", got)
+	}
+
+	// A rescan builds a new tree, which starts in apparent sizes
+	m.Update(ScanCompleteMsg{Root: sizeModeTree()})
+	if got := m.root.TotalSize(); got != 4096 {
+		t.Errorf("total after rescan = %d, want the allocated 4096", got)
+	}
+	if got := m.stats.TotalSize; got != 4096 {
+		t.Errorf("stats total after rescan = %d, want 4096", got)
+	}
+}
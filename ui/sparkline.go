@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// rateHistorySize is how many files-per-second samples the scanning sparkline keeps
+	rateHistorySize = 40
+
+	// rateSampleInterval is the minimum time between two rate samples
+	// Progress updates arrive in bursts, so sampling every message would be noisy
+	rateSampleInterval = 500 * time.Millisecond
+)
+
+// sparkLevels are the block characters used by renderSparkline, lowest first
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// rateHistory is a ring buffer of recent files-per-second samples
+type rateHistory struct {
+	samples   [rateHistorySize]float64
+	next      int // Index the next sample is written to
+	count     int // Number of valid samples (up to rateHistorySize)
+	lastTime  time.Time
+	lastFiles int64
+}
+
+// observe records the files scanned so far and adds a rate sample once enough time has passed
+// It is called on every progress update and spinner tick, so stalls are sampled as zero
+func (r *rateHistory) observe(filesScanned int64, now time.Time) {
+	if r.lastTime.IsZero() {
+		r.lastTime = now
+		r.lastFiles = filesScanned
+		return
+	}
+
+	elapsed := now.Sub(r.lastTime)
+	if elapsed < rateSampleInterval {
+		return
+	}
+
+	r.add(float64(filesScanned-r.lastFiles) / elapsed.Seconds())
+	r.lastTime = now
+	r.lastFiles = filesScanned
+}
+
+// add appends a sample, overwriting the oldest one when the buffer is full
+func (r *rateHistory) add(rate float64) {
+	if rate < 0 {
+		rate = 0
+	}
+	r.samples[r.next] = rate
+	r.next = (r.next + 1) % rateHistorySize
+	if r.count < rateHistorySize {
+		r.count++
+	}
+}
+
+// values returns the samples from oldest to newest
+func (r *rateHistory) values() []float64 {
+	values := make([]float64, 0, r.count)
+	start := (r.next - r.count + rateHistorySize) % rateHistorySize
+	for i := 0; i < r.count; i++ {
+		values = append(values, r.samples[(start+i)%rateHistorySize])
+	}
+	return values
+}
+
+// latest returns the most recent sample (0 if there are none)
+func (r *rateHistory) latest() float64 {
+	if r.count == 0 {
+		return 0
+	}
+	return r.samples[(r.next-1+rateHistorySize)%rateHistorySize]
+}
+
+// renderSparkline draws one block character per sample, scaled to the largest sample
+func renderSparkline(samples []float64) string {
+	peak := 0.0
+	for _, sample := range samples {
+		if sample > peak {
+			peak = sample
+		}
+	}
+
+	var b strings.Builder
+	for _, sample := range samples {
+		level := 0
+		if peak > 0 {
+			level = int(sample / peak * float64(len(sparkLevels)-1))
+		}
+		if level < 0 {
+			level = 0
+		}
+		b.WriteRune(sparkLevels[level])
+	}
+	return b.String()
+}
@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRenderSparkline(t *testing.T) {
+	tests := []struct {
+		samples []float64
+		want    string
+	}{
+		{nil, ""},
+		{[]float64{0, 0}, "▁▁"},
+		{[]float64{0, 50, 100}, "▁▄█"},
+		{[]float64{10, 20, 30, 40, 50, 60, 70, 80}, "▁▂▃▄▅▆▇█"},
+		{[]float64{-5, 100}, "▁█"},
+	}
+	for _, tt := range tests {
+		if got := renderSparkline(tt.samples); got != tt.want {
+			t.Errorf("renderSparkline(%v) = %q, want %q", tt.samples, got, tt.want)
+		}
+	}
+}
+
+func TestRateHistorySamplesStalls(t *testing.T) {
+	var rates rateHistory
+	start := time.Now()
+	rates.observe(0, start)
+	rates.observe(100, start.Add(100*time.Millisecond)) // Too soon for a sample
+	rates.observe(1000, start.Add(time.Second))
+	rates.observe(1000, start.Add(2*time.Second)) // No progress: the scan is stuck
+	rates.observe(1000, start.Add(3*time.Second))
+
+	got := rates.values()
+	want := []float64{1000, 0, 0}
+	if len(got) != len(want) {
+		t.Fatalf("values() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("values() = %v, want %v", got, want)
+		}
+	}
+	if got := renderSparkline(got); got != "█▁▁" {
+		t.Errorf("sparkline = %q, want a falling line", got)
+	}
+}
+
+func TestRateHistoryKeepsNewestSamples(t *testing.T) {
+	var rates rateHistory
+	for i := 0; i < rateHistorySize+5; i++ {
+		rates.add(float64(i))
+	}
+	values := rates.values()
+	if len(values) != rateHistorySize || values[0] != 5 || rates.latest() != float64(rateHistorySize+4) {
+		t.Errorf("values() = %v, latest() = %v", values, rates.latest())
+	}
+}
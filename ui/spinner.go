@@ -0,0 +1,81 @@
+package ui
+
+import (
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// spinnerInterval is how often the scanning spinner advances
+	spinnerInterval = 120 * time.Millisecond
+
+	// stallThreshold is how long without a progress update before the scanning view
+	// says it is still working on the current path
+	stallThreshold = 3 * time.Second
+)
+
+// spinnerFrames animate the scanning title, independently of scan progress
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// spinnerTickMsg advances the scanning spinner
+type spinnerTickMsg struct{}
+
+// spinnerTick schedules the next spinner frame
+func spinnerTick() tea.Cmd {
+	return tea.Tick(spinnerInterval, func(time.Time) tea.Msg {
+		return spinnerTickMsg{}
+	})
+}
+
+// activity tracks the scanning spinner and when progress was last reported, so a scan
+// stuck in one slow directory still looks alive and says where it is
+type activity struct {
+	frame        int
+	running      bool      // A tick is scheduled (keeps rescans from starting a second chain)
+	lastProgress time.Time // When the last progress update arrived
+}
+
+// start resets the stall clock and returns the first tick, unless one is already scheduled
+func (a *activity) start(now time.Time) tea.Cmd {
+	a.lastProgress = now
+	if a.running {
+		return nil
+	}
+	a.running = true
+	return spinnerTick()
+}
+
+// tick advances the spinner and schedules the next frame while scanning
+func (a *activity) tick(scanning bool, paused bool) tea.Cmd {
+	if !scanning {
+		a.running = false
+		return nil
+	}
+	if !paused {
+		a.frame = (a.frame + 1) % len(spinnerFrames)
+	}
+	return spinnerTick()
+}
+
+// observe records that a progress update arrived
+func (a *activity) observe(now time.Time) {
+	a.lastProgress = now
+}
+
+// spinner returns the current spinner frame
+func (a *activity) spinner() string {
+	return spinnerFrames[a.frame]
+}
+
+// stalled returns how long it has been since the last progress update, or 0 if that
+// is still within stallThreshold
+func (a *activity) stalled(now time.Time) time.Duration {
+	if a.lastProgress.IsZero() {
+		return 0
+	}
+	if quiet := now.Sub(a.lastProgress); quiet >= stallThreshold {
+		return quiet
+	}
+	return 0
+}
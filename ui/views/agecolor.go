@@ -0,0 +1,48 @@
+package views
+
+import (
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"spaceforce/util"
+)
+
+// ageColors run from recently modified (green) to ancient (gray)
+var ageColors = []lipgloss.Color{
+	lipgloss.Color("#10B981"),
+	lipgloss.Color("#6EE7B7"),
+	lipgloss.Color("#D1D5DB"),
+	lipgloss.Color("#9CA3AF"),
+	util.ColorMuted,
+}
+
+// AgeLegend describes the age coloring using the oldest of the timeline buckets set by
+// cutoffs (nil = built-in buckets)
+func AgeLegend(cutoffs []TimelineCutoff) string {
+	buckets := timelineBuckets(time.Now(), cutoffs)
+	return "green = recent, gray = " + buckets[len(buckets)-1].Name
+}
+
+// timelineBuckets returns the (empty) timeline buckets for cutoffs, newest first
+func timelineBuckets(now time.Time, cutoffs []TimelineCutoff) []*TimeBucket {
+	if len(cutoffs) > 0 {
+		return customBuckets(now, cutoffs)
+	}
+	return defaultBuckets(now)
+}
+
+// ageStyle returns the name style for a file modified at modTime
+// The timeline buckets decide the age band, spread evenly over ageColors
+func ageStyle(modTime time.Time, now time.Time, cutoffs []TimelineCutoff) lipgloss.Style {
+	buckets := timelineBuckets(now, cutoffs)
+	band := len(buckets) - 1
+	for i, bucket := range buckets {
+		if !modTime.Before(bucket.StartDate) {
+			band = i
+			break
+		}
+	}
+
+	color := ageColors[band*(len(ageColors)-1)/(len(buckets)-1)]
+	return lipgloss.NewStyle().Foreground(color)
+}
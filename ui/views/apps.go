@@ -0,0 +1,207 @@
+package views
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"spaceforce/analyzer"
+	"spaceforce/scanner"
+	"spaceforce/util"
+)
+
+// AppsView displays sandbox container space grouped by application
+type AppsView struct {
+	apps          []*analyzer.AppUsage
+	totalSize     int64
+	selectedIndex int
+	height        int
+}
+
+// NewAppsView creates a new per-application container view
+func NewAppsView(root *scanner.FileNode) *AppsView {
+	apps := analyzer.GroupContainersByApp(root, analyzer.NewAppNameResolver())
+
+	totalSize := int64(0)
+	for _, app := range apps {
+		totalSize += app.Size
+	}
+
+	return &AppsView{
+		apps:      apps,
+		totalSize: totalSize,
+		height:    20,
+	}
+}
+
+// Init initializes the view
+func (av *AppsView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles updates
+func (av *AppsView) Update(msg tea.Msg) (*AppsView, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if av.selectedIndex > 0 {
+				av.selectedIndex--
+			}
+		case "down", "j":
+			if av.selectedIndex < len(av.apps)-1 {
+				av.selectedIndex++
+			}
+		case "enter", "return":
+			// Jump to tree view with the app's largest container
+			if node := av.GetSelectedNode(); node != nil {
+				return av, func() tea.Msg {
+					return "JUMP_TO_TREE:" + node.Path
+				}
+			}
+		}
+	}
+	return av, nil
+}
+
+// View renders the view
+func (av *AppsView) View() string {
+	var b strings.Builder
+
+	b.WriteString(util.TitleStyle.Render("📦 Space by Application"))
+	b.WriteString("\n")
+	b.WriteString(util.SubtitleStyle.Render(fmt.Sprintf("%d apps | %s in ~/Library/Containers and Group Containers",
+		len(av.apps), util.FormatBytesPlain(av.totalSize))))
+	b.WriteString("\n\n")
+
+	if len(av.apps) == 0 {
+		b.WriteString(util.HelpStyle.Render("No app containers found in this scan."))
+		return b.String()
+	}
+
+	// Header
+	header := fmt.Sprintf("%-32s %10s %12s %8s  %s",
+		"Application", "Containers", "Size", "Percent", "Distribution")
+	b.WriteString(util.HelpStyle.Render(header))
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat("─", 90))
+	b.WriteString("\n")
+
+	// Reserve lines for title (2), subtitle (3), header (2), separator (2), footer (2)
+	contentHeight := av.height - 11
+	if contentHeight < 1 {
+		contentHeight = 1
+	}
+
+	// Calculate viewport
+	start := av.selectedIndex - contentHeight/2
+	if start < 0 {
+		start = 0
+	}
+	end := start + contentHeight
+	if end > len(av.apps) {
+		end = len(av.apps)
+		start = end - contentHeight
+		if start < 0 {
+			start = 0
+		}
+	}
+
+	// Render items
+	for i := start; i < end && i < len(av.apps); i++ {
+		b.WriteString(av.renderApp(av.apps[i], i == av.selectedIndex))
+		b.WriteString("\n")
+	}
+
+	// Footer
+	if len(av.apps) > contentHeight {
+		b.WriteString("\n")
+		b.WriteString(util.HelpStyle.Render(fmt.Sprintf("Showing %d-%d of %d apps",
+			start+1, end, len(av.apps))))
+	}
+
+	return b.String()
+}
+
+// renderApp renders a single application row
+func (av *AppsView) renderApp(app *analyzer.AppUsage, selected bool) string {
+	name := app.AppName
+	if len(name) > 32 {
+		name = name[:29] + "..."
+	}
+
+	percentage := 0.0
+	if av.totalSize > 0 {
+		percentage = float64(app.Size) / float64(av.totalSize) * 100
+	}
+
+	// Create progress bar
+	barWidth := 20
+	filledWidth := int(percentage / 100 * float64(barWidth))
+	if filledWidth > barWidth {
+		filledWidth = barWidth
+	}
+	bar := strings.Repeat("█", filledWidth) + strings.Repeat("░", barWidth-filledWidth)
+
+	line := fmt.Sprintf("%-32s %10d %12s %7.1f%%  %s",
+		name,
+		len(app.Containers),
+		util.FormatBytes(app.Size),
+		percentage,
+		bar)
+
+	if selected {
+		return util.SelectedItemStyle.Render(line)
+	}
+	return util.NormalItemStyle.Render(line)
+}
+
+// RemoveNodes drops deleted containers and shrinks apps that lost space inside theirs
+func (av *AppsView) RemoveNodes(r *Removal) {
+	kept := make([]*analyzer.AppUsage, 0, len(av.apps))
+	for _, app := range av.apps {
+		for _, container := range app.Containers {
+			removed := r.bytesFrom(container)
+			app.Size -= removed
+			av.totalSize -= removed
+		}
+		app.Containers = r.Without(app.Containers)
+		if len(app.Containers) > 0 {
+			kept = append(kept, app)
+		}
+	}
+
+	sort.Slice(kept, func(i, j int) bool {
+		return kept[i].Size > kept[j].Size
+	})
+	av.apps = kept
+	if av.selectedIndex >= len(av.apps) {
+		av.selectedIndex = len(av.apps) - 1
+	}
+	if av.selectedIndex < 0 {
+		av.selectedIndex = 0
+	}
+}
+
+// SetHeight sets the viewport height
+func (av *AppsView) SetHeight(height int) {
+	av.height = height
+}
+
+// GetSelectedApp returns the currently selected application
+func (av *AppsView) GetSelectedApp() *analyzer.AppUsage {
+	if av.selectedIndex < len(av.apps) {
+		return av.apps[av.selectedIndex]
+	}
+	return nil
+}
+
+// GetSelectedNode returns the largest container of the selected application
+func (av *AppsView) GetSelectedNode() *scanner.FileNode {
+	app := av.GetSelectedApp()
+	if app == nil || len(app.Containers) == 0 {
+		return nil
+	}
+	return app.Containers[0]
+}
@@ -12,34 +12,186 @@ import (
 
 // BreakdownView displays file type breakdown statistics
 type BreakdownView struct {
-	stats         *scanner.DirStats
-	types         []*scanner.TypeStats
-	selectedIndex int
-	height        int
-	totalSize     int64
+	stats           *scanner.DirStats // Shared with the app model, which computes it once per scan
+	types           []*scanner.TypeStats // Rows for the current grouping
+	extensionTypes  []*scanner.TypeStats // One row per extension
+	categoryTypes   []*scanner.TypeStats // One row per GetCategoryDescription category
+	groupByCategory bool
+	selectedIndex   int
+	height          int
+	totalSize       int64
+	scannedOnDisk   int64 // Allocated size of the scan, comparable to filesystem usage
+	volumeUsed      int64 // Bytes used on the scanned filesystem (0 if unknown)
+	identified      map[*scanner.FileNode]string // Detected types of files without an extension (nil until 'i')
 }
 
-// NewBreakdownView creates a new breakdown view
-func NewBreakdownView(root *scanner.FileNode) *BreakdownView {
-	stats := scanner.CalculateStats(root)
+// identifiedPrefix starts the row key of files without an extension of one detected type
+const identifiedPrefix = scanner.NoExtensionType + ":"
+
+// identifyLimit caps how many files without an extension 'i' reads the header of (largest first)
+const identifyLimit = 10000
+
+// NewBreakdownView creates a new breakdown view of root from its already computed stats
+func NewBreakdownView(root *scanner.FileNode, stats *scanner.DirStats) *BreakdownView {
 	bv := &BreakdownView{
 		stats:     stats,
-		types:     make([]*scanner.TypeStats, 0),
 		height:    20,
 		totalSize: stats.TotalSize,
 	}
+	if root != nil {
+		bv.scannedOnDisk = root.TotalAllocatedSize()
+	}
+	bv.buildRows()
+
+	return bv
+}
 
-	// Convert map to sorted slice
-	for _, typeStats := range stats.TypeBreakdown {
-		bv.types = append(bv.types, typeStats)
+// buildRows rebuilds the extension and category rows from the stats
+// Once identified, files without an extension get one row per detected type
+func (bv *BreakdownView) buildRows() {
+	bv.extensionTypes = make([]*scanner.TypeStats, 0, len(bv.stats.TypeBreakdown))
+	for _, typeStats := range bv.stats.TypeBreakdown {
+		if typeStats.Extension == scanner.NoExtensionType && bv.identified != nil {
+			bv.extensionTypes = append(bv.extensionTypes, splitByDetectedType(typeStats, bv.identified)...)
+			continue
+		}
+		bv.extensionTypes = append(bv.extensionTypes, typeStats)
 	}
+	sortTypeStats(bv.extensionTypes)
 
-	// Sort by total size descending
-	sort.Slice(bv.types, func(i, j int) bool {
-		return bv.types[i].TotalSize > bv.types[j].TotalSize
+	bv.categoryTypes = GroupByCategory(bv.extensionTypes)
+	if bv.groupByCategory {
+		bv.types = bv.categoryTypes
+	} else {
+		bv.types = bv.extensionTypes
+	}
+}
+
+// identifyNoExtension detects the type of files without an extension from their headers
+// Only the identifyLimit largest are read; the rest stay in the [no extension] row
+func (bv *BreakdownView) identifyNoExtension() {
+	typeStats, exists := bv.stats.TypeBreakdown[scanner.NoExtensionType]
+	if !exists {
+		return
+	}
+
+	files := make([]*scanner.FileNode, len(typeStats.Files))
+	copy(files, typeStats.Files)
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].FileSize() > files[j].FileSize()
 	})
+	if len(files) > identifyLimit {
+		files = files[:identifyLimit]
+	}
 
-	return bv
+	bv.identified = make(map[*scanner.FileNode]string, len(files))
+	for _, file := range files {
+		bv.identified[file] = scanner.DetectType(file.Path)
+	}
+	bv.buildRows()
+	bv.selectedIndex = 0
+}
+
+// splitByDetectedType splits the files of the no-extension row by their detected type
+// Files not identified (or of an unknown type) stay in a [no extension] row, as do
+// files counted without a node (low-memory scans)
+func splitByDetectedType(typeStats *scanner.TypeStats, identified map[*scanner.FileNode]string) []*scanner.TypeStats {
+	byType := make(map[string]*scanner.TypeStats)
+	split := func(key string) *scanner.TypeStats {
+		row, exists := byType[key]
+		if !exists {
+			row = &scanner.TypeStats{Extension: key}
+			byType[key] = row
+		}
+		return row
+	}
+
+	unlistedSize, unlistedCount := typeStats.TotalSize, typeStats.FileCount
+	for _, file := range typeStats.Files {
+		key := scanner.NoExtensionType
+		if detected := identified[file]; detected != "" {
+			key = identifiedPrefix + detected
+		}
+		row := split(key)
+		row.TotalSize += file.FileSize()
+		row.FileCount++
+		row.Files = append(row.Files, file)
+		unlistedSize -= file.FileSize()
+		unlistedCount--
+	}
+	if unlistedCount > 0 {
+		row := split(scanner.NoExtensionType)
+		row.TotalSize += unlistedSize
+		row.FileCount += unlistedCount
+	}
+
+	rows := make([]*scanner.TypeStats, 0, len(byType))
+	for _, split := range byType {
+		rows = append(rows, split)
+	}
+	return rows
+}
+
+// RemoveNodes takes deleted files out of the per-type totals without walking the tree again
+// This updates the stats shared with the app model too
+func (bv *BreakdownView) RemoveNodes(r *Removal) {
+	bv.stats.RemoveFiles(r.files, r.dirs)
+	bv.buildRows()
+
+	bv.totalSize = bv.stats.TotalSize
+	bv.scannedOnDisk -= r.allocated
+
+	if bv.selectedIndex >= len(bv.types) {
+		bv.selectedIndex = len(bv.types) - 1
+	}
+	if bv.selectedIndex < 0 {
+		bv.selectedIndex = 0
+	}
+}
+
+// SetVolumeUsage sets the used bytes of the scanned filesystem for the reconciliation line
+func (bv *BreakdownView) SetVolumeUsage(used int64) {
+	bv.volumeUsed = used
+}
+
+// unscannedSpace returns the filesystem usage a scan does not account for
+// (purgeable space, snapshots, other users' files, or anything outside the scan root)
+func unscannedSpace(volumeUsed, scannedOnDisk int64) int64 {
+	if volumeUsed <= scannedOnDisk {
+		return 0
+	}
+	return volumeUsed - scannedOnDisk
+}
+
+// GroupByCategory merges per-extension stats into GetCategoryDescription categories
+// The result is sorted by total size descending
+func GroupByCategory(extensionTypes []*scanner.TypeStats) []*scanner.TypeStats {
+	byCategory := make(map[string]*scanner.TypeStats)
+	for _, typeStats := range extensionTypes {
+		category := GetCategoryDescription(strings.ToLower(typeStats.Extension))
+		grouped, exists := byCategory[category]
+		if !exists {
+			grouped = &scanner.TypeStats{Extension: category}
+			byCategory[category] = grouped
+		}
+		grouped.TotalSize += typeStats.TotalSize
+		grouped.FileCount += typeStats.FileCount
+		grouped.Files = append(grouped.Files, typeStats.Files...)
+	}
+
+	categories := make([]*scanner.TypeStats, 0, len(byCategory))
+	for _, grouped := range byCategory {
+		categories = append(categories, grouped)
+	}
+	sortTypeStats(categories)
+	return categories
+}
+
+// sortTypeStats sorts type stats by total size descending
+func sortTypeStats(types []*scanner.TypeStats) {
+	sort.Slice(types, func(i, j int) bool {
+		return types[i].TotalSize > types[j].TotalSize
+	})
 }
 
 // Init initializes the view
@@ -60,6 +212,38 @@ func (bv *BreakdownView) Update(msg tea.Msg) (*BreakdownView, tea.Cmd) {
 			if bv.selectedIndex < len(bv.types)-1 {
 				bv.selectedIndex++
 			}
+		case "pgup", "pgdown", "home", "end":
+			bv.selectedIndex = navigateList(msg.String(), bv.selectedIndex, len(bv.types), bv.pageSize())
+		case "enter", "return":
+			// Drill into the selected type's files in the top list
+			if typeStats := bv.GetSelectedType(); typeStats != nil {
+				label := bv.typeLabel(typeStats)
+				files := typeStats.Files
+				return bv, func() tea.Msg {
+					return ShowInTopListMsg{Label: label, Files: files}
+				}
+			}
+		case "t":
+			// Show the selected type's largest file in the tree
+			if node := bv.GetSelectedNode(); node != nil {
+				return bv, func() tea.Msg {
+					return "JUMP_TO_TREE:" + node.Path
+				}
+			}
+		case "g":
+			// Toggle between extension and category grouping
+			bv.groupByCategory = !bv.groupByCategory
+			if bv.groupByCategory {
+				bv.types = bv.categoryTypes
+			} else {
+				bv.types = bv.extensionTypes
+			}
+			bv.selectedIndex = 0
+		case "i":
+			// Identify files without an extension by their magic bytes
+			if bv.identified == nil {
+				bv.identifyNoExtension()
+			}
 		}
 	}
 	return bv, nil
@@ -71,13 +255,28 @@ func (bv *BreakdownView) View() string {
 
 	b.WriteString(util.TitleStyle.Render("📈 File Type Breakdown"))
 	b.WriteString("\n")
-	b.WriteString(util.SubtitleStyle.Render(fmt.Sprintf("Total: %s across %d files in %d directories",
-		util.FormatBytes(bv.stats.TotalSize), bv.stats.FileCount, bv.stats.DirCount)))
-	b.WriteString("\n\n")
+	grouping := "extension"
+	if bv.groupByCategory {
+		grouping = "category"
+	}
+	b.WriteString(util.SubtitleStyle.Render(fmt.Sprintf("Total: %s across %d files in %d directories | Grouped by %s (g to toggle)",
+		util.FormatBytes(bv.stats.TotalSize), bv.stats.FileCount, bv.stats.DirCount, grouping)))
+	b.WriteString("\n")
+	if bv.volumeUsed > 0 {
+		b.WriteString(util.HelpStyle.Render(fmt.Sprintf("Scanned on disk: %s | Filesystem used: %s | Purgeable/unscanned: %s",
+			util.FormatBytesPlain(bv.scannedOnDisk), util.FormatBytesPlain(bv.volumeUsed),
+			util.FormatBytesPlain(unscannedSpace(bv.volumeUsed, bv.scannedOnDisk)))))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
 
 	// Header
+	typeHeader, rowLabel := "Type", "types"
+	if bv.groupByCategory {
+		typeHeader, rowLabel = "Category", "categories"
+	}
 	header := fmt.Sprintf("%-20s %12s %10s %8s %s",
-		"Type", "Total Size", "Files", "Percent", "Bar")
+		typeHeader, "Total Size", "Files", "Percent", "Bar")
 	b.WriteString(util.HelpStyle.Render(header))
 	b.WriteString("\n")
 	b.WriteString(strings.Repeat("─", 90))
@@ -85,10 +284,7 @@ func (bv *BreakdownView) View() string {
 
 	// Reserve lines for title (2), subtitle (3), header (2), separator (2), summary (2)
 	// Total chrome: 9 lines + 2 for optional summary = 11 lines worst case
-	contentHeight := bv.height - 11
-	if contentHeight < 1 {
-		contentHeight = 1
-	}
+	contentHeight := bv.pageSize()
 
 	// Calculate viewport
 	start := bv.selectedIndex - contentHeight/2
@@ -115,8 +311,8 @@ func (bv *BreakdownView) View() string {
 	// Summary
 	if len(bv.types) > contentHeight {
 		b.WriteString("\n")
-		b.WriteString(util.HelpStyle.Render(fmt.Sprintf("Showing %d-%d of %d types",
-			start+1, end, len(bv.types))))
+		b.WriteString(util.HelpStyle.Render(fmt.Sprintf("Showing %d-%d of %d %s",
+			start+1, end, len(bv.types), rowLabel)))
 	}
 
 	return b.String()
@@ -139,12 +335,7 @@ func (bv *BreakdownView) renderTypeStats(typeStats *scanner.TypeStats, selected
 	bar := strings.Repeat("█", filledWidth) + strings.Repeat("░", barWidth-filledWidth)
 
 	// Format type name
-	typeName := typeStats.Extension
-	if typeName == "directory" {
-		typeName = "[directories]"
-	} else if typeName == "no-extension" {
-		typeName = "[no extension]"
-	}
+	typeName := bv.typeLabel(typeStats)
 	if len(typeName) > 18 {
 		typeName = typeName[:15] + "..."
 	}
@@ -163,6 +354,35 @@ func (bv *BreakdownView) renderTypeStats(typeStats *scanner.TypeStats, selected
 	return util.NormalItemStyle.Render(line)
 }
 
+// typeLabel returns the display name of a row (category names are already readable)
+func (bv *BreakdownView) typeLabel(typeStats *scanner.TypeStats) string {
+	if bv.groupByCategory {
+		return typeStats.Extension
+	}
+	switch typeStats.Extension {
+	case "directory":
+		return "[directories]"
+	case scanner.NoExtensionType:
+		return "[no extension]"
+	}
+	if detected := strings.TrimPrefix(typeStats.Extension, identifiedPrefix); detected != typeStats.Extension {
+		return "[" + detected + "]"
+	}
+	return typeStats.Extension
+}
+
+// pageSize returns the number of rows that fit in the viewport
+func (bv *BreakdownView) pageSize() int {
+	contentHeight := bv.height - 11
+	if bv.volumeUsed > 0 {
+		contentHeight-- // Reconciliation line
+	}
+	if contentHeight < 1 {
+		contentHeight = 1
+	}
+	return contentHeight
+}
+
 // SetHeight sets the viewport height
 func (bv *BreakdownView) SetHeight(height int) {
 	bv.height = height
@@ -176,6 +396,25 @@ func (bv *BreakdownView) GetSelectedType() *scanner.TypeStats {
 	return nil
 }
 
+// GetSelectedNode returns the largest file of the selected type
+func (bv *BreakdownView) GetSelectedNode() *scanner.FileNode {
+	if typeStats := bv.GetSelectedType(); typeStats != nil {
+		return largestFile(typeStats.Files)
+	}
+	return nil
+}
+
+// largestFile returns the biggest of files, or nil if there are none
+func largestFile(files []*scanner.FileNode) *scanner.FileNode {
+	var largest *scanner.FileNode
+	for _, file := range files {
+		if largest == nil || file.FileSize() > largest.FileSize() {
+			largest = file
+		}
+	}
+	return largest
+}
+
 // GetCategoryDescription returns a description for common file categories
 func GetCategoryDescription(extension string) string {
 	categories := map[string]string{
@@ -204,11 +443,14 @@ func GetCategoryDescription(extension string) string {
 		".pkg":       "Installers",
 		".cache":     "Cache Files",
 		"directory":  "Directories",
-		"no-extension": "Files without extension",
+		scanner.NoExtensionType: "Files without extension",
 	}
 
 	if desc, ok := categories[extension]; ok {
 		return desc
 	}
+	if strings.HasPrefix(extension, strings.ToLower(identifiedPrefix)) {
+		return categories[scanner.NoExtensionType]
+	}
 	return "Other Files"
 }
@@ -0,0 +1,75 @@
+package views
+
+import (
+	"fmt"
+
+	"spaceforce/scanner"
+	"spaceforce/util"
+)
+
+// selectionDetail renders the size/share line for the selected node
+// The scan total and the last rendered line are cached, so the tree is only
+// walked again when the selection changes
+type selectionDetail struct {
+	rootTotal int64
+	path      string
+	line      string
+}
+
+// newSelectionDetail creates a detail line for nodes within root
+func newSelectionDetail(root *scanner.FileNode) *selectionDetail {
+	detail := &selectionDetail{}
+	if root != nil {
+		detail.rootTotal = root.TotalSize()
+	}
+	return detail
+}
+
+// render returns the detail line for node ("" if there is no selection)
+func (d *selectionDetail) render(node *scanner.FileNode) string {
+	if node == nil {
+		return ""
+	}
+	if node.Path == d.path {
+		return d.line
+	}
+
+	size := node.TotalSize()
+	parentSize := int64(0)
+	if node.Parent != nil {
+		parentSize = node.Parent.TotalSize()
+	}
+	ofParent, ofTotal := sizeShares(size, parentSize, d.rootTotal)
+
+	line := fmt.Sprintf("%s: %s", node.Name, util.FormatBytesPlain(size))
+	if node.Parent != nil {
+		line += fmt.Sprintf(" • %.1f%% of %s", ofParent, node.Parent.Name)
+	}
+	line += fmt.Sprintf(" • %.1f%% of total", ofTotal)
+	if node.IsSymlink {
+		line += " • 🔗 symlink → " + node.LinkTarget
+	}
+
+	d.path = node.Path
+	d.line = line
+	return line
+}
+
+// remove takes deleted bytes out of the scan total and forgets the cached line
+func (d *selectionDetail) remove(bytes int64) {
+	d.rootTotal -= bytes
+	d.path = ""
+}
+
+// sizeShares returns size as a percentage of its parent and of the whole scan
+// A zero parent or total gives 0 rather than dividing by zero
+func sizeShares(size, parentSize, totalSize int64) (float64, float64) {
+	ofParent, ofTotal := 0.0, 0.0
+	if parentSize > 0 {
+		ofParent = float64(size) / float64(parentSize) * 100
+	}
+	if totalSize > 0 {
+		ofTotal = float64(size) / float64(totalSize) * 100
+	}
+	return ofParent, ofTotal
+}
@@ -0,0 +1,55 @@
+package views
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSizeShares(t *testing.T) {
+	tests := []struct {
+		name                    string
+		size, parent, total     int64
+		wantParent, wantOfTotal float64
+	}{
+		{"quarter of parent", 250, 1000, 4000, 25, 6.25},
+		{"whole parent", 1000, 1000, 1000, 100, 100},
+		{"empty item", 0, 1000, 4000, 0, 0},
+		{"zero parent", 250, 0, 4000, 0, 6.25},
+		{"zero total", 250, 1000, 0, 25, 0},
+		{"empty scan", 0, 0, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		ofParent, ofTotal := sizeShares(tt.size, tt.parent, tt.total)
+		if ofParent != tt.wantParent || ofTotal != tt.wantOfTotal {
+			t.Errorf("%s: got %.2f%% of parent, %.2f%% of total; want %.2f%%, %.2f%%",
+				tt.name, ofParent, ofTotal, tt.wantParent, tt.wantOfTotal)
+		}
+	}
+}
+
+func TestSelectionDetailLine(t *testing.T) {
+	root := testDir(nil, "root")
+	dir := testDir(root, "dir")
+	file := testFile(dir, "file", 250)
+	testFile(dir, "other", 750)
+	testFile(root, "outside", 3000)
+
+	detail := newSelectionDetail(root)
+	line := detail.render(file)
+	for _, want := range []string{"file:", "25.0% of dir", "6.2% of total"} {
+		if !strings.Contains(line, want) {
+			t.Errorf("detail %q is missing %q", line, want)
+		}
+	}
+
+	// The root has no parent share, only its share of the total
+	if line := detail.render(root); strings.Contains(line, "of root") || !strings.Contains(line, "100.0% of total") {
+		t.Errorf("root detail = %q", line)
+	}
+
+	// Deleting everything leaves a zero total, which must not divide by zero
+	detail.remove(root.TotalSize())
+	if line := detail.render(file); !strings.Contains(line, "0.0% of total") {
+		t.Errorf("detail after removing everything = %q", line)
+	}
+}
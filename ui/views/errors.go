@@ -1,7 +1,9 @@
 package views
 
 import (
+	"bufio"
 	"fmt"
+	"io"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -13,13 +15,26 @@ type ErrorsView struct {
 	errors        []error
 	selectedIndex int
 	height        int
+	grouped       bool            // Show errors under collapsible per-type headers
+	expanded      map[string]bool // Error type -> expanded (grouped mode)
+	rows          []errorRow      // Rows shown in grouped mode
+	rowIndex      int             // Selected row in grouped mode
+}
+
+// errorRow is a line of the grouped display: a type header, or an error beneath it
+type errorRow struct {
+	errType string
+	count   int   // Errors of this type (headers only)
+	err     error // nil for headers
+	index   int   // Position of err in the flat list
 }
 
 // NewErrorsView creates a new errors view
 func NewErrorsView(errors []error) *ErrorsView {
 	return &ErrorsView{
-		errors: errors,
-		height: 20,
+		errors:   errors,
+		height:   20,
+		expanded: make(map[string]bool),
 	}
 }
 
@@ -32,6 +47,16 @@ func (ev *ErrorsView) Init() tea.Cmd {
 func (ev *ErrorsView) Update(msg tea.Msg) (*ErrorsView, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if msg.String() == "g" {
+			ev.grouped = !ev.grouped
+			ev.buildRows()
+			return ev, nil
+		}
+		if ev.grouped {
+			ev.updateGrouped(msg)
+			return ev, nil
+		}
+
 		switch msg.String() {
 		case "up", "k":
 			if ev.selectedIndex > 0 {
@@ -41,6 +66,8 @@ func (ev *ErrorsView) Update(msg tea.Msg) (*ErrorsView, tea.Cmd) {
 			if ev.selectedIndex < len(ev.errors)-1 {
 				ev.selectedIndex++
 			}
+		case "pgup", "pgdown", "home", "end":
+			ev.selectedIndex = navigateList(msg.String(), ev.selectedIndex, len(ev.errors), ev.pageSize())
 		}
 	}
 	return ev, nil
@@ -62,12 +89,14 @@ func (ev *ErrorsView) View() string {
 	b.WriteString(util.SubtitleStyle.Render("These directories/files could not be accessed"))
 	b.WriteString("\n\n")
 
+	if ev.grouped {
+		b.WriteString(ev.viewGrouped())
+		return b.String()
+	}
+
 	// Reserve lines for title (2), subtitle (3), footer (2)
 	// Total chrome: 5 lines + 2 for optional footer = 7 lines worst case
-	contentHeight := ev.height - 7
-	if contentHeight < 1 {
-		contentHeight = 1
-	}
+	contentHeight := ev.pageSize()
 
 	// Calculate viewport
 	start := ev.selectedIndex - contentHeight/2
@@ -129,6 +158,135 @@ func (ev *ErrorsView) renderError(index int, selected bool) string {
 	}
 }
 
+// updateGrouped handles navigation in grouped mode
+// Collapsed groups are a single row, so the cursor moves between groups until one is expanded
+func (ev *ErrorsView) updateGrouped(msg tea.KeyMsg) {
+	switch msg.String() {
+	case "up", "k":
+		if ev.rowIndex > 0 {
+			ev.rowIndex--
+		}
+	case "down", "j":
+		if ev.rowIndex < len(ev.rows)-1 {
+			ev.rowIndex++
+		}
+	case "pgup", "pgdown", "home", "end":
+		ev.rowIndex = navigateList(msg.String(), ev.rowIndex, len(ev.rows), ev.pageSize())
+	case "enter", " ", "right", "l", "left", "h":
+		if ev.rowIndex >= len(ev.rows) {
+			return
+		}
+		errType := ev.rows[ev.rowIndex].errType
+		switch msg.String() {
+		case "right", "l":
+			ev.expanded[errType] = true
+		case "left", "h":
+			ev.expanded[errType] = false
+		default:
+			ev.expanded[errType] = !ev.expanded[errType]
+		}
+		ev.buildRows()
+
+		// Keep the cursor on the group header that was toggled
+		for i, row := range ev.rows {
+			if row.err == nil && row.errType == errType {
+				ev.rowIndex = i
+				break
+			}
+		}
+	}
+}
+
+// buildRows rebuilds the grouped rows from the expansion state
+func (ev *ErrorsView) buildRows() {
+	// Group positions in the flat list, so rows keep the flat numbering
+	byType := make(map[string][]int)
+	for i, err := range ev.errors {
+		errType := errorType(err)
+		byType[errType] = append(byType[errType], i)
+	}
+
+	ev.rows = make([]errorRow, 0)
+	for _, errType := range errorTypeOrder {
+		indexes := byType[errType]
+		if len(indexes) == 0 {
+			continue
+		}
+		ev.rows = append(ev.rows, errorRow{errType: errType, count: len(indexes)})
+		if !ev.expanded[errType] {
+			continue
+		}
+		for _, i := range indexes {
+			ev.rows = append(ev.rows, errorRow{errType: errType, err: ev.errors[i], index: i})
+		}
+	}
+
+	if ev.rowIndex >= len(ev.rows) {
+		ev.rowIndex = len(ev.rows) - 1
+	}
+	if ev.rowIndex < 0 {
+		ev.rowIndex = 0
+	}
+}
+
+// viewGrouped renders the per-type sections
+func (ev *ErrorsView) viewGrouped() string {
+	var b strings.Builder
+	contentHeight := ev.pageSize()
+
+	start := ev.rowIndex - contentHeight/2
+	if start < 0 {
+		start = 0
+	}
+	end := start + contentHeight
+	if end > len(ev.rows) {
+		end = len(ev.rows)
+		start = end - contentHeight
+		if start < 0 {
+			start = 0
+		}
+	}
+
+	for i := start; i < end; i++ {
+		row := ev.rows[i]
+		selected := i == ev.rowIndex
+		if row.err != nil {
+			b.WriteString("  " + ev.renderError(row.index, selected))
+			b.WriteString("\n")
+			continue
+		}
+
+		arrow := "▶"
+		if ev.expanded[row.errType] {
+			arrow = "▼"
+		}
+		line := fmt.Sprintf("%s %s (%d)", arrow, row.errType, row.count)
+		if selected {
+			b.WriteString(util.SelectedItemStyle.Render(line))
+		} else {
+			b.WriteString(util.SubtitleStyle.UnsetMarginBottom().Render(line))
+		}
+		b.WriteString("\n")
+	}
+
+	if len(ev.rows) > contentHeight {
+		b.WriteString("\n")
+		b.WriteString(util.HelpStyle.Render(fmt.Sprintf("Showing %d-%d of %d rows",
+			start+1, end, len(ev.rows))))
+	}
+
+	return b.String()
+}
+
+// pageSize returns the number of rows that fit in the viewport
+func (ev *ErrorsView) pageSize() int {
+	contentHeight := ev.height - 7
+	if contentHeight < 1 {
+		contentHeight = 1
+	}
+	return contentHeight
+}
+
 // SetHeight sets the viewport height
 func (ev *ErrorsView) SetHeight(height int) {
 	ev.height = height
@@ -144,18 +302,45 @@ func (ev *ErrorsView) GetErrorsByType() map[string][]error {
 	byType := make(map[string][]error)
 
 	for _, err := range ev.errors {
-		errStr := strings.ToLower(err.Error())
-
-		if strings.Contains(errStr, "permission denied") {
-			byType["Permission Denied"] = append(byType["Permission Denied"], err)
-		} else if strings.Contains(errStr, "not found") || strings.Contains(errStr, "no such") {
-			byType["Not Found"] = append(byType["Not Found"], err)
-		} else if strings.Contains(errStr, "cannot read") {
-			byType["Read Error"] = append(byType["Read Error"], err)
-		} else {
-			byType["Other"] = append(byType["Other"], err)
-		}
+		errType := errorType(err)
+		byType[errType] = append(byType[errType], err)
 	}
 
 	return byType
 }
+
+// errorType classifies an error as "Permission Denied", "Not Found", "Read Error" or "Other"
+func errorType(err error) string {
+	errStr := strings.ToLower(err.Error())
+
+	if strings.Contains(errStr, "permission denied") {
+		return "Permission Denied"
+	} else if strings.Contains(errStr, "not found") || strings.Contains(errStr, "no such") {
+		return "Not Found"
+	} else if strings.Contains(errStr, "cannot read") {
+		return "Read Error"
+	}
+	return "Other"
+}
+
+// errorTypeOrder is the order GetErrorsByType groups are listed in
+var errorTypeOrder = []string{"Permission Denied", "Not Found", "Read Error", "Other"}
+
+// WriteErrors writes every error, one per line, under a header for each error type
+func (ev *ErrorsView) WriteErrors(w io.Writer) error {
+	buf := bufio.NewWriter(w)
+	fmt.Fprintf(buf, "SpaceForce scan errors (%d)\n", len(ev.errors))
+
+	byType := ev.GetErrorsByType()
+	for _, errType := range errorTypeOrder {
+		errs := byType[errType]
+		if len(errs) == 0 {
+			continue
+		}
+		fmt.Fprintf(buf, "\n== %s (%d) ==\n", errType, len(errs))
+		for _, err := range errs {
+			fmt.Fprintln(buf, err.Error())
+		}
+	}
+	return buf.Flush()
+}
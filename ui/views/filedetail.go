@@ -0,0 +1,85 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	"spaceforce/safety"
+	"spaceforce/scanner"
+	"spaceforce/util"
+)
+
+// fileDetail describes a scan root that is a single file rather than a directory
+// The safety check and content sniffing read the disk, so they are done once
+type fileDetail struct {
+	risk     int
+	reason   string // Why the file is not safe to delete ("" if it is)
+	detected string // Format from the file's magic bytes ("" if unknown)
+}
+
+// newFileDetail checks node's safety and sniffs its contents
+func newFileDetail(node *scanner.FileNode) *fileDetail {
+	protector := safety.NewProtector()
+	detail := &fileDetail{risk: protector.GetRiskLevel(node.Path)}
+	if safe, reason := protector.IsSafeToDelete(node.Path); !safe {
+		detail.reason = reason
+	}
+	detail.detected = scanner.DetectType(node.Path)
+	return detail
+}
+
+// renderFileDetail shows the size, type, age and safety of a file scan root
+func (tv *TreeView) renderFileDetail() string {
+	node := tv.root
+	if tv.file == nil {
+		tv.file = newFileDetail(node)
+	}
+
+	var b strings.Builder
+	b.WriteString(util.TitleStyle.Render("📄 " + node.Name))
+	b.WriteString("\n\n")
+
+	row := func(label, value string) {
+		b.WriteString(util.HelpStyle.UnsetMarginTop().Render(fmt.Sprintf("%-10s", label)))
+		b.WriteString(value)
+		b.WriteString("\n")
+	}
+
+	row("Path", node.Path)
+	if tv.linkPath != "" {
+		row("Symlink", tv.linkPath+" → "+node.Path)
+	}
+
+	size := util.FormatBytes(node.FileSize())
+	if node.AllocatedSize != node.Size {
+		size += fmt.Sprintf(" (apparent %s, allocated %s)",
+			util.FormatBytesPlain(node.Size), util.FormatBytesPlain(node.AllocatedSize))
+	}
+	row("Size", size)
+
+	fileType := node.FileType
+	if tv.file.detected != "" {
+		fileType += " [" + tv.file.detected + "]"
+	}
+	row("Type", fileType)
+
+	row("Modified", fmt.Sprintf("%s (%s)",
+		node.ModTime.Format("2006-01-02 15:04"), util.FormatRelativeTime(node.ModTime)))
+
+	safetyLine := util.FormatSafetyLevel(tv.file.risk)
+	if tv.file.reason != "" {
+		safetyLine += " - " + tv.file.reason
+	}
+	row("Safety", safetyLine)
+
+	marked := "no"
+	if tv.markedFiles.IsMarked(node.Path) {
+		marked = "yes"
+	}
+	row("Marked", marked)
+
+	b.WriteString("\n")
+	b.WriteString(util.HelpStyle.UnsetMarginTop().Render("m: mark for deletion • x: delete marked • O: open"))
+
+	return b.String()
+}
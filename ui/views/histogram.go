@@ -0,0 +1,159 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"spaceforce/analyzer"
+	"spaceforce/scanner"
+	"spaceforce/util"
+)
+
+// HistogramView displays files binned by size range
+type HistogramView struct {
+	buckets       []*analyzer.SizeBucket
+	selectedIndex int
+	height        int
+	totalSize     int64
+	totalCount    int64
+}
+
+// NewHistogramView creates a new size histogram view of nodes (the flattened tree)
+func NewHistogramView(nodes []*scanner.FileNode) *HistogramView {
+	hv := &HistogramView{
+		buckets: analyzer.BuildSizeHistogram(nodes),
+		height:  20,
+	}
+	for _, bucket := range hv.buckets {
+		hv.totalSize += bucket.TotalSize
+		hv.totalCount += bucket.Count
+	}
+	return hv
+}
+
+// Init initializes the view
+func (hv *HistogramView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles updates
+func (hv *HistogramView) Update(msg tea.Msg) (*HistogramView, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if hv.selectedIndex > 0 {
+				hv.selectedIndex--
+			}
+		case "down", "j":
+			if hv.selectedIndex < len(hv.buckets)-1 {
+				hv.selectedIndex++
+			}
+		case "enter", "return":
+			// Jump to tree view with the largest file in the bucket
+			if node := hv.GetSelectedNode(); node != nil {
+				return hv, func() tea.Msg {
+					return "JUMP_TO_TREE:" + node.Path
+				}
+			}
+		}
+	}
+	return hv, nil
+}
+
+// View renders the view
+func (hv *HistogramView) View() string {
+	var b strings.Builder
+
+	b.WriteString(util.TitleStyle.Render("📏 Size Distribution"))
+	b.WriteString("\n")
+	b.WriteString(util.SubtitleStyle.Render(fmt.Sprintf("%d files grouped by size (bar = share of total bytes)",
+		hv.totalCount)))
+	b.WriteString("\n\n")
+
+	// Header
+	header := fmt.Sprintf("%-20s %12s %10s %8s %8s %s",
+		"Size Range", "Total Size", "Files", "Files %", "Size %", "Bar")
+	b.WriteString(util.HelpStyle.Render(header))
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat("─", 90))
+	b.WriteString("\n")
+
+	// Render buckets
+	for i, bucket := range hv.buckets {
+		line := hv.renderBucket(bucket, i == hv.selectedIndex)
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString(util.HelpStyle.Render("A few giant files are quick wins; many medium files point to a cluttered folder"))
+
+	return b.String()
+}
+
+// renderBucket renders a size bucket
+func (hv *HistogramView) renderBucket(bucket *analyzer.SizeBucket, selected bool) string {
+	// Calculate percentages
+	sizePercentage := float64(0)
+	if hv.totalSize > 0 {
+		sizePercentage = float64(bucket.TotalSize) / float64(hv.totalSize) * 100
+	}
+	countPercentage := float64(0)
+	if hv.totalCount > 0 {
+		countPercentage = float64(bucket.Count) / float64(hv.totalCount) * 100
+	}
+
+	// Create progress bar
+	barWidth := 20
+	filledWidth := int(sizePercentage / 100 * float64(barWidth))
+	if filledWidth > barWidth {
+		filledWidth = barWidth
+	}
+	bar := strings.Repeat("█", filledWidth) + strings.Repeat("░", barWidth-filledWidth)
+
+	// Build line
+	line := fmt.Sprintf("%-20s %12s %10d %7.1f%% %7.1f%% %s",
+		bucket.Label,
+		util.FormatBytes(bucket.TotalSize),
+		bucket.Count,
+		countPercentage,
+		sizePercentage,
+		bar)
+
+	if selected {
+		return util.SelectedItemStyle.Render(line)
+	}
+	return util.NormalItemStyle.Render(line)
+}
+
+// RemoveNodes takes deleted files out of the size buckets without walking the tree again
+func (hv *HistogramView) RemoveNodes(r *Removal) {
+	analyzer.RemoveFromHistogram(hv.buckets, r.files)
+	for _, file := range r.files {
+		hv.totalSize -= file.FileSize()
+		hv.totalCount--
+	}
+}
+
+// SetHeight sets the viewport height
+func (hv *HistogramView) SetHeight(height int) {
+	hv.height = height
+}
+
+// GetSelectedBucket returns the currently selected bucket
+func (hv *HistogramView) GetSelectedBucket() *analyzer.SizeBucket {
+	if hv.selectedIndex < len(hv.buckets) {
+		return hv.buckets[hv.selectedIndex]
+	}
+	return nil
+}
+
+// GetSelectedNode returns the largest file of the selected bucket
+func (hv *HistogramView) GetSelectedNode() *scanner.FileNode {
+	if bucket := hv.GetSelectedBucket(); bucket != nil {
+		return bucket.Largest
+	}
+	return nil
+}
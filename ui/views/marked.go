@@ -0,0 +1,232 @@
+package views
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"spaceforce/safety"
+	"spaceforce/scanner"
+)
+
+// MarkedSet is the set of nodes marked for deletion, keyed by path
+// It is shared by the app model and the views that show mark indicators.
+// All access goes through the mutex, so commands running outside the Bubble Tea
+// Update goroutine (deletion, export, bulk marking) can use it without races.
+type MarkedSet struct {
+	mu    sync.RWMutex
+	nodes map[string]*scanner.FileNode // Path -> Node
+}
+
+// NewMarkedSet creates an empty marked set
+func NewMarkedSet() *MarkedSet {
+	return &MarkedSet{
+		nodes: make(map[string]*scanner.FileNode),
+	}
+}
+
+// Toggle marks node if it is unmarked (and vice versa); returns true if it is now marked
+func (ms *MarkedSet) Toggle(node *scanner.FileNode) bool {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if _, exists := ms.nodes[node.Path]; exists {
+		delete(ms.nodes, node.Path)
+		return false
+	}
+	ms.nodes[node.Path] = node
+	return true
+}
+
+// Add marks a node
+func (ms *MarkedSet) Add(node *scanner.FileNode) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.nodes[node.Path] = node
+}
+
+// ToggleSubtree marks every file beneath dir, or unmarks them all if they are already marked
+// The directory itself is left alone. Returns how many files changed state and whether they are now marked
+func (ms *MarkedSet) ToggleSubtree(dir *scanner.FileNode) (int, bool) {
+	files := descendantFiles(dir)
+
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	allMarked := true
+	for _, file := range files {
+		if _, exists := ms.nodes[file.Path]; !exists {
+			allMarked = false
+			break
+		}
+	}
+
+	if allMarked {
+		for _, file := range files {
+			delete(ms.nodes, file.Path)
+		}
+		return len(files), false
+	}
+
+	added := 0
+	for _, file := range files {
+		if _, exists := ms.nodes[file.Path]; !exists {
+			ms.nodes[file.Path] = file
+			added++
+		}
+	}
+	return added, true
+}
+
+// descendantFiles returns every file (not directory) beneath node
+func descendantFiles(node *scanner.FileNode) []*scanner.FileNode {
+	files := make([]*scanner.FileNode, 0)
+	var walk func(n *scanner.FileNode)
+	walk = func(n *scanner.FileNode) {
+		for _, child := range n.Children {
+			if child.IsDir {
+				walk(child)
+			} else {
+				files = append(files, child)
+			}
+		}
+	}
+	walk(node)
+	return files
+}
+
+// IsMarked reports whether path is marked
+func (ms *MarkedSet) IsMarked(path string) bool {
+	if ms == nil {
+		return false
+	}
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	_, exists := ms.nodes[path]
+	return exists
+}
+
+// Len returns the number of marked nodes
+func (ms *MarkedSet) Len() int {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	return len(ms.nodes)
+}
+
+// Paths returns the marked paths, sorted
+func (ms *MarkedSet) Paths() []string {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	paths := make([]string, 0, len(ms.nodes))
+	for path := range ms.nodes {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// Snapshot returns a copy of the marked nodes that is safe to iterate without the lock
+func (ms *MarkedSet) Snapshot() map[string]*scanner.FileNode {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+
+	snapshot := make(map[string]*scanner.FileNode, len(ms.nodes))
+	for path, node := range ms.nodes {
+		snapshot[path] = node
+	}
+	return snapshot
+}
+
+// RemovePaths unmarks the given paths (e.g. after they were deleted)
+func (ms *MarkedSet) RemovePaths(paths []string) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	for _, path := range paths {
+		delete(ms.nodes, path)
+	}
+}
+
+// Clear unmarks everything
+func (ms *MarkedSet) Clear() {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+	ms.nodes = make(map[string]*scanner.FileNode)
+}
+
+// PatternMatch is the result of matching a marking pattern against the tree
+type PatternMatch struct {
+	Safe      []*scanner.FileNode // Matches that may be marked for deletion
+	Protected []*scanner.FileNode // Matches the protector refuses to delete
+}
+
+// MatchPattern finds the nodes matching pattern and splits them by safety
+// Globs use the -exclude syntax (*.log, */DerivedData/*, **/node_modules); anything
+// else matches as a substring of the path. When a directory matches, the nodes
+// inside it are not listed separately, since deleting the directory covers them
+func MatchPattern(nodes []*scanner.FileNode, pattern string, protector *safety.Protector) PatternMatch {
+	result := PatternMatch{
+		Safe:      make([]*scanner.FileNode, 0),
+		Protected: make([]*scanner.FileNode, 0),
+	}
+	if pattern == "" {
+		return result
+	}
+
+	isGlob := strings.ContainsAny(pattern, "*?[")
+	matches := make([]*scanner.FileNode, 0)
+	for _, node := range nodes {
+		if isGlob && scanner.MatchPathPattern(node.Path, pattern) ||
+			!isGlob && strings.Contains(node.Path, pattern) {
+			matches = append(matches, node)
+		}
+	}
+
+	return SplitBySafety(matches, protector)
+}
+
+// SplitBySafety splits nodes into those that may be deleted and protected ones
+// Nodes inside a safe directory that is also listed are dropped, since deleting
+// the directory covers them. Both lists are sorted by path
+func SplitBySafety(nodes []*scanner.FileNode, protector *safety.Protector) PatternMatch {
+	result := PatternMatch{
+		Safe:      make([]*scanner.FileNode, 0),
+		Protected: make([]*scanner.FileNode, 0),
+	}
+
+	// Sorting by path puts each directory right before its contents
+	sorted := make([]*scanner.FileNode, len(nodes))
+	copy(sorted, nodes)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Path < sorted[j].Path
+	})
+
+	safeDirs := make(map[string]bool)
+	for _, node := range sorted {
+		if insideAny(node.Path, safeDirs) {
+			continue
+		}
+		if safe, _ := protector.IsSafeToDelete(node.Path); safe {
+			result.Safe = append(result.Safe, node)
+			if node.IsDir {
+				safeDirs[node.Path] = true
+			}
+		} else {
+			result.Protected = append(result.Protected, node)
+		}
+	}
+	return result
+}
+
+// insideAny reports whether path is beneath one of dirs
+func insideAny(path string, dirs map[string]bool) bool {
+	for dir := filepath.Dir(path); ; dir = filepath.Dir(dir) {
+		if dirs[dir] {
+			return true
+		}
+		if dir == filepath.Dir(dir) {
+			return false
+		}
+	}
+}
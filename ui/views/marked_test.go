@@ -0,0 +1,181 @@
+package views
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"spaceforce/safety"
+	"spaceforce/scanner"
+)
+
+// TestMarkedSetConcurrentUse marks, reads and renders from several goroutines at once
+// Run with -race: the set must be safe to share with commands outside the Update goroutine
+func TestMarkedSetConcurrentUse(t *testing.T) {
+	root := testDir(nil, "root")
+	dirs := make([]*scanner.FileNode, 4)
+	files := make([]*scanner.FileNode, 0)
+	for d := range dirs {
+		dirs[d] = testDir(root, fmt.Sprintf("dir%d", d))
+		for f := 0; f < 25; f++ {
+			files = append(files, testFile(dirs[d], fmt.Sprintf("file%d", f), int64(f+1)*1024))
+		}
+	}
+
+	marked := NewMarkedSet()
+	tree := NewTreeView(root)
+	tree.SetHeight(40)
+	tree.SetMarkedFiles(marked)
+
+	var wg sync.WaitGroup
+	run := func(work func(i int)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 200; i++ {
+				work(i)
+			}
+		}()
+	}
+
+	run(func(i int) { marked.Toggle(files[i%len(files)]) })
+	run(func(i int) { marked.Add(files[(i*7)%len(files)]) })
+	run(func(i int) { marked.ToggleSubtree(dirs[i%len(dirs)]) })
+	run(func(i int) {
+		for path, node := range marked.Snapshot() {
+			if node.Path != path {
+				t.Errorf("snapshot maps %s to %s", path, node.Path)
+			}
+		}
+	})
+	run(func(i int) {
+		marked.IsMarked(files[i%len(files)].Path)
+		marked.Len()
+		marked.Paths()
+	})
+	run(func(i int) {
+		if i%50 == 0 {
+			marked.RemovePaths([]string{files[i%len(files)].Path})
+		}
+	})
+	run(func(int) { _ = tree.View() })
+	wg.Wait()
+
+	// Every marked path still refers to a node in the tree
+	for _, path := range marked.Paths() {
+		if !marked.IsMarked(path) {
+			t.Errorf("%s is listed but not marked", path)
+		}
+	}
+	if marked.Len() != len(marked.Snapshot()) {
+		t.Errorf("Len() = %d, snapshot has %d", marked.Len(), len(marked.Snapshot()))
+	}
+}
+
+// diskTree creates rel paths (directories end in /) under a temporary home and returns their nodes
+// The protector checks real files, so the nodes need something on disk
+func diskTree(t *testing.T, rels ...string) (string, map[string]*scanner.FileNode, []*scanner.FileNode) {
+	t.Helper()
+	home, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("HOME", home)
+
+	byRel := map[string]*scanner.FileNode{"": scanner.NewFileNode(home, 0, true, time.Now())}
+	nodes := make([]*scanner.FileNode, 0, len(rels))
+	for _, rel := range rels {
+		isDir := strings.HasSuffix(rel, "/")
+		rel = strings.TrimSuffix(rel, "/")
+		path := filepath.Join(home, rel)
+		if isDir {
+			err = os.MkdirAll(path, 0o755)
+		} else {
+			err = os.WriteFile(path, []byte("x"), 0o644)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		node := scanner.NewFileNode(path, 1, isDir, time.Now())
+		parent := filepath.Dir(rel)
+		if parent == "." {
+			parent = ""
+		}
+		byRel[parent].AddChild(node)
+		byRel[rel] = node
+		nodes = append(nodes, node)
+	}
+	return home, byRel, nodes
+}
+
+func relPaths(home string, nodes []*scanner.FileNode) []string {
+	paths := make([]string, len(nodes))
+	for i, node := range nodes {
+		paths[i] = strings.TrimPrefix(node.Path, home+"/")
+	}
+	return paths
+}
+
+func TestMatchPatternDoubleStar(t *testing.T) {
+	home, _, nodes := diskTree(t,
+		"app/",
+		"app/node_modules/",
+		"app/node_modules/left-pad/",
+		"app/node_modules/left-pad/index.js",
+		"app/web/",
+		"app/web/node_modules/",
+		"app/web/node_modules/react.js",
+		"app/vendor/",
+		"app/vendor/node_modules/",
+		"app/vendor/node_modules/pinned.js",
+		"app/node_modules.txt",
+	)
+	protector := safety.NewProtector()
+	protector.AddProtectedPath("~/app/vendor")
+
+	match := MatchPattern(nodes, "**/node_modules/**", protector)
+
+	wantSafe := []string{"app/node_modules", "app/web/node_modules"}
+	wantProtected := []string{"app/vendor/node_modules", "app/vendor/node_modules/pinned.js"}
+	if got := relPaths(home, match.Safe); strings.Join(got, ",") != strings.Join(wantSafe, ",") {
+		t.Errorf("safe = %v, want %v", got, wantSafe)
+	}
+	if got := relPaths(home, match.Protected); strings.Join(got, ",") != strings.Join(wantProtected, ",") {
+		t.Errorf("protected = %v, want %v", got, wantProtected)
+	}
+}
+
+func TestSplitBySafetyDropsNestedDuplicates(t *testing.T) {
+	home, byRel, _ := diskTree(t,
+		"cache/",
+		"cache/a.bin",
+		"cache/sub/",
+		"cache/sub/b.bin",
+		"cachefile.bin",
+		"keep/",
+		"keep/c.bin",
+	)
+	protector := safety.NewProtector()
+	protector.AddProtectedPath("~/keep")
+
+	// Given in an order where contents come before their directory
+	nodes := []*scanner.FileNode{
+		byRel["cache/sub/b.bin"], byRel["keep/c.bin"], byRel["cachefile.bin"],
+		byRel["cache/a.bin"], byRel["cache"], byRel["keep"],
+	}
+	match := SplitBySafety(nodes, protector)
+
+	// cachefile.bin shares a prefix with cache but is not inside it
+	wantSafe := []string{"cache", "cachefile.bin"}
+	wantProtected := []string{"keep", "keep/c.bin"}
+	if got := relPaths(home, match.Safe); strings.Join(got, ",") != strings.Join(wantSafe, ",") {
+		t.Errorf("safe = %v, want %v", got, wantSafe)
+	}
+	if got := relPaths(home, match.Protected); strings.Join(got, ",") != strings.Join(wantProtected, ",") {
+		t.Errorf("protected = %v, want %v", got, wantProtected)
+	}
+}
@@ -0,0 +1,28 @@
+package views
+
+// navigateList applies the page and jump keys shared by the list views
+// Returns the new selection, clamped to [0, count-1]
+func navigateList(key string, selected int, count int, page int) int {
+	if page < 1 {
+		page = 1
+	}
+
+	switch key {
+	case "pgup":
+		selected -= page
+	case "pgdown":
+		selected += page
+	case "home":
+		selected = 0
+	case "end":
+		selected = count - 1
+	}
+
+	if selected > count-1 {
+		selected = count - 1
+	}
+	if selected < 0 {
+		selected = 0
+	}
+	return selected
+}
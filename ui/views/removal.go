@@ -0,0 +1,92 @@
+package views
+
+import (
+	"spaceforce/scanner"
+)
+
+// Removal describes subtrees detached from the scanned tree by a deletion
+// Views use it to update their cached lists and totals in place instead of
+// being rebuilt from the whole tree
+type Removal struct {
+	nodes     map[*scanner.FileNode]bool  // Every node inside the removed subtrees
+	lost      map[*scanner.FileNode]int64 // Bytes each ancestor of a removed subtree lost
+	lostFiles map[*scanner.FileNode]int64 // Files each ancestor of a removed subtree lost
+	files     []*scanner.FileNode         // Removed files (not directories)
+	dirs      []*scanner.FileNode         // Removed directories
+	bytes     int64                       // Total removed, in the current size mode
+	allocated int64                       // Total removed on disk
+}
+
+// NewRemoval collects the removed subtrees, which must already be detached
+// from their parents' Children (their Parent links are used to find ancestors)
+func NewRemoval(roots []*scanner.FileNode) *Removal {
+	r := &Removal{
+		nodes:     make(map[*scanner.FileNode]bool),
+		lost:      make(map[*scanner.FileNode]int64),
+		lostFiles: make(map[*scanner.FileNode]int64),
+	}
+
+	for _, root := range roots {
+		size := root.TotalSize()
+		count := root.FileCount()
+		r.bytes += size
+		r.allocated += root.TotalAllocatedSize()
+		for ancestor := root.Parent; ancestor != nil; ancestor = ancestor.Parent {
+			r.lost[ancestor] += size
+			r.lostFiles[ancestor] += count
+		}
+		r.collect(root)
+	}
+	return r
+}
+
+// collect records node and everything below it
+func (r *Removal) collect(node *scanner.FileNode) {
+	r.nodes[node] = true
+	if !node.IsDir {
+		r.files = append(r.files, node)
+		return
+	}
+	r.dirs = append(r.dirs, node)
+	for _, child := range node.Children {
+		r.collect(child)
+	}
+}
+
+// Contains reports whether node was removed
+func (r *Removal) Contains(node *scanner.FileNode) bool {
+	return r.nodes[node]
+}
+
+// Bytes returns the total size removed
+func (r *Removal) Bytes() int64 {
+	return r.bytes
+}
+
+// bytesFrom returns how many of node's bytes were removed: all of them if node
+// itself was removed, otherwise whatever was removed below it
+func (r *Removal) bytesFrom(node *scanner.FileNode) int64 {
+	if r.nodes[node] {
+		return node.TotalSize() + r.lost[node]
+	}
+	return r.lost[node]
+}
+
+// Without returns the nodes that were not removed, in the same order, as a new slice
+func (r *Removal) Without(nodes []*scanner.FileNode) []*scanner.FileNode {
+	kept := make([]*scanner.FileNode, 0, len(nodes))
+	for _, node := range nodes {
+		if !r.nodes[node] {
+			kept = append(kept, node)
+		}
+	}
+	return kept
+}
+
+// survivingAncestor returns node, or its closest ancestor that was not removed
+func (r *Removal) survivingAncestor(node *scanner.FileNode) *scanner.FileNode {
+	for node != nil && r.nodes[node] {
+		node = node.Parent
+	}
+	return node
+}
@@ -0,0 +1,310 @@
+package views
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"spaceforce/analyzer"
+	"spaceforce/safety"
+	"spaceforce/scanner"
+	"spaceforce/util"
+)
+
+// oldFilesCategory is the suggestion category re-derived when the age cutoff changes
+const oldFilesCategory = "Old Files"
+
+// SuggestionsView displays cleanup suggestions from the analyzer
+type SuggestionsView struct {
+	engine        *analyzer.SuggestionEngine
+	suggestions   []*analyzer.Suggestion
+	selectedIndex int
+	height        int
+	snapshots     []safety.LocalSnapshot // Time Machine local snapshots of the startup volume
+	hashing       bool                   // Duplicate files are still being hashed
+	reclaimable   int64                  // Safely reclaimable estimate, updated with the suggestions
+}
+
+// NewSuggestionsView creates a new suggestions view for root and its flattened nodes
+func NewSuggestionsView(root *scanner.FileNode, nodes []*scanner.FileNode) *SuggestionsView {
+	engine := analyzer.NewSuggestionEngine(root, nodes)
+	sv := &SuggestionsView{
+		engine:      engine,
+		suggestions: engine.GenerateSuggestions(),
+		height:      20,
+	}
+	sv.reclaimable = engine.SafelyReclaimable(sv.suggestions)
+	return sv
+}
+
+// Init initializes the view
+func (sv *SuggestionsView) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles updates
+func (sv *SuggestionsView) Update(msg tea.Msg) (*SuggestionsView, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "up", "k":
+			if sv.selectedIndex > 0 {
+				sv.selectedIndex--
+			}
+		case "down", "j":
+			if sv.selectedIndex < len(sv.suggestions)-1 {
+				sv.selectedIndex++
+			}
+		case "+", "=":
+			// Older cutoff - fewer files match
+			sv.SetOldFileMonths(sv.engine.OldFileMonths() + 1)
+		case "-", "_":
+			// Newer cutoff - more files match
+			sv.SetOldFileMonths(sv.engine.OldFileMonths() - 1)
+		case "enter", "return":
+			// Jump to tree view with the first file of the suggestion
+			if node := sv.GetSelectedNode(); node != nil {
+				return sv, func() tea.Msg {
+					return "JUMP_TO_TREE:" + node.Path
+				}
+			}
+		}
+	}
+	return sv, nil
+}
+
+// SetOldFileMonths changes the old-files age cutoff and re-derives that suggestion
+func (sv *SuggestionsView) SetOldFileMonths(months int) {
+	sv.engine.SetOldFileMonths(months)
+
+	// Replace the existing old-files suggestion (if any) with the recomputed one
+	updated := make([]*analyzer.Suggestion, 0, len(sv.suggestions)+1)
+	for _, suggestion := range sv.suggestions {
+		if suggestion.Category != oldFilesCategory {
+			updated = append(updated, suggestion)
+		}
+	}
+	if oldFiles := sv.engine.OldFilesSuggestion(); oldFiles != nil {
+		updated = append(updated, oldFiles)
+	}
+
+	sv.replaceSuggestions(updated)
+}
+
+// SetDuplicates replaces the duplicates suggestion with one built from groups
+// pending reports that hashing is still running
+func (sv *SuggestionsView) SetDuplicates(groups []*analyzer.DuplicateGroup, pending bool) {
+	sv.hashing = pending
+
+	updated := make([]*analyzer.Suggestion, 0, len(sv.suggestions)+1)
+	for _, suggestion := range sv.suggestions {
+		if suggestion.Category != analyzer.DuplicatesCategory {
+			updated = append(updated, suggestion)
+		}
+	}
+	if duplicates := analyzer.DuplicatesSuggestion(groups); duplicates != nil {
+		updated = append(updated, duplicates)
+	}
+	sv.replaceSuggestions(updated)
+}
+
+// RemoveNodes drops deleted files from the suggestions and reduces their savings
+// Suggestions left with nothing to reclaim are removed
+func (sv *SuggestionsView) RemoveNodes(r *Removal) {
+	sv.engine.SetNodes(r.Without(sv.engine.Nodes())) // Re-derived suggestions must not list them
+	updated := make([]*analyzer.Suggestion, 0, len(sv.suggestions))
+	for _, suggestion := range sv.suggestions {
+		adjusted := *suggestion
+		for _, file := range suggestion.Files {
+			adjusted.Savings -= r.bytesFrom(file)
+		}
+		adjusted.Files = r.Without(suggestion.Files)
+		if len(adjusted.Files) > 0 && adjusted.Savings > 0 {
+			updated = append(updated, &adjusted)
+		}
+	}
+	sv.replaceSuggestions(updated)
+}
+
+// replaceSuggestions installs a new suggestion list, largest savings first
+func (sv *SuggestionsView) replaceSuggestions(updated []*analyzer.Suggestion) {
+	sort.Slice(updated, func(i, j int) bool {
+		return updated[i].Savings > updated[j].Savings
+	})
+	sv.suggestions = updated
+	sv.reclaimable = sv.engine.SafelyReclaimable(updated)
+
+	if sv.selectedIndex >= len(sv.suggestions) {
+		sv.selectedIndex = len(sv.suggestions) - 1
+	}
+	if sv.selectedIndex < 0 {
+		sv.selectedIndex = 0
+	}
+}
+
+// SetSnapshots sets the Time Machine local snapshots shown above the suggestions
+func (sv *SuggestionsView) SetSnapshots(snapshots []safety.LocalSnapshot) {
+	sv.snapshots = snapshots
+}
+
+// SafeCleanupItems returns the items the safe cleanup would move to the Trash
+// (see analyzer.SuggestionEngine.SafeCleanupItems)
+func (sv *SuggestionsView) SafeCleanupItems() []*scanner.FileNode {
+	return sv.engine.SafeCleanupItems(sv.suggestions)
+}
+
+// Reclaimable returns the bytes of no-risk caches, logs and known bloat among the suggestions
+func (sv *SuggestionsView) Reclaimable() int64 {
+	return sv.reclaimable
+}
+
+// OldFileMonths returns the current old-files age cutoff
+func (sv *SuggestionsView) OldFileMonths() int {
+	return sv.engine.OldFileMonths()
+}
+
+// View renders the view
+func (sv *SuggestionsView) View() string {
+	var b strings.Builder
+
+	totalSavings := int64(0)
+	for _, suggestion := range sv.suggestions {
+		totalSavings += suggestion.Savings
+	}
+
+	b.WriteString(util.TitleStyle.Render("💡 Cleanup Suggestions"))
+	b.WriteString("\n")
+	subtitle := fmt.Sprintf("Potential savings: %s | Old files: older than %d months (+/- to adjust)",
+		util.FormatBytesPlain(totalSavings), sv.engine.OldFileMonths())
+	if sv.hashing {
+		subtitle += " | Looking for duplicates..."
+	}
+	b.WriteString(util.SubtitleStyle.Render(subtitle))
+	b.WriteString("\n\n")
+
+	// Snapshots panel: space the scan cannot see
+	panelLines := 0
+	if len(sv.snapshots) > 0 {
+		b.WriteString(util.RiskyStyle.Render(sv.snapshotSummary()))
+		b.WriteString("\n\n")
+		panelLines = 2
+	}
+
+	if len(sv.suggestions) == 0 {
+		b.WriteString(util.HelpStyle.Render("No cleanup suggestions for this scan."))
+		return b.String()
+	}
+
+	// Header
+	header := fmt.Sprintf("%-20s %-45s %12s %12s",
+		"Category", "Description", "Savings", "Risk")
+	b.WriteString(util.HelpStyle.Render(header))
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat("─", 90))
+	b.WriteString("\n")
+
+	// Reserve lines for title (2), subtitle (3), header (2), separator (2), footer (2)
+	// Total chrome: 9 lines + 2 for optional footer = 11 lines worst case
+	contentHeight := sv.height - 11 - panelLines
+	if contentHeight < 1 {
+		contentHeight = 1
+	}
+
+	// Calculate viewport
+	start := sv.selectedIndex - contentHeight/2
+	if start < 0 {
+		start = 0
+	}
+	end := start + contentHeight
+	if end > len(sv.suggestions) {
+		end = len(sv.suggestions)
+		start = end - contentHeight
+		if start < 0 {
+			start = 0
+		}
+	}
+
+	// Render items
+	for i := start; i < end && i < len(sv.suggestions); i++ {
+		line := sv.renderSuggestion(sv.suggestions[i], i == sv.selectedIndex)
+		b.WriteString(line)
+		b.WriteString("\n")
+	}
+
+	// Footer
+	if len(sv.suggestions) > contentHeight {
+		b.WriteString("\n")
+		b.WriteString(util.HelpStyle.Render(fmt.Sprintf("Showing %d-%d of %d suggestions",
+			start+1, end, len(sv.suggestions))))
+	}
+
+	return b.String()
+}
+
+// snapshotSummary describes the local snapshots in one line
+// tmutil doesn't report snapshot sizes, so only the count and date range are known
+func (sv *SuggestionsView) snapshotSummary() string {
+	oldest, newest := sv.snapshots[0].Time, sv.snapshots[0].Time
+	for _, snapshot := range sv.snapshots[1:] {
+		if snapshot.Time.Before(oldest) {
+			oldest = snapshot.Time
+		}
+		if snapshot.Time.After(newest) {
+			newest = snapshot.Time
+		}
+	}
+
+	dates := ""
+	if !oldest.IsZero() {
+		dates = fmt.Sprintf(" from %s to %s", oldest.Format("Jan 2 15:04"), newest.Format("Jan 2 15:04"))
+	}
+	return fmt.Sprintf("🕒 %d Time Machine local snapshot(s)%s hold space this scan can't see • T: delete them",
+		len(sv.snapshots), dates)
+}
+
+// renderSuggestion renders a single suggestion
+func (sv *SuggestionsView) renderSuggestion(suggestion *analyzer.Suggestion, selected bool) string {
+	category := suggestion.Category
+	if len(category) > 20 {
+		category = category[:17] + "..."
+	}
+
+	description := fmt.Sprintf("%s (%d items)", suggestion.Description, len(suggestion.Files))
+	if len(description) > 45 {
+		description = description[:42] + "..."
+	}
+
+	line := fmt.Sprintf("%-20s %-45s %12s %12s",
+		category,
+		description,
+		util.FormatBytes(suggestion.Savings),
+		util.FormatSafetyLevel(suggestion.RiskLevel))
+
+	if selected {
+		return util.SelectedItemStyle.Render(line)
+	}
+	return util.NormalItemStyle.Render(line)
+}
+
+// SetHeight sets the viewport height
+func (sv *SuggestionsView) SetHeight(height int) {
+	sv.height = height
+}
+
+// GetSelectedSuggestion returns the currently selected suggestion
+func (sv *SuggestionsView) GetSelectedSuggestion() *analyzer.Suggestion {
+	if sv.selectedIndex < len(sv.suggestions) {
+		return sv.suggestions[sv.selectedIndex]
+	}
+	return nil
+}
+
+// GetSelectedNode returns the first file of the selected suggestion
+func (sv *SuggestionsView) GetSelectedNode() *scanner.FileNode {
+	suggestion := sv.GetSelectedSuggestion()
+	if suggestion == nil || len(suggestion.Files) == 0 {
+		return nil
+	}
+	return suggestion.Files[0]
+}
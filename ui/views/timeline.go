@@ -28,12 +28,67 @@ type TimeBucket struct {
 	FileCount int64
 }
 
-// NewTimelineView creates a new timeline view
-func NewTimelineView(root *scanner.FileNode) *TimelineView {
+// TimelineCutoff is a custom bucket boundary: files modified within Age fall before it
+type TimelineCutoff struct {
+	Label string // As written by the user, e.g. "30d"
+	Age   time.Duration
+}
+
+// ParseTimelineCutoffs parses a comma-separated list of ages like "7d,30d,180d,2y"
+// Ages must be strictly increasing
+func ParseTimelineCutoffs(spec string) ([]TimelineCutoff, error) {
+	cutoffs := make([]TimelineCutoff, 0)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		age, err := util.ParseAge(part)
+		if err != nil {
+			return nil, err
+		}
+		if len(cutoffs) > 0 && age <= cutoffs[len(cutoffs)-1].Age {
+			return nil, fmt.Errorf("cutoffs must increase: %s is not after %s", part, cutoffs[len(cutoffs)-1].Label)
+		}
+		cutoffs = append(cutoffs, TimelineCutoff{Label: part, Age: age})
+	}
+	return cutoffs, nil
+}
+
+// customBuckets builds one bucket per cutoff plus an "older than" catch-all
+func customBuckets(now time.Time, cutoffs []TimelineCutoff) []*TimeBucket {
+	buckets := make([]*TimeBucket, 0, len(cutoffs)+1)
+
+	end := now
+	for i, cutoff := range cutoffs {
+		name := "Last " + cutoff.Label
+		if i > 0 {
+			name = cutoffs[i-1].Label + " - " + cutoff.Label + " ago"
+		}
+		start := now.Add(-cutoff.Age)
+		buckets = append(buckets, &TimeBucket{
+			Name:      name,
+			StartDate: start,
+			EndDate:   end,
+			Files:     make([]*scanner.FileNode, 0),
+		})
+		end = start
+	}
+
+	buckets = append(buckets, &TimeBucket{
+		Name:      "Older than " + cutoffs[len(cutoffs)-1].Label,
+		StartDate: time.Time{}, // Beginning of time
+		EndDate:   end,
+		Files:     make([]*scanner.FileNode, 0),
+	})
+
+	return buckets
+}
+
+// NewTimelineView creates a new timeline view of nodes (the flattened tree), bucketed
+// by cutoffs (nil = built-in buckets)
+func NewTimelineView(nodes []*scanner.FileNode, cutoffs []TimelineCutoff) *TimelineView {
 	tv := &TimelineView{
 		height: 20,
 	}
-	tv.buildBuckets(root)
+	tv.buildBuckets(nodes, cutoffs)
 	return tv
 }
 
@@ -55,6 +110,25 @@ func (tv *TimelineView) Update(msg tea.Msg) (*TimelineView, tea.Cmd) {
 			if tv.selectedIndex < len(tv.buckets)-1 {
 				tv.selectedIndex++
 			}
+		case "pgup", "pgdown", "home", "end":
+			// All buckets are always visible, so a page is the whole list
+			tv.selectedIndex = navigateList(msg.String(), tv.selectedIndex, len(tv.buckets), len(tv.buckets))
+		case "t":
+			// Show the selected period's largest file in the tree
+			if node := tv.GetSelectedNode(); node != nil {
+				return tv, func() tea.Msg {
+					return "JUMP_TO_TREE:" + node.Path
+				}
+			}
+		case "enter", "return":
+			// Drill into the selected bucket's files in the top list
+			if bucket := tv.GetSelectedBucket(); bucket != nil && len(bucket.Files) > 0 {
+				label := "modified " + strings.ToLower(bucket.Name)
+				files := bucket.Files
+				return tv, func() tea.Msg {
+					return ShowInTopListMsg{Label: label, Files: files}
+				}
+			}
 		}
 	}
 	return tv, nil
@@ -85,7 +159,7 @@ func (tv *TimelineView) View() string {
 	}
 
 	b.WriteString("\n")
-	b.WriteString(util.HelpStyle.Render("Old files may be safe to archive or delete"))
+	b.WriteString(util.HelpStyle.Render("Old files may be safe to archive or delete • Enter: list the period's files"))
 
 	return b.String()
 }
@@ -120,12 +194,9 @@ func (tv *TimelineView) renderBucket(bucket *TimeBucket, selected bool) string {
 	return util.NormalItemStyle.Render(line)
 }
 
-// buildBuckets creates time buckets and categorizes files
-func (tv *TimelineView) buildBuckets(root *scanner.FileNode) {
-	now := time.Now()
-
-	// Define time buckets
-	tv.buckets = []*TimeBucket{
+// defaultBuckets returns the built-in time buckets
+func defaultBuckets(now time.Time) []*TimeBucket {
+	return []*TimeBucket{
 		{
 			Name:      "Last 24 hours",
 			StartDate: now.Add(-24 * time.Hour),
@@ -169,10 +240,14 @@ func (tv *TimelineView) buildBuckets(root *scanner.FileNode) {
 			Files:     make([]*scanner.FileNode, 0),
 		},
 	}
+}
+
+// buildBuckets creates time buckets and categorizes files
+func (tv *TimelineView) buildBuckets(nodes []*scanner.FileNode, cutoffs []TimelineCutoff) {
+	tv.buckets = timelineBuckets(time.Now(), cutoffs)
 
 	// Categorize all files
-	allFiles := scanner.FlattenTree(root)
-	for _, file := range allFiles {
+	for _, file := range nodes {
 		if file.IsDir {
 			continue // Skip directories in timeline view
 		}
@@ -181,22 +256,40 @@ func (tv *TimelineView) buildBuckets(root *scanner.FileNode) {
 		for _, bucket := range tv.buckets {
 			if file.ModTime.After(bucket.StartDate) && file.ModTime.Before(bucket.EndDate) {
 				bucket.Files = append(bucket.Files, file)
-				bucket.TotalSize += file.Size
+				bucket.TotalSize += file.FileSize()
 				bucket.FileCount++
-				tv.totalSize += file.Size
+				tv.totalSize += file.FileSize()
 				break
 			} else if bucket.StartDate.IsZero() && file.ModTime.Before(bucket.EndDate) {
 				// Handle "over a year ago" bucket
 				bucket.Files = append(bucket.Files, file)
-				bucket.TotalSize += file.Size
+				bucket.TotalSize += file.FileSize()
 				bucket.FileCount++
-				tv.totalSize += file.Size
+				tv.totalSize += file.FileSize()
 				break
 			}
 		}
 	}
 }
 
+// RemoveNodes takes deleted files out of their buckets without walking the tree again
+func (tv *TimelineView) RemoveNodes(r *Removal) {
+	for _, bucket := range tv.buckets {
+		kept := r.Without(bucket.Files)
+		if len(kept) == len(bucket.Files) {
+			continue
+		}
+		for _, file := range bucket.Files {
+			if r.Contains(file) {
+				bucket.TotalSize -= file.FileSize()
+				bucket.FileCount--
+				tv.totalSize -= file.FileSize()
+			}
+		}
+		bucket.Files = kept
+	}
+}
+
 // SetHeight sets the viewport height
 func (tv *TimelineView) SetHeight(height int) {
 	tv.height = height
@@ -210,6 +303,14 @@ func (tv *TimelineView) GetSelectedBucket() *TimeBucket {
 	return nil
 }
 
+// GetSelectedNode returns the largest file of the selected bucket
+func (tv *TimelineView) GetSelectedNode() *scanner.FileNode {
+	if bucket := tv.GetSelectedBucket(); bucket != nil {
+		return largestFile(bucket.Files)
+	}
+	return nil
+}
+
 // GetOldFiles returns files older than a certain age
 func (tv *TimelineView) GetOldFiles(months int) []*scanner.FileNode {
 	cutoffDate := time.Now().Add(-time.Duration(months) * 30 * 24 * time.Hour)
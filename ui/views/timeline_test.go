@@ -0,0 +1,37 @@
+package views
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"spaceforce/scanner"
+)
+
+func TestTimelineBucketsComeFromEachView(t *testing.T) {
+	root := testDir(nil, "root")
+	old := testFile(root, "old.log", 100)
+	old.ModTime = time.Now().Add(-10 * 24 * time.Hour)
+	nodes := scanner.FlattenTree(root)
+
+	cutoffs, err := ParseTimelineCutoffs("7d,30d")
+	if err != nil {
+		t.Fatal(err)
+	}
+	custom := NewTimelineView(nodes, cutoffs)
+	builtIn := NewTimelineView(nodes, nil)
+
+	if got := len(custom.buckets); got != 3 {
+		t.Errorf("custom timeline has %d buckets, want 3", got)
+	}
+	if got, want := len(builtIn.buckets), len(defaultBuckets(time.Now())); got != want {
+		t.Errorf("built-in timeline has %d buckets, want %d", got, want)
+	}
+	if got := custom.buckets[1].FileCount; got != 1 {
+		t.Errorf("7d - 30d bucket holds %d files, want the 10-day-old file", got)
+	}
+
+	if legend := AgeLegend(cutoffs); !strings.Contains(legend, "30d") {
+		t.Errorf("age legend %q does not name the oldest custom bucket", legend)
+	}
+}
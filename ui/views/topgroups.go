@@ -0,0 +1,207 @@
+package views
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"spaceforce/scanner"
+	"spaceforce/util"
+)
+
+// topGroup is the listed files that share a parent directory
+type topGroup struct {
+	dir   string
+	files []*scanner.FileNode // In list order
+	size  int64
+}
+
+// topGroupRow is a line of the grouped display: a directory header, or a file beneath it
+type topGroupRow struct {
+	group *topGroup
+	file  *scanner.FileNode // nil for headers
+}
+
+// buildGroups groups the listed files by parent directory, largest total first
+// Directories in the list are left out, since their size is already an aggregate
+func (tlv *TopListView) buildGroups() {
+	byDir := make(map[string]*topGroup)
+	groups := make([]*topGroup, 0)
+	for _, item := range tlv.items {
+		if item.IsDir {
+			continue
+		}
+		dir := filepath.Dir(item.Path)
+		group, ok := byDir[dir]
+		if !ok {
+			group = &topGroup{dir: dir}
+			byDir[dir] = group
+			groups = append(groups, group)
+		}
+		group.files = append(group.files, item)
+		group.size += item.TotalSize()
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return groups[i].size > groups[j].size
+	})
+	tlv.groups = groups
+	tlv.buildGroupRows()
+}
+
+// buildGroupRows rebuilds the grouped rows from the expansion state
+func (tlv *TopListView) buildGroupRows() {
+	tlv.groupRows = make([]topGroupRow, 0, len(tlv.groups))
+	for _, group := range tlv.groups {
+		tlv.groupRows = append(tlv.groupRows, topGroupRow{group: group})
+		if !tlv.groupExpanded[group.dir] {
+			continue
+		}
+		for _, file := range group.files {
+			tlv.groupRows = append(tlv.groupRows, topGroupRow{group: group, file: file})
+		}
+	}
+
+	if tlv.groupIndex >= len(tlv.groupRows) {
+		tlv.groupIndex = len(tlv.groupRows) - 1
+	}
+	if tlv.groupIndex < 0 {
+		tlv.groupIndex = 0
+	}
+}
+
+// updateGrouped handles navigation in grouped mode and reports whether it used the key
+// Enter on a file jumps to it in the tree; on a folder header it expands or collapses it
+func (tlv *TopListView) updateGrouped(msg tea.KeyMsg) (bool, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		if tlv.groupIndex > 0 {
+			tlv.groupIndex--
+		}
+	case "down", "j":
+		if tlv.groupIndex < len(tlv.groupRows)-1 {
+			tlv.groupIndex++
+		}
+	case "pgup", "pgdown", "home", "end":
+		tlv.groupIndex = navigateList(msg.String(), tlv.groupIndex, len(tlv.groupRows), tlv.pageSize())
+	case "enter", "return":
+		if file := tlv.GetSelectedNode(); file != nil {
+			return true, func() tea.Msg {
+				return "JUMP_TO_TREE:" + file.Path
+			}
+		}
+		tlv.toggleGroup(nil)
+	case " ":
+		tlv.toggleGroup(nil)
+	case "right", "l":
+		expand := true
+		tlv.toggleGroup(&expand)
+	case "left", "h":
+		collapse := false
+		tlv.toggleGroup(&collapse)
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// toggleGroup expands or collapses the group of the selected row (expand: true/false to
+// force it) and keeps the cursor on that group's header
+func (tlv *TopListView) toggleGroup(expand *bool) {
+	if tlv.groupIndex >= len(tlv.groupRows) {
+		return
+	}
+	dir := tlv.groupRows[tlv.groupIndex].group.dir
+	if expand != nil {
+		tlv.groupExpanded[dir] = *expand
+	} else {
+		tlv.groupExpanded[dir] = !tlv.groupExpanded[dir]
+	}
+	tlv.buildGroupRows()
+
+	for i, row := range tlv.groupRows {
+		if row.file == nil && row.group.dir == dir {
+			tlv.groupIndex = i
+			break
+		}
+	}
+}
+
+// selectGroupedPath expands the group holding path and selects its row
+func (tlv *TopListView) selectGroupedPath(path string) {
+	dir := filepath.Dir(path)
+	tlv.groupExpanded[dir] = true
+	tlv.buildGroupRows()
+	for i, row := range tlv.groupRows {
+		if row.file != nil && row.file.Path == path {
+			tlv.groupIndex = i
+			return
+		}
+	}
+}
+
+// viewGroups renders the per-directory sections
+func (tlv *TopListView) viewGroups() string {
+	var b strings.Builder
+	contentHeight := tlv.pageSize()
+
+	start := tlv.groupIndex - contentHeight/2
+	if start < 0 {
+		start = 0
+	}
+	end := start + contentHeight
+	if end > len(tlv.groupRows) {
+		end = len(tlv.groupRows)
+		start = end - contentHeight
+		if start < 0 {
+			start = 0
+		}
+	}
+
+	for i := start; i < end; i++ {
+		row := tlv.groupRows[i]
+		selected := i == tlv.groupIndex
+		if row.file != nil {
+			b.WriteString(tlv.renderItem(row.file, selected, false))
+			b.WriteString("\n")
+			continue
+		}
+		b.WriteString(tlv.renderGroupHeader(row.group, selected))
+		b.WriteString("\n")
+	}
+
+	footer := fmt.Sprintf("%d folder(s)", len(tlv.groups))
+	if len(tlv.groupRows) > contentHeight {
+		footer += fmt.Sprintf(" | Showing %d-%d of %d rows", start+1, end, len(tlv.groupRows))
+	}
+	b.WriteString("\n")
+	b.WriteString(util.HelpStyle.Render(footer))
+
+	return b.String()
+}
+
+// renderGroupHeader renders a directory's line, with the path and size in the item columns
+func (tlv *TopListView) renderGroupHeader(group *topGroup, selected bool) string {
+	arrow := "▶"
+	if tlv.groupExpanded[group.dir] {
+		arrow = "▼"
+	}
+
+	path := group.dir
+	if len(path) > 42 {
+		path = "..." + path[len(path)-39:]
+	}
+
+	line := fmt.Sprintf(" %s  %-47s %12s", arrow, path, util.FormatBytes(group.size))
+	if tlv.showPercent() {
+		_, ofTotal := sizeShares(group.size, 0, tlv.detail.rootTotal)
+		line += fmt.Sprintf(" %*s", percentColumnWidth, fmt.Sprintf("%.1f%%", ofTotal))
+	}
+	line += fmt.Sprintf(" %10s", fmt.Sprintf("%d file(s)", len(group.files)))
+
+	if selected {
+		return util.SelectedItemStyle.Render(line)
+	}
+	return util.SubtitleStyle.UnsetMarginBottom().Render(line)
+}
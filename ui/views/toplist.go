@@ -5,6 +5,7 @@ import (
 	"path/filepath"
 	"sort"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"spaceforce/safety"
@@ -12,29 +13,77 @@ import (
 	"spaceforce/util"
 )
 
+// ShowInTopListMsg asks the app to switch to the top list showing only Files
+// Sent by views that aggregate files (e.g. the breakdown) to drill into a group
+type ShowInTopListMsg struct {
+	Label string // Describes the group, shown in the subtitle
+	Files []*scanner.FileNode
+}
+
+const (
+	ageMinWidth        = 106 // Terminal width needed for the Modified column
+	ageColumnWidth     = 8   // "11mo ago"
+	percentMinWidth    = 100 // Terminal width needed for the % of total column
+	percentColumnWidth = 6   // "100.0%"
+)
+
 // TopListView displays the largest files/folders sorted by size
 type TopListView struct {
+	fullItems     []*scanner.FileNode              // Every node in the tree
+	filterLabel   string                           // Set when allItems is a drill-down subset
 	allItems      []*scanner.FileNode              // Full unfiltered list
 	items         []*scanner.FileNode              // Filtered/sorted display list
 	selectedIndex int
 	height        int
-	sortMode      string                           // "size", "name", "modified"
+	width         int
+	sortMode      string                           // "size", "name", "modified", "count"
 	protector     *safety.Protector
 	showFiles     bool
 	showDirs      bool
-	markedFiles   map[string]*scanner.FileNode // Files marked for deletion
+	markedFiles   *MarkedSet                       // Files marked for deletion
+	protectedMode string                           // "show", "dim", "hide"
+	safeCache     map[string]bool                  // Path -> IsSafeToDelete result
+	cumulative    []int64                          // cumulative[i] = total size of items[0..i]
+	detail        *selectionDetail                 // Size and share of the selected node
+	countCache    map[*scanner.FileNode]int64      // FileCount of directories, filled in by the count sort
+	recentWindow  int                              // Index into recentWindows while showing what changed (-1 = off)
+	visualAnchor  int                              // Row where visual selection started with 'v' (-1 = off)
+	hidePercent   bool                             // '%' turned the % of total column off
+	grouped       bool                             // Show the listed files under their parent directories
+	groups        []*topGroup                      // Listed files by parent, largest first (grouped mode)
+	groupRows     []topGroupRow                    // Rows shown in grouped mode
+	groupIndex    int                              // Selected row in grouped mode
+	groupExpanded map[string]bool                  // Directory -> expanded (grouped mode)
+	colorByAge    bool                             // Color file names by modification age ('A')
+	ageCutoffs    []TimelineCutoff                 // Timeline buckets that band the age colors (nil = built-in)
 }
 
-// NewTopListView creates a new top list view
-func NewTopListView(root *scanner.FileNode) *TopListView {
+// recentWindows are the "what changed" periods cycled with 'w', shortest first
+var recentWindows = []struct {
+	label string
+	age   time.Duration
+}{
+	{"changed in the last 24 hours", 24 * time.Hour},
+	{"changed in the last 7 days", 7 * 24 * time.Hour},
+}
+
+// NewTopListView creates a new top list view of nodes, the flattened tree under root
+func NewTopListView(root *scanner.FileNode, nodes []*scanner.FileNode) *TopListView {
 	tlv := &TopListView{
-		height:    20,
-		sortMode:  "size",
-		protector: safety.NewProtector(),
-		showFiles: true,
-		showDirs:  true,
+		height:        20,
+		sortMode:      "size",
+		protector:     safety.NewProtector(),
+		showFiles:     true,
+		showDirs:      true,
+		protectedMode: "show",
+		safeCache:     make(map[string]bool),
+		detail:        newSelectionDetail(root),
+		countCache:    make(map[*scanner.FileNode]int64),
+		recentWindow:  -1,
+		visualAnchor:  -1,
+		groupExpanded: make(map[string]bool),
 	}
-	tlv.buildItemList(root)
+	tlv.buildItemList(nodes)
 	return tlv
 }
 
@@ -47,6 +96,12 @@ func (tlv *TopListView) Init() tea.Cmd {
 func (tlv *TopListView) Update(msg tea.Msg) (*TopListView, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if tlv.grouped {
+			if handled, cmd := tlv.updateGrouped(msg); handled {
+				return tlv, cmd
+			}
+		}
+
 		switch msg.String() {
 		case "up", "k":
 			if tlv.selectedIndex > 0 {
@@ -56,6 +111,8 @@ func (tlv *TopListView) Update(msg tea.Msg) (*TopListView, tea.Cmd) {
 			if tlv.selectedIndex < len(tlv.items)-1 {
 				tlv.selectedIndex++
 			}
+		case "pgup", "pgdown", "home", "end":
+			tlv.selectedIndex = navigateList(msg.String(), tlv.selectedIndex, len(tlv.items), tlv.pageSize())
 		case "enter", "return":
 			// Jump to tree view with selected item
 			if tlv.selectedIndex < len(tlv.items) {
@@ -74,6 +131,8 @@ func (tlv *TopListView) Update(msg tea.Msg) (*TopListView, tea.Cmd) {
 			case "name":
 				tlv.sortMode = "modified"
 			case "modified":
+				tlv.sortMode = "count"
+			case "count":
 				tlv.sortMode = "size"
 			}
 			tlv.sortItems()
@@ -85,6 +144,52 @@ func (tlv *TopListView) Update(msg tea.Msg) (*TopListView, tea.Cmd) {
 			// Toggle directories
 			tlv.showDirs = !tlv.showDirs
 			tlv.filterItems()
+		case "p":
+			// Cycle how protected items are shown
+			switch tlv.protectedMode {
+			case "show":
+				tlv.protectedMode = "dim"
+			case "dim":
+				tlv.protectedMode = "hide"
+			case "hide":
+				tlv.protectedMode = "show"
+			}
+			tlv.filterItems()
+			tlv.sortItems()
+		case "w":
+			// What changed: recently modified files, largest first (24 hours → 7 days → off)
+			tlv.cycleRecentWindow()
+		case "%":
+			// Show or hide the share-of-scan column
+			tlv.hidePercent = !tlv.hidePercent
+		case "g":
+			// Group the listed files by parent directory, keeping the selection
+			selected := tlv.GetSelectedNode()
+			tlv.grouped = !tlv.grouped
+			tlv.EndVisual()
+			if tlv.grouped {
+				tlv.groupIndex = 0
+				tlv.buildGroups()
+			}
+			if selected != nil {
+				tlv.SelectPath(selected.Path)
+			}
+		case "v":
+			// Visual mode: moving the cursor extends a range that 'm' marks at once
+			if tlv.grouped {
+				break
+			} else if tlv.visualAnchor >= 0 {
+				tlv.EndVisual()
+			} else if len(tlv.items) > 0 {
+				tlv.visualAnchor = tlv.selectedIndex
+			}
+		case "esc":
+			// Leave visual mode first, then a drill-down to show every item again
+			if tlv.visualAnchor >= 0 {
+				tlv.EndVisual()
+			} else if tlv.filterLabel != "" {
+				tlv.ClearFileFilter()
+			}
 		}
 	}
 	return tlv, nil
@@ -96,25 +201,54 @@ func (tlv *TopListView) View() string {
 
 	b.WriteString(util.TitleStyle.Render("📊 Largest Items"))
 	b.WriteString("\n")
-	b.WriteString(util.SubtitleStyle.Render(fmt.Sprintf("Sort: %s | Files: %t | Dirs: %t",
-		tlv.sortMode, tlv.showFiles, tlv.showDirs)))
+	subtitle := fmt.Sprintf("Sort: %s | Files: %t | Dirs: %t | Protected: %s",
+		tlv.sortMode, tlv.showFiles, tlv.showDirs, tlv.protectedMode)
+	if tlv.filterLabel != "" {
+		subtitle += fmt.Sprintf(" | Only: %s (esc: show all)", tlv.filterLabel)
+	}
+	if tlv.grouped {
+		subtitle += " | Grouped by folder (g: flat)"
+	}
+	if first, last, ok := tlv.visualRange(); ok {
+		subtitle += fmt.Sprintf(" | VISUAL: %d item(s) (m: mark, esc: cancel)", last-first+1)
+	}
+	b.WriteString(util.SubtitleStyle.Render(subtitle))
 	b.WriteString("\n\n")
 
 	// Header
-	header := fmt.Sprintf("%-50s %12s %10s %15s",
-		"Path", "Size", "Type", "Safety")
+	typeHeader := "Type"
+	if tlv.sortMode == "count" {
+		typeHeader = "Files"
+	}
+	header := fmt.Sprintf("%-50s %12s", "Path", "Size")
+	rule := 90
+	if tlv.showPercent() {
+		header += fmt.Sprintf(" %*s", percentColumnWidth, "Total")
+		rule += percentColumnWidth + 1
+	}
+	header += fmt.Sprintf(" %10s %15s", typeHeader, "Safety")
+	if tlv.showAge() {
+		header += fmt.Sprintf(" %*s", ageColumnWidth, "Modified")
+		rule += ageColumnWidth + 1
+	}
 	b.WriteString(util.HelpStyle.Render(header))
 	b.WriteString("\n")
-	b.WriteString(strings.Repeat("─", 90))
+	b.WriteString(strings.Repeat("─", rule))
 	b.WriteString("\n")
 
-	// Reserve lines for title (2), subtitle (3), header (2), separator (2), footer (2)
-	// Total chrome: 9 lines + 2 for optional footer = 11 lines worst case
-	contentHeight := tlv.height - 11
-	if contentHeight < 1 {
-		contentHeight = 1
+	if tlv.grouped {
+		b.WriteString(tlv.viewGroups())
+		if line := tlv.detail.render(tlv.GetSelectedNode()); line != "" {
+			b.WriteString("\n")
+			b.WriteString(util.HelpStyle.UnsetMarginTop().Render(line))
+		}
+		return b.String()
 	}
 
+	// Reserve lines for title (2), subtitle (3), header (2), separator (2), footer (2)
+	// Total chrome: 9 lines + 2 for optional footer + 1 detail line = 12 lines worst case
+	contentHeight := tlv.pageSize()
+
 	// Calculate viewport
 	start := tlv.selectedIndex - contentHeight/2
 	if start < 0 {
@@ -130,31 +264,46 @@ func (tlv *TopListView) View() string {
 	}
 
 	// Render items
+	first, last, visual := tlv.visualRange()
 	for i := start; i < end && i < len(tlv.items); i++ {
 		item := tlv.items[i]
-		line := tlv.renderItem(item, i == tlv.selectedIndex)
+		line := tlv.renderItem(item, i == tlv.selectedIndex, visual && i >= first && i <= last)
 		b.WriteString(line)
 		b.WriteString("\n")
 	}
 
-	// Footer
+	// Footer: running total from the top of the list to the selection
+	footer := ""
+	if tlv.selectedIndex < len(tlv.cumulative) {
+		footer = fmt.Sprintf("Top %d items = %s",
+			tlv.selectedIndex+1, util.FormatBytesPlain(tlv.cumulative[tlv.selectedIndex]))
+	}
 	if len(tlv.items) > contentHeight {
+		if footer != "" {
+			footer += " | "
+		}
+		footer += fmt.Sprintf("Showing %d-%d of %d items", start+1, end, len(tlv.items))
+	}
+	if footer != "" {
+		b.WriteString("\n")
+		b.WriteString(util.HelpStyle.Render(footer))
+	}
+
+	// Size of the selection relative to its folder and to the whole scan
+	if line := tlv.detail.render(tlv.GetSelectedNode()); line != "" {
 		b.WriteString("\n")
-		b.WriteString(util.HelpStyle.Render(fmt.Sprintf("Showing %d-%d of %d items",
-			start+1, end, len(tlv.items))))
+		b.WriteString(util.HelpStyle.UnsetMarginTop().Render(line))
 	}
 
 	return b.String()
 }
 
-// renderItem renders a single item
-func (tlv *TopListView) renderItem(node *scanner.FileNode, selected bool) string {
+// renderItem renders a single item; inRange rows are part of the visual selection
+func (tlv *TopListView) renderItem(node *scanner.FileNode, selected bool, inRange bool) string {
 	// Mark indicator
 	markIndicator := "   "
-	if tlv.markedFiles != nil {
-		if _, isMarked := tlv.markedFiles[node.Path]; isMarked {
-			markIndicator = "[✓]"
-		}
+	if tlv.markedFiles.IsMarked(node.Path) {
+		markIndicator = "[✓]"
 	}
 
 	// Get relative or shortened path
@@ -167,51 +316,189 @@ func (tlv *TopListView) renderItem(node *scanner.FileNode, selected bool) string
 	itemType := "File"
 	if node.IsDir {
 		itemType = "Dir"
+		if tlv.sortMode == "count" {
+			itemType = fmt.Sprintf("%d", tlv.fileCount(node))
+		}
+	}
+	if node.IsSymlink {
+		itemType = "Link"
+		if node.IsDir {
+			itemType = "Link dir"
+		}
+	}
+	if node.IsNetwork {
+		itemType += " (net)"
 	}
 
 	// Safety check
 	riskLevel := tlv.protector.GetRiskLevel(node.Path)
 	safetyStr := util.FormatSafetyLevel(riskLevel)
 
-	// Build line
-	line := fmt.Sprintf("%s %-47s %12s %10s %15s",
-		markIndicator,
-		path,
-		util.FormatBytes(node.TotalSize()),
-		itemType,
-		safetyStr)
+	// Build line (file paths colored by age when enabled; selection and dimming win)
+	dimmed := tlv.dimmed(node)
+	pathColumn := fmt.Sprintf("%-47s", path)
+	if tlv.colorByAge && !node.IsDir && !selected && !inRange && !dimmed {
+		pathColumn = ageStyle(node.ModTime, time.Now(), tlv.ageCutoffs).Render(pathColumn)
+	}
+	line := fmt.Sprintf("%s %s %12s", markIndicator, pathColumn, util.FormatBytes(node.TotalSize()))
+	if tlv.showPercent() {
+		// Share of the whole scan; the total is computed once and kept current by RemoveNodes
+		_, ofTotal := sizeShares(node.TotalSize(), 0, tlv.detail.rootTotal)
+		line += fmt.Sprintf(" %*s", percentColumnWidth, fmt.Sprintf("%.1f%%", ofTotal))
+	}
+	line += fmt.Sprintf(" %10s %15s", itemType, safetyStr)
+	if tlv.showAge() {
+		line += fmt.Sprintf(" %*s", ageColumnWidth, util.FormatRelativeTime(node.ModTime))
+	}
 
 	if selected {
 		return util.SelectedItemStyle.Render(line)
 	}
+	if inRange {
+		return util.RangeItemStyle.Render(line)
+	}
+	if dimmed {
+		return util.DimItemStyle.Render(line)
+	}
 	return util.NormalItemStyle.Render(line)
 }
 
-// buildItemList builds the flat list from the tree
-func (tlv *TopListView) buildItemList(root *scanner.FileNode) {
-	tlv.allItems = scanner.FlattenTree(root)
+// dimmed reports whether a row is greyed out because it is protected and the mode is "dim"
+func (tlv *TopListView) dimmed(node *scanner.FileNode) bool {
+	return tlv.protectedMode == "dim" && !tlv.isSafe(node.Path)
+}
+
+// isSafe caches protector.IsSafeToDelete results by path
+func (tlv *TopListView) isSafe(path string) bool {
+	if safe, ok := tlv.safeCache[path]; ok {
+		return safe
+	}
+	safe, _ := tlv.protector.IsSafeToDelete(path)
+	tlv.safeCache[path] = safe
+	return safe
+}
+
+// buildItemList builds the list from the flattened tree
+// The nodes are copied, since sorting reorders the list in place
+func (tlv *TopListView) buildItemList(nodes []*scanner.FileNode) {
+	tlv.fullItems = make([]*scanner.FileNode, len(nodes))
+	copy(tlv.fullItems, nodes)
+	tlv.allItems = tlv.fullItems
 	tlv.filterItems()
 	tlv.sortItems()
 }
 
-// filterItems filters the list based on show flags
+// RemoveNodes drops deleted items from the list without flattening the tree again
+func (tlv *TopListView) RemoveNodes(r *Removal) {
+	selected := tlv.GetSelectedNode()
+
+	drillDown := tlv.filterLabel != ""
+	tlv.fullItems = r.Without(tlv.fullItems)
+	if drillDown {
+		tlv.allItems = r.Without(tlv.allItems)
+	} else {
+		tlv.allItems = tlv.fullItems
+	}
+	tlv.detail.remove(r.Bytes())
+	for node, lost := range r.lostFiles {
+		if count, ok := tlv.countCache[node]; ok {
+			tlv.countCache[node] = count - lost
+		}
+	}
+	tlv.filterItems()
+	tlv.sortItems() // Directory sizes changed
+
+	if selected != nil && !r.Contains(selected) {
+		tlv.SelectPath(selected.Path)
+	}
+}
+
+// cycleRecentWindow steps through recentWindows, then back to every item
+func (tlv *TopListView) cycleRecentWindow() {
+	next := tlv.recentWindow + 1
+	if next >= len(recentWindows) {
+		tlv.ClearFileFilter()
+		return
+	}
+
+	cutoff := time.Now().Add(-recentWindows[next].age)
+	recent := make([]*scanner.FileNode, 0)
+	for _, node := range tlv.fullItems {
+		if !node.IsDir && node.ModTime.After(cutoff) {
+			recent = append(recent, node)
+		}
+	}
+
+	tlv.sortMode = "size"
+	tlv.SetFileFilter(recentWindows[next].label, recent)
+	tlv.recentWindow = next
+}
+
+// FilesLarger returns the files in nodes larger than size, largest first
+func FilesLarger(nodes []*scanner.FileNode, size int64) []*scanner.FileNode {
+	files := make([]*scanner.FileNode, 0)
+	for _, node := range nodes {
+		if !node.IsDir && node.TotalSize() > size {
+			files = append(files, node)
+		}
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].TotalSize() > files[j].TotalSize()
+	})
+	return files
+}
+
+// ShowFilesLarger restricts the list to files larger than size, sorted by size
+func (tlv *TopListView) ShowFilesLarger(size int64) {
+	tlv.sortMode = "size"
+	tlv.SetFileFilter("files over "+util.FormatBytesPlain(size), FilesLarger(tlv.fullItems, size))
+}
+
+// SetFileFilter restricts the list to the given files (e.g. one file type)
+func (tlv *TopListView) SetFileFilter(label string, files []*scanner.FileNode) {
+	tlv.filterLabel = label
+	tlv.recentWindow = -1
+	tlv.allItems = make([]*scanner.FileNode, len(files))
+	copy(tlv.allItems, files)
+	tlv.selectedIndex = 0
+	tlv.filterItems()
+	tlv.sortItems()
+}
+
+// ClearFileFilter goes back to listing every item in the tree
+func (tlv *TopListView) ClearFileFilter() {
+	tlv.filterLabel = ""
+	tlv.recentWindow = -1
+	tlv.allItems = tlv.fullItems
+	tlv.selectedIndex = 0
+	tlv.filterItems()
+	tlv.sortItems()
+}
+
+// filterItems filters the list based on show flags and the protected mode
+// A visual selection ends, since its rows no longer mean the same items
 func (tlv *TopListView) filterItems() {
-	if tlv.showFiles && tlv.showDirs {
+	tlv.visualAnchor = -1
+	if tlv.showFiles && tlv.showDirs && tlv.protectedMode != "hide" {
 		// No filtering needed - use all items
 		tlv.items = tlv.allItems
+		tlv.cumulative = cumulativeSizes(tlv.items)
 		return
 	}
 
 	// Filter from the full unfiltered list
 	filtered := make([]*scanner.FileNode, 0)
 	for _, item := range tlv.allItems {
-		if item.IsDir && tlv.showDirs {
-			filtered = append(filtered, item)
-		} else if !item.IsDir && tlv.showFiles {
-			filtered = append(filtered, item)
+		if item.IsDir && !tlv.showDirs || !item.IsDir && !tlv.showFiles {
+			continue
+		}
+		if tlv.protectedMode == "hide" && !tlv.isSafe(item.Path) {
+			continue
 		}
+		filtered = append(filtered, item)
 	}
 	tlv.items = filtered
+	tlv.cumulative = cumulativeSizes(tlv.items)
 
 	// Adjust selection if needed
 	if tlv.selectedIndex >= len(tlv.items) {
@@ -222,8 +509,42 @@ func (tlv *TopListView) filterItems() {
 	}
 }
 
-// sortItems sorts the items based on the current sort mode
+// cumulativeSizes returns running totals: result[i] is the space taken by items[0..i]
+// The list holds directories and their contents, so bytes are counted once: an item inside
+// a directory already counted adds nothing, and a directory adds only what its contents
+// listed above it did not
+func cumulativeSizes(items []*scanner.FileNode) []int64 {
+	totals := make([]int64, len(items))
+	counted := make(map[*scanner.FileNode]bool, len(items))
+	coveredBelow := make(map[*scanner.FileNode]int64) // Bytes counted inside each directory
+	running := int64(0)
+	for i, item := range items {
+		if !counted[item] && !ancestorCounted(item, counted) {
+			added := item.TotalSize() - coveredBelow[item]
+			running += added
+			counted[item] = true
+			for parent := item.Parent; parent != nil; parent = parent.Parent {
+				coveredBelow[parent] += added
+			}
+		}
+		totals[i] = running
+	}
+	return totals
+}
+
+// ancestorCounted reports whether a directory containing node is in counted
+func ancestorCounted(node *scanner.FileNode, counted map[*scanner.FileNode]bool) bool {
+	for parent := node.Parent; parent != nil; parent = parent.Parent {
+		if counted[parent] {
+			return true
+		}
+	}
+	return false
+}
+
+// sortItems sorts the items based on the current sort mode (ending any visual selection)
 func (tlv *TopListView) sortItems() {
+	tlv.visualAnchor = -1
 	switch tlv.sortMode {
 	case "size":
 		sort.Slice(tlv.items, func(i, j int) bool {
@@ -237,7 +558,41 @@ func (tlv *TopListView) sortItems() {
 		sort.Slice(tlv.items, func(i, j int) bool {
 			return tlv.items[i].ModTime.After(tlv.items[j].ModTime)
 		})
+	case "count":
+		sort.Slice(tlv.items, func(i, j int) bool {
+			return tlv.fileCount(tlv.items[i]) > tlv.fileCount(tlv.items[j])
+		})
+	}
+
+	// Running totals depend on the order
+	tlv.cumulative = cumulativeSizes(tlv.items)
+
+	// Groups follow the list (their files are kept in this order)
+	if tlv.grouped {
+		tlv.buildGroups()
+	}
+}
+
+// fileCount returns node.FileCount(), cached for directories (a file counts as one)
+func (tlv *TopListView) fileCount(node *scanner.FileNode) int64 {
+	if !node.IsDir {
+		return 1
+	}
+	if count, ok := tlv.countCache[node]; ok {
+		return count
 	}
+	count := node.FileCount()
+	tlv.countCache[node] = count
+	return count
+}
+
+// pageSize returns the number of rows that fit in the viewport
+func (tlv *TopListView) pageSize() int {
+	contentHeight := tlv.height - 12
+	if contentHeight < 1 {
+		contentHeight = 1
+	}
+	return contentHeight
 }
 
 // SetHeight sets the viewport height
@@ -245,13 +600,89 @@ func (tlv *TopListView) SetHeight(height int) {
 	tlv.height = height
 }
 
+// SetWidth sets the viewport width (the Modified column needs ageMinWidth)
+func (tlv *TopListView) SetWidth(width int) {
+	tlv.width = width
+}
+
+// showAge reports whether the terminal is wide enough for the Modified column
+// (and the % of total column, when that is shown too)
+func (tlv *TopListView) showAge() bool {
+	if tlv.showPercent() {
+		return tlv.width >= ageMinWidth+percentColumnWidth+1
+	}
+	return tlv.width >= ageMinWidth
+}
+
+// showPercent reports whether the % of total column is on and fits the terminal
+func (tlv *TopListView) showPercent() bool {
+	return !tlv.hidePercent && tlv.width >= percentMinWidth
+}
+
+// SetColorByAge turns coloring file names by modification age on or off
+func (tlv *TopListView) SetColorByAge(enabled bool) {
+	tlv.colorByAge = enabled
+}
+
+// SetTimelineCutoffs sets the timeline buckets that band the age colors (nil = built-in buckets)
+func (tlv *TopListView) SetTimelineCutoffs(cutoffs []TimelineCutoff) {
+	tlv.ageCutoffs = cutoffs
+}
+
 // SetMarkedFiles updates the marked files map
-func (tlv *TopListView) SetMarkedFiles(markedFiles map[string]*scanner.FileNode) {
+func (tlv *TopListView) SetMarkedFiles(markedFiles *MarkedSet) {
 	tlv.markedFiles = markedFiles
 }
 
-// GetSelectedNode returns the currently selected node
+// SelectPath selects the item with the given path, if it is listed
+// In grouped mode its folder is expanded to show it
+func (tlv *TopListView) SelectPath(path string) {
+	if tlv.grouped {
+		tlv.selectGroupedPath(path)
+	}
+	for i, item := range tlv.items {
+		if item.Path == path {
+			tlv.selectedIndex = i
+			return
+		}
+	}
+}
+
+// visualRange returns the first and last rows of the visual selection, if one is active
+func (tlv *TopListView) visualRange() (int, int, bool) {
+	if tlv.visualAnchor < 0 || tlv.visualAnchor >= len(tlv.items) {
+		return 0, 0, false
+	}
+	if tlv.visualAnchor < tlv.selectedIndex {
+		return tlv.visualAnchor, tlv.selectedIndex, true
+	}
+	return tlv.selectedIndex, tlv.visualAnchor, true
+}
+
+// VisualSelection returns the items in the visual selection, or nil outside visual mode
+func (tlv *TopListView) VisualSelection() []*scanner.FileNode {
+	first, last, ok := tlv.visualRange()
+	if !ok {
+		return nil
+	}
+	selection := make([]*scanner.FileNode, last-first+1)
+	copy(selection, tlv.items[first:last+1])
+	return selection
+}
+
+// EndVisual leaves visual mode
+func (tlv *TopListView) EndVisual() {
+	tlv.visualAnchor = -1
+}
+
+// GetSelectedNode returns the currently selected node (nil on a folder header in grouped mode)
 func (tlv *TopListView) GetSelectedNode() *scanner.FileNode {
+	if tlv.grouped {
+		if tlv.groupIndex < len(tlv.groupRows) {
+			return tlv.groupRows[tlv.groupIndex].file
+		}
+		return nil
+	}
 	if tlv.selectedIndex < len(tlv.items) {
 		return tlv.items[tlv.selectedIndex]
 	}
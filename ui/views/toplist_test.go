@@ -0,0 +1,122 @@
+package views
+
+import (
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"spaceforce/scanner"
+)
+
+// testFile adds a file of size bytes to dir and returns it
+func testFile(dir *scanner.FileNode, name string, size int64) *scanner.FileNode {
+	file := scanner.NewFileNode(dir.Path+"/"+name, size, false, time.Now())
+	dir.AddChild(file)
+	return file
+}
+
+// testDir adds a subdirectory to dir (or makes a root when dir is nil) and returns it
+func testDir(dir *scanner.FileNode, name string) *scanner.FileNode {
+	if dir == nil {
+		return scanner.NewFileNode("/"+name, 0, true, time.Now())
+	}
+	sub := scanner.NewFileNode(dir.Path+"/"+name, 0, true, time.Now())
+	dir.AddChild(sub)
+	return sub
+}
+
+func TestCumulativeSizesSumsItemsAbove(t *testing.T) {
+	root := testDir(nil, "root")
+	items := []*scanner.FileNode{
+		testFile(root, "a", 500),
+		testFile(root, "b", 300),
+		testFile(root, "c", 200),
+		testFile(root, "d", 0),
+	}
+
+	totals := cumulativeSizes(items)
+	for i := range items {
+		var want int64
+		for _, item := range items[:i+1] {
+			want += item.TotalSize()
+		}
+		if totals[i] != want {
+			t.Errorf("cumulative[%d] = %d, want %d", i, totals[i], want)
+		}
+	}
+}
+
+func TestCumulativeSizesCountsNestedItemsOnce(t *testing.T) {
+	root := testDir(nil, "root")
+	dir := testDir(root, "dir")
+	inside := testFile(dir, "inside", 600)
+	other := testFile(dir, "other", 100)
+	outside := testFile(root, "outside", 300)
+
+	tests := []struct {
+		name  string
+		items []*scanner.FileNode
+		want  []int64
+	}{
+		{"size order, root first", []*scanner.FileNode{root, dir, inside, outside, other}, []int64{1000, 1000, 1000, 1000, 1000}},
+		{"directory before its contents", []*scanner.FileNode{dir, inside, outside, other}, []int64{700, 700, 1000, 1000}},
+		{"contents before their directory", []*scanner.FileNode{inside, outside, dir, other}, []int64{600, 900, 1000, 1000}},
+	}
+	for _, tt := range tests {
+		got := cumulativeSizes(tt.items)
+		for i := range tt.want {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s: cumulative = %v, want %v", tt.name, got, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestFilterItemsProtectedModes(t *testing.T) {
+	root := testDir(nil, "root")
+	cache := testDir(root, "cache")
+	blob := testFile(cache, "blob", 300)
+	system := testDir(root, "system")
+	kernel := testFile(system, "kernel", 500)
+	nodes := []*scanner.FileNode{root, cache, blob, system, kernel}
+	protected := map[*scanner.FileNode]bool{root: true, system: true, kernel: true}
+
+	tlv := NewTopListView(root, nodes)
+	for _, node := range nodes {
+		tlv.safeCache[node.Path] = !protected[node]
+	}
+
+	for _, mode := range []string{"show", "dim", "hide"} {
+		t.Run(mode, func(t *testing.T) {
+			tlv.protectedMode = mode
+			tlv.filterItems()
+
+			listed := make(map[*scanner.FileNode]bool)
+			for _, item := range tlv.items {
+				listed[item] = true
+			}
+			for _, node := range nodes {
+				wantListed := mode != "hide" || !protected[node]
+				if listed[node] != wantListed {
+					t.Errorf("%s listed = %v, want %v", node.Path, listed[node], wantListed)
+				}
+				wantDimmed := mode == "dim" && protected[node]
+				if tlv.dimmed(node) != wantDimmed {
+					t.Errorf("%s dimmed = %v, want %v", node.Path, tlv.dimmed(node), wantDimmed)
+				}
+			}
+		})
+	}
+}
+
+func TestProtectedModeKeyCycles(t *testing.T) {
+	root := testDir(nil, "root")
+	tlv := NewTopListView(root, []*scanner.FileNode{root})
+	for _, want := range []string{"dim", "hide", "show"} {
+		tlv, _ = tlv.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("p")})
+		if tlv.protectedMode != want {
+			t.Errorf("protected mode = %q, want %q", tlv.protectedMode, want)
+		}
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"sort"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"spaceforce/scanner"
 	"spaceforce/util"
 )
@@ -16,6 +18,7 @@ type TreeSortBy int
 const (
 	TreeSortByName TreeSortBy = iota
 	TreeSortBySize
+	TreeSortByCount // Directories with the most files first
 )
 
 // TreeView displays files in a hierarchical tree structure
@@ -28,17 +31,53 @@ type TreeView struct {
 	height        int
 	width         int                               // Terminal width for dynamic rendering
 	sortBy        TreeSortBy
-	markedFiles   map[string]*scanner.FileNode     // Files marked for deletion
+	markedFiles   *MarkedSet                       // Files marked for deletion
 	sortedCache   map[string][]*scanner.FileNode   // Cache of sorted children by path
 	lastSortMode  TreeSortBy                       // Track when sort mode changes
+	exploredDirs  map[string]bool                  // Directories the user has expanded at least once
+	unexplored    []*scanner.FileNode              // Largest directories not yet explored (hint)
+	detail        *selectionDetail                 // Size and share of the selected node
+	sizeCache     map[string]int64                 // TotalSize by path, so rows don't re-walk subtrees every frame
+	countCache    map[string]int64                 // FileCount by path, for the same reason
+	crumbIndex    int                              // Breadcrumb segment being picked with 'b' (-1 = not picking)
+	cappedCache   map[string]*cappedChildren       // Rows shown for directories over the child cap, by path
+	uncappedDirs  map[string]bool                  // Dense directories whose "… and N more" row was expanded
+	linkPath      string                           // Symlink given as the scan path, if any
+	file          *fileDetail                      // Safety and type of a file scan root (computed on first render)
+	colorByAge    bool                             // Color file names by modification age ('A')
+	ageCutoffs    []TimelineCutoff                 // Timeline buckets that band the age colors (nil = built-in)
+	maxChildren   int                              // Children of one directory shown before folding the rest (0 = show all)
 }
 
+// unexploredHintCount is how many unexplored directories the hint lists
+const unexploredHintCount = 3
+
+// DefaultMaxTreeChildren is how many children of one directory the tree shows before
+// folding the rest into a "… and N more" row, unless SetMaxChildren changes it
+const DefaultMaxTreeChildren = 1000
+
+// cappedChildren is what the tree shows of a directory with more children than the cap:
+// the largest ones, in the current sort order, and totals for the rest
+type cappedChildren struct {
+	shown      []*scanner.FileNode
+	hidden     int
+	hiddenSize int64
+}
+
+const (
+	shareMinWidth    = 110  // Terminal width needed for the share-of-parent column
+	shareColumnWidth = 16   // " 80.0% ██████░░"
+	shareBarWidth    = 8    // Bar cells in the share column
+	dominantShare    = 50.0 // Rows taking at least this percent of their parent are highlighted
+)
+
 type treeItem struct {
 	node   *scanner.FileNode
 	depth  int
 	index  int
 	hasChildren bool
 	isExpanded bool
+	more   *cappedChildren // Set on the "… and N more" row, whose node is the dense directory
 }
 
 // NewTreeView creates a new tree view
@@ -47,11 +86,19 @@ func NewTreeView(root *scanner.FileNode) *TreeView {
 		root:         root,
 		displayRoot:  root,
 		expandedDirs: make(map[string]bool),
+		exploredDirs: make(map[string]bool),
 		sortedCache:  make(map[string][]*scanner.FileNode),
+		sizeCache:    make(map[string]int64),
+		countCache:   make(map[string]int64),
 		height:       20,
 		width:        80, // Default width, will be updated by SetWidth
 		sortBy:       TreeSortByName,
 		lastSortMode: TreeSortByName,
+		detail:       newSelectionDetail(root),
+		crumbIndex:   -1,
+		cappedCache:  make(map[string]*cappedChildren),
+		uncappedDirs: make(map[string]bool),
+		maxChildren:  DefaultMaxTreeChildren,
 	}
 	tv.expandedDirs[root.Path] = true // Expand root by default
 	tv.rebuildVisibleItems()
@@ -67,6 +114,11 @@ func (tv *TreeView) Init() tea.Cmd {
 func (tv *TreeView) Update(msg tea.Msg) (*TreeView, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if tv.crumbIndex >= 0 {
+			tv.updateBreadcrumb(msg.String())
+			return tv, nil
+		}
+
 		switch msg.String() {
 		case "up", "k":
 			if tv.selectedIndex > 0 {
@@ -76,11 +128,15 @@ func (tv *TreeView) Update(msg tea.Msg) (*TreeView, tea.Cmd) {
 			if tv.selectedIndex < len(tv.visibleItems)-1 {
 				tv.selectedIndex++
 			}
+		case "pgup", "pgdown", "home", "end":
+			tv.selectedIndex = navigateList(msg.String(), tv.selectedIndex, len(tv.visibleItems), tv.pageSize())
 		case "enter", " ":
 			// Toggle expansion
 			if tv.selectedIndex < len(tv.visibleItems) {
 				item := tv.visibleItems[tv.selectedIndex]
-				if item.node.IsDir {
+				if item.more != nil {
+					tv.showAllChildren(item.node)
+				} else if item.node.IsDir {
 					tv.expandedDirs[item.node.Path] = !tv.expandedDirs[item.node.Path]
 					tv.rebuildVisibleItems()
 				}
@@ -89,7 +145,9 @@ func (tv *TreeView) Update(msg tea.Msg) (*TreeView, tea.Cmd) {
 			// Expand directory
 			if tv.selectedIndex < len(tv.visibleItems) {
 				item := tv.visibleItems[tv.selectedIndex]
-				if item.node.IsDir {
+				if item.more != nil {
+					tv.showAllChildren(item.node)
+				} else if item.node.IsDir {
 					tv.expandedDirs[item.node.Path] = true
 					tv.rebuildVisibleItems()
 				}
@@ -103,22 +161,29 @@ func (tv *TreeView) Update(msg tea.Msg) (*TreeView, tea.Cmd) {
 					tv.rebuildVisibleItems()
 				}
 			}
+		case "L":
+			// Follow the space: expand and select the largest child
+			tv.selectLargestChild()
 		case "s":
-			// Toggle sort
-			if tv.sortBy == TreeSortByName {
+			// Cycle sort: name → size → file count
+			switch tv.sortBy {
+			case TreeSortByName:
 				tv.sortBy = TreeSortBySize
-			} else {
+			case TreeSortBySize:
+				tv.sortBy = TreeSortByCount
+			default:
 				tv.sortBy = TreeSortByName
 			}
 			// Clear cache when sort mode changes
 			tv.sortedCache = make(map[string][]*scanner.FileNode)
+			tv.cappedCache = make(map[string]*cappedChildren)
 			tv.lastSortMode = tv.sortBy
 			tv.rebuildVisibleItems()
 		case "z":
 			// Zoom into selected directory
 			if tv.selectedIndex < len(tv.visibleItems) {
 				item := tv.visibleItems[tv.selectedIndex]
-				if item.node.IsDir {
+				if item.node.IsDir && item.more == nil {
 					tv.displayRoot = item.node
 					tv.expandedDirs[item.node.Path] = true
 					tv.selectedIndex = 0
@@ -128,9 +193,8 @@ func (tv *TreeView) Update(msg tea.Msg) (*TreeView, tea.Cmd) {
 		case "u":
 			// Zoom out to parent directory
 			if tv.displayRoot != tv.root {
-				// Find parent of current displayRoot
-				parent := tv.findParent(tv.root, tv.displayRoot)
-				if parent != nil {
+				// Parent is set for every node but the scan root (AddChild)
+				if parent := tv.displayRoot.Parent; parent != nil {
 					tv.displayRoot = parent
 				} else {
 					tv.displayRoot = tv.root
@@ -138,16 +202,119 @@ func (tv *TreeView) Update(msg tea.Msg) (*TreeView, tea.Cmd) {
 				tv.selectedIndex = 0
 				tv.rebuildVisibleItems()
 			}
+		case "b":
+			// Pick a breadcrumb segment to jump to, starting at the parent
+			if tv.displayRoot != tv.root {
+				tv.crumbIndex = len(tv.zoomPath()) - 2
+			}
 		}
 	}
 	return tv, nil
 }
 
+// updateBreadcrumb moves along the breadcrumb while a segment is being picked
+func (tv *TreeView) updateBreadcrumb(key string) {
+	path := tv.zoomPath()
+	switch key {
+	case "left", "h":
+		if tv.crumbIndex > 0 {
+			tv.crumbIndex--
+		}
+	case "right", "l":
+		if tv.crumbIndex < len(path)-1 {
+			tv.crumbIndex++
+		}
+	case "enter", " ":
+		tv.zoomTo(path[tv.crumbIndex])
+		tv.crumbIndex = -1
+	case "esc", "b":
+		tv.crumbIndex = -1
+	}
+}
+
+// zoomTo makes node the displayed root of the tree
+func (tv *TreeView) zoomTo(node *scanner.FileNode) {
+	tv.displayRoot = node
+	tv.expandedDirs[node.Path] = true
+	tv.selectedIndex = 0
+	tv.rebuildVisibleItems()
+}
+
+// zoomPath returns the nodes from the root down to displayRoot, following Parent links
+func (tv *TreeView) zoomPath() []*scanner.FileNode {
+	path := make([]*scanner.FileNode, 0)
+	for node := tv.displayRoot; node != nil; node = node.Parent {
+		path = append([]*scanner.FileNode{node}, path...)
+		if node == tv.root {
+			break
+		}
+	}
+	return path
+}
+
+// renderBreadcrumb renders the zoom path (root › … › current), highlighting the segment being picked
+// Segments after the root are elided when the line is wider than the terminal
+func (tv *TreeView) renderBreadcrumb() string {
+	path := tv.zoomPath()
+	names := make([]string, len(path))
+	for i, node := range path {
+		names[i] = node.Name
+		if len(names[i]) > 30 {
+			names[i] = names[i][:27] + "..."
+		}
+	}
+	names[0] = path[0].Path
+
+	// Drop segments after the root until the line fits, but never the one being picked
+	first := 1
+	for first < len(names)-1 && (tv.crumbIndex < 0 || first < tv.crumbIndex) && breadcrumbWidth(names, first) > tv.width-4 {
+		first++
+	}
+
+	muted := util.HelpStyle.UnsetMarginTop()
+	segments := []string{tv.renderCrumb(names, 0, muted)}
+	if first > 1 {
+		segments = append(segments, muted.Render("…"))
+	}
+	for i := first; i < len(names); i++ {
+		segments = append(segments, tv.renderCrumb(names, i, muted))
+	}
+	line := strings.Join(segments, muted.Render(" › "))
+
+	if tv.crumbIndex >= 0 {
+		line += muted.Render("   ←→: choose • enter: zoom here • esc: cancel")
+	}
+	return line
+}
+
+// renderCrumb renders breadcrumb segment i, highlighted while it is being picked
+func (tv *TreeView) renderCrumb(names []string, i int, style lipgloss.Style) string {
+	if i == tv.crumbIndex {
+		return util.SelectedItemStyle.Render(names[i])
+	}
+	return style.Render(names[i])
+}
+
+// breadcrumbWidth returns the width of the breadcrumb showing the root and names[first:]
+func breadcrumbWidth(names []string, first int) int {
+	width := len(names[0])
+	if first > 1 {
+		width += len(" › …")
+	}
+	for _, name := range names[first:] {
+		width += len(" › ") + len(name)
+	}
+	return width
+}
+
 // View renders the tree view
 func (tv *TreeView) View() string {
 	if tv.root == nil {
 		return "No data to display"
 	}
+	if !tv.root.IsDir {
+		return tv.renderFileDetail()
+	}
 
 	var b strings.Builder
 
@@ -159,21 +326,12 @@ func (tv *TreeView) View() string {
 		sortIndicator = " (sorted by size)"
 	case TreeSortByName:
 		sortIndicator = " (sorted by name)"
-	}
-
-	// Add zoom indicator if we're zoomed into a subdirectory
-	zoomIndicator := ""
-	if tv.displayRoot != tv.root {
-		dirName := tv.displayRoot.Name
-		// Truncate long directory names to prevent wrapping
-		if len(dirName) > 30 {
-			dirName = dirName[:27] + "..."
-		}
-		zoomIndicator = " [zoomed: " + dirName + "]"
+	case TreeSortByCount:
+		sortIndicator = " (sorted by file count)"
 	}
 
 	// Truncate entire title if needed (max ~70 chars to be safe)
-	fullTitle := title + sortIndicator + zoomIndicator
+	fullTitle := title + sortIndicator
 	if len(fullTitle) > 70 {
 		fullTitle = fullTitle[:67] + "..."
 	}
@@ -181,15 +339,18 @@ func (tv *TreeView) View() string {
 	b.WriteString(util.TitleStyle.Render(fullTitle))
 	b.WriteString("\n\n")
 
-	// Calculate content height - now simple since we removed file counts to prevent wrapping
-	// Tree view outputs: title(3) + items(contentHeight) + scroll(2) = contentHeight + 5
-	// So: contentHeight + 5 <= tv.height → contentHeight = tv.height - 5
-	// Use tv.height - 6 to be slightly conservative
-	contentHeight := tv.height - 6
-	if contentHeight < 1 {
-		contentHeight = 1
+	// Zoomed in: show where we are, with 'b' to jump back up to any level
+	if tv.displayRoot != tv.root {
+		b.WriteString(tv.renderBreadcrumb())
+		b.WriteString("\n")
 	}
 
+	// Calculate content height - now simple since we removed file counts to prevent wrapping
+	// Tree view outputs: title(3) + items(contentHeight) + scroll(2) + detail(1) + hint(1) = contentHeight + 7
+	// So: contentHeight + 7 <= tv.height → contentHeight = tv.height - 7
+	// Use tv.height - 8 to be slightly conservative
+	contentHeight := tv.pageSize()
+
 	// Calculate viewport
 	start := tv.selectedIndex - contentHeight/2
 	if start < 0 {
@@ -219,11 +380,82 @@ func (tv *TreeView) View() string {
 			start+1, end, len(tv.visibleItems))))
 	}
 
+	// Size of the selection relative to its folder and to the whole scan
+	if line := tv.detail.render(tv.GetSelectedNode()); line != "" {
+		b.WriteString("\n")
+		b.WriteString(util.HelpStyle.UnsetMarginTop().Render(line))
+	}
+
+	// Nudge the user toward big directories they haven't opened yet
+	if hint := tv.renderUnexploredHint(); hint != "" {
+		b.WriteString("\n")
+		b.WriteString(hint)
+	}
+
 	return b.String()
 }
 
+// renderUnexploredHint renders a one-line list of the largest unexplored directories
+func (tv *TreeView) renderUnexploredHint() string {
+	if len(tv.unexplored) == 0 {
+		return ""
+	}
+
+	parts := make([]string, 0, len(tv.unexplored))
+	for _, node := range tv.unexplored {
+		parts = append(parts, fmt.Sprintf("%s (%s)", node.Name, util.FormatBytesPlain(node.TotalSize())))
+	}
+
+	hint := "💡 Not yet explored: " + strings.Join(parts, ", ")
+	maxWidth := tv.width - 4
+	if maxWidth < 40 {
+		maxWidth = 40
+	}
+	if len(hint) > maxWidth {
+		hint = hint[:maxWidth-3] + "..."
+	}
+
+	return util.HelpStyle.UnsetMarginTop().Render(hint)
+}
+
+// LargestUnexploredDirs returns the biggest directories just beyond the explored region
+// Only children of explored directories are considered, so the hint points at the next
+// level the user could open rather than something buried deep in the tree
+func LargestUnexploredDirs(root *scanner.FileNode, explored map[string]bool, limit int) []*scanner.FileNode {
+	candidates := make([]*scanner.FileNode, 0)
+
+	var walk func(node *scanner.FileNode)
+	walk = func(node *scanner.FileNode) {
+		for _, child := range node.Children {
+			if !child.IsDir || len(child.Children) == 0 {
+				continue
+			}
+			if explored[child.Path] {
+				walk(child)
+			} else {
+				candidates = append(candidates, child)
+			}
+		}
+	}
+	if root != nil && explored[root.Path] {
+		walk(root)
+	}
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].TotalSize() > candidates[j].TotalSize()
+	})
+	if len(candidates) > limit {
+		candidates = candidates[:limit]
+	}
+	return candidates
+}
+
 // renderItem renders a single tree item
 func (tv *TreeView) renderItem(item *treeItem, selected bool) string {
+	if item.more != nil {
+		return tv.renderMoreItem(item, selected)
+	}
+
 	var b strings.Builder
 
 	// Indentation
@@ -231,7 +463,9 @@ func (tv *TreeView) renderItem(item *treeItem, selected bool) string {
 	b.WriteString(indent)
 
 	// Expansion indicator
-	if item.node.IsDir {
+	if item.node.Truncated {
+		b.WriteString("· ") // Not scanned (depth limit) - nothing to expand
+	} else if item.node.IsDir {
 		if item.isExpanded {
 			b.WriteString("▼ ")
 		} else {
@@ -241,18 +475,21 @@ func (tv *TreeView) renderItem(item *treeItem, selected bool) string {
 		b.WriteString("  ")
 	}
 
-	// Icon and mark indicator
-	if item.node.IsDir {
+	// Icon and mark indicator (network-sourced nodes show a globe instead)
+	if item.node.IsNetwork {
+		b.WriteString("🌐 ")
+	} else if item.node.IsSymlink {
+		b.WriteString("🔗 ")
+	} else if item.node.IsDir {
 		b.WriteString("📁 ")
+	} else if item.node.IsBundle {
+		b.WriteString("📦 ")
 	} else {
 		b.WriteString("📄 ")
 	}
 
 	// Mark indicator
-	isMarked := false
-	if tv.markedFiles != nil {
-		_, isMarked = tv.markedFiles[item.node.Path]
-	}
+	isMarked := tv.markedFiles.IsMarked(item.node.Path)
 	markWidth := 0
 	if isMarked {
 		b.WriteString("[✓] ")
@@ -263,6 +500,10 @@ func (tv *TreeView) renderItem(item *treeItem, selected bool) string {
 	// Total width - (indent + expansion + icon + mark + size + padding)
 	indentWidth := len(indent)
 	fixedWidth := indentWidth + 2 + 2 + markWidth + 1 + 10 + 2 // expansion(2) + icon(2) + space(1) + size(~10) + padding(2)
+	showShare := tv.width >= shareMinWidth
+	if showShare {
+		fixedWidth += shareColumnWidth
+	}
 	availableWidth := tv.width - fixedWidth
 	if availableWidth < 20 {
 		availableWidth = 20 // Minimum
@@ -275,14 +516,21 @@ func (tv *TreeView) renderItem(item *treeItem, selected bool) string {
 	nameStyle := util.NormalItemStyle
 	if selected {
 		nameStyle = util.SelectedItemStyle
+	} else if tv.colorByAge && !item.node.IsDir {
+		nameStyle = ageStyle(item.node.ModTime, time.Now(), tv.ageCutoffs)
 	}
 
 	name := item.node.Name
+	if item.node.IsSymlink && item.node.LinkTarget != "" {
+		name += " → " + item.node.LinkTarget
+	}
 
 	// Build the complete name string with file count if applicable
 	var nameWithCount string
-	if item.node.IsDir && tv.width > 100 {
-		fileCount := item.node.FileCount()
+	if item.node.Truncated {
+		nameWithCount = name + " (depth limit)"
+	} else if item.node.IsDir && (tv.width > 100 || tv.sortBy == TreeSortByCount) {
+		fileCount := tv.nodeFileCount(item.node)
 		if fileCount > 0 {
 			// Add file count right after name
 			nameWithCount = fmt.Sprintf("%s (%d files)", name, fileCount)
@@ -301,8 +549,13 @@ func (tv *TreeView) renderItem(item *treeItem, selected bool) string {
 	// Render with padding to align size column
 	b.WriteString(nameStyle.Width(availableWidth).Render(nameWithCount))
 
+	// Share of the parent directory (blank for the top row)
+	size := tv.nodeSize(item.node)
+	if showShare {
+		b.WriteString(tv.renderShare(item, size))
+	}
+
 	// Size (right-aligned in its column)
-	size := item.node.TotalSize()
 	b.WriteString(" ")
 	sizeStr := util.FormatBytes(size)
 	b.WriteString(sizeStr)
@@ -310,36 +563,163 @@ func (tv *TreeView) renderItem(item *treeItem, selected bool) string {
 	return b.String()
 }
 
+// renderMoreItem renders the "… and N more" row standing in for a dense directory's smaller children
+func (tv *TreeView) renderMoreItem(item *treeItem, selected bool) string {
+	style := util.HelpStyle.UnsetMarginTop()
+	if selected {
+		style = util.SelectedItemStyle
+	}
+	line := fmt.Sprintf("%s    … and %d more (%s total) — enter to show all",
+		strings.Repeat("  ", item.depth), item.more.hidden, util.FormatBytesPlain(item.more.hiddenSize))
+	return style.Render(line)
+}
+
+// renderShare renders the percent-of-parent column with a small bar
+func (tv *TreeView) renderShare(item *treeItem, size int64) string {
+	parent := item.node.Parent
+	if item.depth == 0 || parent == nil {
+		return strings.Repeat(" ", shareColumnWidth)
+	}
+
+	percent, _ := sizeShares(size, tv.nodeSize(parent), 0)
+	filled := int(percent / 100 * shareBarWidth)
+	if filled > shareBarWidth {
+		filled = shareBarWidth
+	}
+	column := fmt.Sprintf(" %5.1f%% %s", percent,
+		strings.Repeat("█", filled)+strings.Repeat("░", shareBarWidth-filled))
+
+	if percent >= dominantShare {
+		return util.SizeLargeStyle.Render(column)
+	}
+	return util.HelpStyle.Render(column)
+}
+
+// nodeSize returns node.TotalSize(), cached by path for the life of the view
+// (the view is rebuilt when the size mode changes, and RemoveNodes adjusts it after a deletion)
+func (tv *TreeView) nodeSize(node *scanner.FileNode) int64 {
+	if size, ok := tv.sizeCache[node.Path]; ok {
+		return size
+	}
+	size := node.TotalSize()
+	tv.sizeCache[node.Path] = size
+	return size
+}
+
+// RemoveNodes drops deleted subtrees from the view, keeping expansion, zoom and selection
+// Cached sizes of the surviving ancestors are reduced rather than recomputed
+func (tv *TreeView) RemoveNodes(r *Removal) {
+	selected := tv.GetSelectedNode()
+
+	for node, lost := range r.lost {
+		if size, ok := tv.sizeCache[node.Path]; ok {
+			tv.sizeCache[node.Path] = size - lost
+		}
+		if count, ok := tv.countCache[node.Path]; ok {
+			tv.countCache[node.Path] = count - r.lostFiles[node]
+		}
+		delete(tv.sortedCache, node.Path) // Children or their sizes changed
+	}
+	for node := range r.nodes {
+		delete(tv.sizeCache, node.Path)
+		delete(tv.countCache, node.Path)
+		delete(tv.sortedCache, node.Path)
+	}
+	tv.cappedCache = make(map[string]*cappedChildren) // Cheap to rebuild, and any of them may hold a removed node
+
+	tv.displayRoot = r.survivingAncestor(tv.displayRoot)
+	tv.crumbIndex = -1
+	tv.unexplored = r.Without(tv.unexplored)
+	tv.detail.remove(r.Bytes())
+	tv.rebuildVisibleItems()
+
+	// Stay on the selected row, or the closest surviving ancestor if it was deleted
+	if selected = r.survivingAncestor(selected); selected != nil {
+		for i, item := range tv.visibleItems {
+			if item.node == selected {
+				tv.selectedIndex = i
+				break
+			}
+		}
+	}
+	if tv.selectedIndex >= len(tv.visibleItems) {
+		tv.selectedIndex = len(tv.visibleItems) - 1
+	}
+	if tv.selectedIndex < 0 {
+		tv.selectedIndex = 0
+	}
+}
+
+// nodeFileCount returns node.FileCount(), cached by path like nodeSize
+func (tv *TreeView) nodeFileCount(node *scanner.FileNode) int64 {
+	if count, ok := tv.countCache[node.Path]; ok {
+		return count
+	}
+	count := node.FileCount()
+	tv.countCache[node.Path] = count
+	return count
+}
+
 // rebuildVisibleItems rebuilds the list of visible items based on expansion state
 func (tv *TreeView) rebuildVisibleItems() {
 	tv.visibleItems = make([]*treeItem, 0)
 	tv.buildVisibleItemsRecursive(tv.displayRoot, 0, 0)
+	tv.refreshUnexplored()
 }
 
-// findParent finds the parent node of target within the tree rooted at node
-func (tv *TreeView) findParent(node *scanner.FileNode, target *scanner.FileNode) *scanner.FileNode {
-	if node == nil || target == nil {
-		return nil
+// refreshUnexplored records newly expanded directories and recomputes the hint
+func (tv *TreeView) refreshUnexplored() {
+	changed := tv.unexplored == nil
+	for path, expanded := range tv.expandedDirs {
+		if expanded && !tv.exploredDirs[path] {
+			tv.exploredDirs[path] = true
+			changed = true
+		}
 	}
 
-	// Check if any of node's children is the target
-	for _, child := range node.Children {
-		if child == target {
-			return node
+	if changed {
+		tv.unexplored = LargestUnexploredDirs(tv.root, tv.exploredDirs, unexploredHintCount)
+	}
+}
+
+// selectLargestChild expands the selected directory and selects its largest child
+// Repeated calls walk straight down the heaviest path
+func (tv *TreeView) selectLargestChild() {
+	if tv.selectedIndex >= len(tv.visibleItems) {
+		return
+	}
+	item := tv.visibleItems[tv.selectedIndex]
+	if item.more != nil {
+		return
+	}
+	node := item.node
+	largest := largestChild(node)
+	if largest == nil {
+		return
+	}
+
+	tv.expandedDirs[node.Path] = true
+	tv.rebuildVisibleItems()
+	for i := tv.selectedIndex + 1; i < len(tv.visibleItems); i++ {
+		if tv.visibleItems[i].node == largest {
+			tv.selectedIndex = i
+			break
 		}
 	}
+}
 
-	// Recursively search in children
+// largestChild returns the child of a directory with the largest TotalSize, or nil
+func largestChild(node *scanner.FileNode) *scanner.FileNode {
+	if node == nil || !node.IsDir {
+		return nil
+	}
+	var largest *scanner.FileNode
 	for _, child := range node.Children {
-		if child.IsDir {
-			parent := tv.findParent(child, target)
-			if parent != nil {
-				return parent
-			}
+		if largest == nil || child.TotalSize() > largest.TotalSize() {
+			largest = child
 		}
 	}
-
-	return nil
+	return largest
 }
 
 func (tv *TreeView) buildVisibleItemsRecursive(node *scanner.FileNode, depth int, index int) int {
@@ -365,14 +745,83 @@ func (tv *TreeView) buildVisibleItemsRecursive(node *scanner.FileNode, depth int
 			tv.sortedCache[node.Path] = children
 		}
 
+		// Dense directories show only their largest children until asked for the rest
+		var more *cappedChildren
+		if tv.maxChildren > 0 && len(children) > tv.maxChildren && !tv.uncappedDirs[node.Path] {
+			more = tv.capChildren(node, children)
+			children = more.shown
+		}
+
 		for _, child := range children {
 			index = tv.buildVisibleItemsRecursive(child, depth+1, index+1)
 		}
+
+		if more != nil {
+			tv.visibleItems = append(tv.visibleItems, &treeItem{
+				node:  node,
+				depth: depth + 1,
+				index: len(tv.visibleItems),
+				more:  more,
+			})
+			index++
+		}
 	}
 
 	return index
 }
 
+// capChildren returns the maxChildren largest of a directory's sorted children, kept
+// in sort order, with the count and size of the rest. Cached like the sorted children
+func (tv *TreeView) capChildren(node *scanner.FileNode, sorted []*scanner.FileNode) *cappedChildren {
+	if capped, ok := tv.cappedCache[node.Path]; ok {
+		return capped
+	}
+
+	bySize := make([]*scanner.FileNode, len(sorted))
+	copy(bySize, sorted)
+	sort.SliceStable(bySize, func(i, j int) bool {
+		return tv.nodeSize(bySize[i]) > tv.nodeSize(bySize[j])
+	})
+	largest := make(map[*scanner.FileNode]bool, tv.maxChildren)
+	for _, child := range bySize[:tv.maxChildren] {
+		largest[child] = true
+	}
+
+	capped := &cappedChildren{shown: make([]*scanner.FileNode, 0, tv.maxChildren)}
+	for _, child := range sorted {
+		if largest[child] {
+			capped.shown = append(capped.shown, child)
+		} else {
+			capped.hidden++
+			capped.hiddenSize += tv.nodeSize(child)
+		}
+	}
+	tv.cappedCache[node.Path] = capped
+	return capped
+}
+
+// showAllChildren lifts the child cap for a dense directory, keeping the selection on
+// the first row that was hidden
+func (tv *TreeView) showAllChildren(node *scanner.FileNode) {
+	capped := tv.cappedCache[node.Path]
+	tv.uncappedDirs[node.Path] = true
+	tv.rebuildVisibleItems()
+	if capped == nil {
+		return
+	}
+
+	shown := make(map[*scanner.FileNode]bool, len(capped.shown))
+	for _, child := range capped.shown {
+		shown[child] = true
+	}
+	for i, item := range tv.visibleItems {
+		if item.node.Parent == node && !shown[item.node] {
+			tv.selectedIndex = i
+			break
+		}
+	}
+}
+
 // sortChildren sorts a slice of FileNodes based on current sort settings
 func (tv *TreeView) sortChildren(children []*scanner.FileNode) {
 	switch tv.sortBy {
@@ -382,7 +831,19 @@ func (tv *TreeView) sortChildren(children []*scanner.FileNode) {
 			if children[i].IsDir != children[j].IsDir {
 				return children[i].IsDir
 			}
-			return children[i].TotalSize() > children[j].TotalSize()
+			return tv.nodeSize(children[i]) > tv.nodeSize(children[j])
+		})
+	case TreeSortByCount:
+		sort.Slice(children, func(i, j int) bool {
+			// Directories first, then by file count descending (files count as one, so by name)
+			if children[i].IsDir != children[j].IsDir {
+				return children[i].IsDir
+			}
+			countI, countJ := tv.nodeFileCount(children[i]), tv.nodeFileCount(children[j])
+			if countI != countJ {
+				return countI > countJ
+			}
+			return children[i].Name < children[j].Name
 		})
 	case TreeSortByName:
 		sort.Slice(children, func(i, j int) bool {
@@ -395,6 +856,18 @@ func (tv *TreeView) sortChildren(children []*scanner.FileNode) {
 	}
 }
 
+// pageSize returns the number of rows that fit in the viewport
+func (tv *TreeView) pageSize() int {
+	contentHeight := tv.height - 8
+	if tv.displayRoot != tv.root {
+		contentHeight-- // Breadcrumb line
+	}
+	if contentHeight < 1 {
+		contentHeight = 1
+	}
+	return contentHeight
+}
+
 // SetHeight sets the viewport height
 func (tv *TreeView) SetHeight(height int) {
 	tv.height = height
@@ -405,15 +878,41 @@ func (tv *TreeView) SetWidth(width int) {
 	tv.width = width
 }
 
+// SetLinkPath records the symlink the scan path was reached through
+func (tv *TreeView) SetLinkPath(path string) {
+	tv.linkPath = path
+}
+
+// SetMaxChildren sets how many children of one directory are shown before the rest are
+// folded into a "… and N more" row (0 = show all)
+func (tv *TreeView) SetMaxChildren(n int) {
+	tv.maxChildren = n
+	tv.cappedCache = make(map[string]*cappedChildren)
+	tv.rebuildVisibleItems()
+}
+
+// SetColorByAge turns coloring file names by modification age on or off
+func (tv *TreeView) SetColorByAge(enabled bool) {
+	tv.colorByAge = enabled
+}
+
+// SetTimelineCutoffs sets the timeline buckets that band the age colors (nil = built-in buckets)
+func (tv *TreeView) SetTimelineCutoffs(cutoffs []TimelineCutoff) {
+	tv.ageCutoffs = cutoffs
+}
+
 // SetMarkedFiles updates the marked files map
-func (tv *TreeView) SetMarkedFiles(markedFiles map[string]*scanner.FileNode) {
+func (tv *TreeView) SetMarkedFiles(markedFiles *MarkedSet) {
 	tv.markedFiles = markedFiles
 }
 
 // GetSelectedNode returns the currently selected node
+// The "… and N more" row has no node of its own, so nothing is selected on it
 func (tv *TreeView) GetSelectedNode() *scanner.FileNode {
 	if tv.selectedIndex < len(tv.visibleItems) {
-		return tv.visibleItems[tv.selectedIndex].node
+		if item := tv.visibleItems[tv.selectedIndex]; item.more == nil {
+			return item.node
+		}
 	}
 	return nil
 }
@@ -443,11 +942,15 @@ func (tv *TreeView) expandPathToNode(targetPath string) {
 		return
 	}
 
-	// Expand all parents by walking up the tree
+	// Expand all parents by walking up the tree, showing every child of dense
+	// directories so the target can't be folded into a "… and N more" row
 	node := current
 	for node != nil {
 		if node.Parent != nil {
 			tv.expandedDirs[node.Parent.Path] = true
+			if tv.maxChildren > 0 && len(node.Parent.Children) > tv.maxChildren {
+				tv.uncappedDirs[node.Parent.Path] = true
+			}
 		}
 		node = node.Parent
 	}
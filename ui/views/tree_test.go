@@ -0,0 +1,96 @@
+package views
+
+import (
+	"fmt"
+	"testing"
+
+	"spaceforce/scanner"
+)
+
+func unexploredNames(nodes []*scanner.FileNode) []string {
+	names := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		names = append(names, node.Name)
+	}
+	return names
+}
+
+func TestLargestUnexploredDirsSkipsExpanded(t *testing.T) {
+	root := testDir(nil, "root")
+	big := testDir(root, "big")
+	testFile(big, "blob", 900)
+	deep := testDir(big, "deep")
+	testFile(deep, "blob", 400)
+	mid := testDir(root, "mid")
+	testFile(mid, "blob", 500)
+	small := testDir(root, "small")
+	testFile(small, "blob", 100)
+	testDir(root, "empty")
+	testFile(root, "loose", 2000)
+
+	tests := []struct {
+		name     string
+		explored map[string]bool
+		limit    int
+		want     []string
+	}{
+		{"root only", map[string]bool{root.Path: true}, 3, []string{"big", "mid", "small"}},
+		{"limit", map[string]bool{root.Path: true}, 2, []string{"big", "mid"}},
+		{"expanded child replaced by its children", map[string]bool{root.Path: true, big.Path: true}, 3, []string{"mid", "deep", "small"}},
+		{"nothing explored", map[string]bool{}, 3, []string{}},
+		{"everything explored", map[string]bool{root.Path: true, big.Path: true, deep.Path: true, mid.Path: true, small.Path: true}, 3, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes := LargestUnexploredDirs(root, tt.explored, tt.limit)
+			for _, node := range nodes {
+				if tt.explored[node.Path] {
+					t.Errorf("%s is already expanded", node.Path)
+				}
+			}
+			got := unexploredNames(nodes)
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Fatalf("got %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestLargestUnexploredDirsNilRoot(t *testing.T) {
+	if got := LargestUnexploredDirs(nil, map[string]bool{}, 3); len(got) != 0 {
+		t.Errorf("got %v for a nil root", unexploredNames(got))
+	}
+}
+
+func TestMaxChildrenIsPerView(t *testing.T) {
+	root := testDir(nil, "root")
+	for i := 0; i < 5; i++ {
+		testFile(root, fmt.Sprintf("f%d", i), int64(100*(i+1)))
+	}
+
+	capped := NewTreeView(root)
+	capped.SetMaxChildren(2)
+	full := NewTreeView(root)
+
+	// Root, its two largest children and the "… and 3 more" row
+	if got := len(capped.visibleItems); got != 4 {
+		t.Errorf("capped tree shows %d rows, want 4", got)
+	}
+	if more := capped.visibleItems[len(capped.visibleItems)-1].more; more == nil || more.hidden != 3 {
+		t.Errorf("last row of the capped tree is %+v, want 3 hidden children", more)
+	}
+	if got := len(full.visibleItems); got != 6 {
+		t.Errorf("tree with the default cap shows %d rows, want all 6", got)
+	}
+
+	capped.SetMaxChildren(0)
+	if got := len(capped.visibleItems); got != 6 {
+		t.Errorf("uncapped tree shows %d rows, want all 6", got)
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"spaceforce/scanner"
+	"spaceforce/util"
+)
+
+// defaultUsersDir is where macOS keeps user home directories
+const defaultUsersDir = "/Users"
+
+// userHomeUsage is the scan result for one user's home directory
+type userHomeUsage struct {
+	User        string
+	Path        string
+	Size        int64
+	Readable    bool                  // False if the home directory itself could not be listed
+	DeniedPaths []string              // Directories inside the home that could not be read
+	Skipped     []scanner.SkippedPath // Paths the scan did not descend into (for -skip-manifest)
+}
+
+// needsPrivileges reports whether elevated privileges would reveal more of this home
+func (u userHomeUsage) needsPrivileges() bool {
+	return !u.Readable || len(u.DeniedPaths) > 0
+}
+
+// summarizeUserHome builds the usage record for a scanned home directory
+func summarizeUserHome(homePath string, root *scanner.FileNode, errs []error) userHomeUsage {
+	usage := userHomeUsage{
+		User:     filepath.Base(homePath),
+		Path:     homePath,
+		Readable: true,
+	}
+	if root != nil {
+		usage.Size = root.TotalSize()
+	}
+
+	for _, denied := range scanner.PermissionDeniedPaths(errs) {
+		if denied == homePath {
+			usage.Readable = false
+			continue
+		}
+		usage.DeniedPaths = append(usage.DeniedPaths, denied)
+	}
+
+	return usage
+}
+
+// scanUserHomes scans each top-level directory of usersDir separately
+func scanUserHomes(ctx context.Context, usersDir string, opts scanOptions) ([]userHomeUsage, error) {
+	entries, err := os.ReadDir(usersDir)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read %s: %w", usersDir, err)
+	}
+
+	homes := make([]userHomeUsage, 0)
+	for _, entry := range entries {
+		// Skip hidden entries like .localized
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		homePath := filepath.Join(usersDir, entry.Name())
+		scn := opts.newScanner()
+		root, err := scn.Scan(ctx, homePath, nil)
+		if err != nil && root == nil {
+			// Could not even stat the home directory
+			homes = append(homes, userHomeUsage{User: entry.Name(), Path: homePath})
+			continue
+		}
+
+		usage := summarizeUserHome(homePath, root, scn.GetProgress().Errors)
+		usage.Skipped = scn.GetSkippedPaths()
+		homes = append(homes, usage)
+	}
+
+	sort.Slice(homes, func(i, j int) bool {
+		return homes[i].Size > homes[j].Size
+	})
+
+	return homes, nil
+}
+
+// runUsersReport prints per-user home directory sizes and returns the exit code
+func runUsersReport(usersDir string, opts scanOptions) int {
+	homes, err := scanUserHomes(context.Background(), usersDir, opts)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("User home directories in %s:\n\n", usersDir)
+
+	needPrivileges := make([]userHomeUsage, 0)
+	for _, home := range homes {
+		switch {
+		case !home.Readable:
+			fmt.Printf("  %-24s %10s  (permission denied - requires elevated privileges)\n", home.User, "?")
+		case len(home.DeniedPaths) > 0:
+			fmt.Printf("  %-24s %10s  (partial: %d unreadable directories)\n",
+				home.User, util.FormatBytesPlain(home.Size), len(home.DeniedPaths))
+		default:
+			fmt.Printf("  %-24s %10s\n", home.User, util.FormatBytesPlain(home.Size))
+		}
+
+		if home.needsPrivileges() {
+			needPrivileges = append(needPrivileges, home)
+		}
+	}
+
+	if len(needPrivileges) > 0 {
+		names := make([]string, 0, len(needPrivileges))
+		for _, home := range needPrivileges {
+			names = append(names, home.User)
+		}
+		fmt.Printf("\n%d home director(ies) could not be fully read: %s\n",
+			len(needPrivileges), strings.Join(names, ", "))
+		fmt.Println("Run with sudo to include these: sudo spaceforce -users")
+	}
+
+	if opts.skipManifest != "" {
+		skipped := make([]scanner.SkippedPath, 0)
+		for _, home := range homes {
+			skipped = append(skipped, home.Skipped...)
+		}
+		if err := writeSkipManifest(opts.skipManifest, skipped); err != nil {
+			fmt.Printf("Warning: cannot write skip manifest: %v\n", err)
+		}
+	}
+
+	return 0
+}
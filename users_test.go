@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"spaceforce/scanner"
+)
+
+// deniedError is the error a scan records for a directory it may not read
+func deniedError(path string) error {
+	return &fs.PathError{Op: "open", Path: path, Err: fs.ErrPermission}
+}
+
+func TestSummarizeUserHome(t *testing.T) {
+	root := scanner.NewFileNode("/Users/alex", 0, true, time.Now())
+	root.AddChild(scanner.NewFileNode("/Users/alex/movie.mov", 4000, false, time.Now()))
+
+	readable := summarizeUserHome("/Users/alex", root, nil)
+	if !readable.Readable || readable.Size != 4000 || readable.needsPrivileges() {
+		t.Errorf("readable home = %+v, want readable, 4000 bytes, no privileges needed", readable)
+	}
+
+	partial := summarizeUserHome("/Users/alex", root, []error{deniedError("/Users/alex/Library/Mail")})
+	if !partial.Readable || partial.Size != 4000 || len(partial.DeniedPaths) != 1 || !partial.needsPrivileges() {
+		t.Errorf("partly denied home = %+v, want readable with one denied path", partial)
+	}
+
+	denied := summarizeUserHome("/Users/sam", scanner.NewFileNode("/Users/sam", 0, true, time.Now()),
+		[]error{deniedError("/Users/sam")})
+	if denied.Readable || denied.User != "sam" || !denied.needsPrivileges() {
+		t.Errorf("denied home = %+v, want unreadable and flagged", denied)
+	}
+}
+
+func TestScanUserHomes(t *testing.T) {
+	usersDir := t.TempDir()
+	for name, size := range map[string]int{"alex/big.bin": 8000, "sam/small.bin": 2000, ".localized/x": 10} {
+		path := filepath.Join(usersDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, make([]byte, size), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	locked := filepath.Join(usersDir, "locked")
+	if err := os.Mkdir(locked, 0o000); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chmod(locked, 0o755)
+
+	homes, err := scanUserHomes(context.Background(), usersDir, scanOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byUser := make(map[string]userHomeUsage)
+	for _, home := range homes {
+		byUser[home.User] = home
+	}
+	if len(homes) != 3 || byUser[".localized"].Path != "" {
+		t.Fatalf("homes = %+v, want alex, sam and locked only", homes)
+	}
+	if homes[0].User != "alex" || homes[0].Size != 8000 || byUser["sam"].Size != 2000 {
+		t.Errorf("homes = %+v, want alex (8000 bytes) first, then sam (2000 bytes)", homes)
+	}
+	if byUser["alex"].needsPrivileges() || byUser["sam"].needsPrivileges() {
+		t.Errorf("readable homes are flagged: %+v", homes)
+	}
+
+	// Root reads the locked home anyway
+	if os.Geteuid() != 0 && (byUser["locked"].Readable || !byUser["locked"].needsPrivileges()) {
+		t.Errorf("locked home = %+v, want unreadable and flagged", byUser["locked"])
+	}
+}
+
+func TestUsersReportWritesSkipManifest(t *testing.T) {
+	usersDir := t.TempDir()
+	for _, dir := range []string{"alex/project/node_modules", "sam/web/node_modules", "sam/docs"} {
+		if err := os.MkdirAll(filepath.Join(usersDir, dir), 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	manifest := filepath.Join(t.TempDir(), "skipped.txt")
+
+	opts := scanOptions{exclusions: []string{"node_modules"}, skipManifest: manifest}
+	if _, code := captureStdout(t, func() int { return runUsersReport(usersDir, opts) }); code != 0 {
+		t.Fatalf("runUsersReport exit code = %d", code)
+	}
+
+	data, err := os.ReadFile(manifest)
+	if err != nil {
+		t.Fatalf("no skip manifest written: %v", err)
+	}
+	// Every home's skips end up in the one manifest
+	for _, dir := range []string{"alex/project/node_modules", "sam/web/node_modules"} {
+		line := "user-exclusion\t" + filepath.Join(usersDir, dir) + "\t"
+		if !strings.Contains(string(data), line) {
+			t.Errorf("manifest is missing %s:\n%s", dir, data)
+		}
+	}
+}
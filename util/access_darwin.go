@@ -0,0 +1,10 @@
+package util
+
+// fullDiskAccessURL opens the Full Disk Access pane of System Settings
+const fullDiskAccessURL = "x-apple.systempreferences:com.apple.preference.security?Privacy_AllFiles"
+
+// AccessCommand returns a command that gets past "permission denied" on paths, and its purpose
+// On macOS it opens the Full Disk Access settings, which covers every path once granted
+func AccessCommand(paths []string) (command, purpose string) {
+	return "open " + ShellQuote(fullDiskAccessURL), "grant your terminal Full Disk Access"
+}
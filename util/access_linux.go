@@ -0,0 +1,14 @@
+package util
+
+import "strings"
+
+// AccessCommand returns a command that gets past "permission denied" on paths, and its purpose
+// On Linux only root can read them; SpaceForce refuses to run as root, so this sizes them
+// with du under sudo instead
+func AccessCommand(paths []string) (command, purpose string) {
+	quoted := make([]string, len(paths))
+	for i, path := range paths {
+		quoted[i] = ShellQuote(path)
+	}
+	return "sudo du -sh -- " + strings.Join(quoted, " "), "size them as root"
+}
@@ -0,0 +1,48 @@
+package util
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+)
+
+// ShellQuote quotes a path for POSIX shells using single quotes
+// Embedded single quotes are closed, escaped and reopened, so spaces, newlines, $ and globs are all literal
+func ShellQuote(path string) string {
+	return "'" + strings.ReplaceAll(path, "'", `'\''`) + "'"
+}
+
+// WritePathList writes paths either NUL-delimited (for xargs -0) or one shell-quoted path per line
+func WritePathList(w io.Writer, paths []string, nulDelimited bool) error {
+	buf := bufio.NewWriter(w)
+	for _, path := range paths {
+		var err error
+		if nulDelimited {
+			_, err = buf.WriteString(path + "\x00")
+		} else {
+			_, err = buf.WriteString(ShellQuote(path) + "\n")
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return buf.Flush()
+}
+
+// ExportPathList writes a path list to a file, or to stdout when target is "-"
+func ExportPathList(target string, paths []string, nulDelimited bool) error {
+	if target == "-" {
+		return WritePathList(os.Stdout, paths, nulDelimited)
+	}
+
+	file, err := os.Create(target)
+	if err != nil {
+		return err
+	}
+	if err := WritePathList(file, paths, nulDelimited); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,75 @@
+package util
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var awkwardPaths = []string{
+	"/tmp/plain",
+	"/tmp/with space/file",
+	"/tmp/it's quoted",
+	"/tmp/line\nbreak",
+	"/tmp/$HOME `date` *.log",
+	`/tmp/back\slash "double"`,
+}
+
+func TestShellQuoteRoundTrips(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no sh to check quoting against")
+	}
+	for _, path := range awkwardPaths {
+		out, err := exec.Command("sh", "-c", "printf '%s' "+ShellQuote(path)).Output()
+		if err != nil {
+			t.Fatalf("sh rejected %q: %v", ShellQuote(path), err)
+		}
+		if string(out) != path {
+			t.Errorf("sh read %q back as %q", path, out)
+		}
+	}
+}
+
+func TestWritePathList(t *testing.T) {
+	var nul bytes.Buffer
+	if err := WritePathList(&nul, awkwardPaths, true); err != nil {
+		t.Fatal(err)
+	}
+	got := strings.Split(strings.TrimSuffix(nul.String(), "\x00"), "\x00")
+	if strings.Join(got, "|") != strings.Join(awkwardPaths, "|") || !strings.HasSuffix(nul.String(), "\x00") {
+		t.Errorf("NUL-delimited list = %q", nul.String())
+	}
+
+	var quoted bytes.Buffer
+	if err := WritePathList(&quoted, awkwardPaths, false); err != nil {
+		t.Fatal(err)
+	}
+	var want strings.Builder
+	for _, path := range awkwardPaths {
+		want.WriteString(ShellQuote(path) + "\n")
+	}
+	if quoted.String() != want.String() {
+		t.Errorf("quoted list = %q, want %q", quoted.String(), want.String())
+	}
+}
+
+func TestExportPathListWritesFile(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "paths.txt")
+	if err := ExportPathList(target, awkwardPaths, true); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != strings.Join(awkwardPaths, "\x00")+"\x00" {
+		t.Errorf("exported %q", data)
+	}
+
+	if err := ExportPathList(filepath.Join(target, "missing", "paths.txt"), awkwardPaths, false); err == nil {
+		t.Error("expected an error for an unwritable target")
+	}
+}
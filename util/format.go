@@ -2,6 +2,11 @@ package util
 
 import (
 	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 )
@@ -56,11 +61,20 @@ var (
 	NormalItemStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#FFFFFF"))
 
+	DimItemStyle = lipgloss.NewStyle().
+			Foreground(ColorMuted).
+			Faint(true)
+
 	SelectedItemStyle = lipgloss.NewStyle().
 				Background(ColorSelected).
 				Foreground(ColorPrimary).
 				Bold(true)
 
+	// Rows inside a visual selection range (other than the cursor row)
+	RangeItemStyle = lipgloss.NewStyle().
+			Background(ColorSelected).
+			Foreground(lipgloss.Color("#FFFFFF"))
+
 	// Box styles
 	BoxStyle = lipgloss.NewStyle().
 			Border(lipgloss.RoundedBorder()).
@@ -68,41 +82,243 @@ var (
 			Padding(1, 2)
 )
 
+// PrecisionAuto shows one decimal below 10 and none above (the default)
+const PrecisionAuto = -1
+
+// SizeFormat is how sizes are written: decimals, units and the color thresholds
+// Start from DefaultSizeFormat; the zero value shows whole numbers only
+type SizeFormat struct {
+	Precision int   // Decimals (0-2), or PrecisionAuto
+	Decimal   bool  // Decimal units like Finder (1000, KB/MB/GB) instead of binary like du (1024, KiB/MiB/GiB)
+	Medium    int64 // Sizes from here on are colored as medium (0 = 1 MB in the format's units)
+	Large     int64 // Sizes from here on are colored as large (0 = 100 MB in the format's units)
+}
+
+// DefaultSizeFormat is binary units with automatic precision and the default colors
+var DefaultSizeFormat = SizeFormat{Precision: PrecisionAuto}
+
+// sizeFormat is the format installed by SetSizeFormat, swapped as a whole so a size
+// is never formatted with half of one format and half of another
+var sizeFormat atomic.Pointer[SizeFormat]
+
+// SetSizeFormat sets how FormatBytes and the other package-level helpers write sizes
+// Call it once at startup, before any output. A precision outside 0-2 becomes PrecisionAuto
+func SetSizeFormat(f SizeFormat) {
+	if f.Precision < 0 || f.Precision > 2 {
+		f.Precision = PrecisionAuto
+	}
+	sizeFormat.Store(&f)
+}
+
+// CurrentSizeFormat returns the format set with SetSizeFormat (DefaultSizeFormat if none)
+func CurrentSizeFormat() SizeFormat {
+	if f := sizeFormat.Load(); f != nil {
+		return *f
+	}
+	return DefaultSizeFormat
+}
+
+// binaryUnitNames and decimalUnitNames label kilo through peta in each mode
+var (
+	binaryUnitNames  = []string{"KiB", "MiB", "GiB", "TiB", "PiB"}
+	decimalUnitNames = []string{"KB", "MB", "GB", "TB", "PB"}
+)
+
+// thresholds returns the medium and large color thresholds of f
+// The defaults follow the unit mode, so "1 MB" means the same as the label
+func (f SizeFormat) thresholds() (int64, int64) {
+	mega := f.UnitBase() * f.UnitBase()
+	medium, large := mega, 100*mega
+	if f.Medium > 0 {
+		medium = f.Medium
+	}
+	if f.Large > 0 {
+		large = f.Large
+	}
+	return medium, large
+}
+
+// UnitBase returns the size of one kilo unit in f's units (1000 or 1024)
+func (f SizeFormat) UnitBase() int64 {
+	if f.Decimal {
+		return 1000
+	}
+	return 1024
+}
+
+// UnitName returns the label of the exp-th unit in f's units (0 = KiB or KB)
+func (f SizeFormat) UnitName(exp int) string {
+	if f.Decimal {
+		return decimalUnitNames[exp]
+	}
+	return binaryUnitNames[exp]
+}
+
+// UnitBase returns the size of one kilo unit in the current format (1000 or 1024)
+func UnitBase() int64 {
+	return CurrentSizeFormat().UnitBase()
+}
+
+// UnitName returns the label of the exp-th unit in the current format (0 = KiB or KB)
+func UnitName(exp int) string {
+	return CurrentSizeFormat().UnitName(exp)
+}
+
 // FormatBytes converts bytes to human-readable format with color coding
 func FormatBytes(bytes int64) string {
-	const unit = 1024
+	return CurrentSizeFormat().FormatBytes(bytes)
+}
+
+// FormatBytesPlain converts bytes to a human-readable string without styling
+// Use this for non-TUI output (CLI reports, exported files)
+func FormatBytesPlain(bytes int64) string {
+	return CurrentSizeFormat().FormatBytesPlain(bytes)
+}
+
+// FormatBytes converts bytes to human-readable format in f, with color coding
+func (f SizeFormat) FormatBytes(bytes int64) string {
+	unit := f.UnitBase()
 	if bytes < unit {
-		return SizeSmallStyle.Render("< 1 KB")
+		return SizeSmallStyle.Render("< 1 " + f.UnitName(0))
 	}
 
-	div, exp := int64(unit), 0
-	for n := bytes / unit; n >= unit; n /= unit {
+	// Color based on size (1 MB and 100 MB unless f sets its own thresholds)
+	medium, large := f.thresholds()
+	var style lipgloss.Style
+	if bytes < medium {
+		style = SizeSmallStyle
+	} else if bytes < large {
+		style = SizeMediumStyle
+	} else {
+		style = SizeLargeStyle
+	}
+
+	return style.Width(10).Align(lipgloss.Right).Render(f.FormatBytesPlain(bytes))
+}
+
+// FormatBytesPlain converts bytes to a human-readable string in f, without styling
+func (f SizeFormat) FormatBytesPlain(bytes int64) string {
+	unit := f.UnitBase()
+	if bytes < unit {
+		return "< 1 " + f.UnitName(0)
+	}
+
+	div, exp := unit, 0
+	for n := bytes / unit; n >= unit && exp < len(binaryUnitNames)-1; n /= unit {
 		div *= unit
 		exp++
 	}
 
 	value := float64(bytes) / float64(div)
-	units := []string{"KB", "MB", "GB", "TB", "PB"}
+	decimals := f.decimals(value)
 
-	// Color based on size
-	var style lipgloss.Style
-	if bytes < 1024*1024 { // < 1 MB
-		style = SizeSmallStyle
-	} else if bytes < 100*1024*1024 { // < 100 MB
-		style = SizeMediumStyle
-	} else {
-		style = SizeLargeStyle
+	// Values that would show as 1000 or more move up a unit ("1020.00 KiB" is "1.00 MiB"),
+	// so a size always fits the 10-character column FormatBytes renders into
+	if exp < len(binaryUnitNames)-1 && roundTo(value, decimals) >= 1000 {
+		value /= float64(unit)
+		exp++
+		decimals = f.decimals(value)
 	}
 
-	// Format the size string
-	var sizeStr string
+	return fmt.Sprintf("%.*f %s", decimals, value, f.UnitName(exp))
+}
+
+// decimals returns the number of decimals to show value with
+func (f SizeFormat) decimals(value float64) int {
+	if f.Precision != PrecisionAuto {
+		return f.Precision
+	}
 	if value < 10 {
-		sizeStr = fmt.Sprintf("%.1f %s", value, units[exp])
-	} else {
-		sizeStr = fmt.Sprintf("%.0f %s", value, units[exp])
+		return 1
+	}
+	return 0
+}
+
+// roundTo rounds value to the given number of decimals, as %.*f prints it
+func roundTo(value float64, decimals int) float64 {
+	scale := math.Pow(10, float64(decimals))
+	return math.Round(value*scale) / scale
+}
+
+// ParseSize parses a human-readable size like "500MB", "1.5GiB", "1.5G" or "2048"
+// Units are binary (1 KB = 1 KiB = 1024 bytes) whichever display mode is active
+func ParseSize(s string) (int64, error) {
+	str := strings.ToUpper(strings.TrimSpace(s))
+	if str == "" {
+		return 0, fmt.Errorf("empty size")
+	}
+
+	multipliers := []struct {
+		suffix string
+		factor int64
+	}{
+		{"PIB", 1 << 50}, {"TIB", 1 << 40}, {"GIB", 1 << 30}, {"MIB", 1 << 20}, {"KIB", 1 << 10},
+		{"PB", 1 << 50}, {"TB", 1 << 40}, {"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10},
+		{"P", 1 << 50}, {"T", 1 << 40}, {"G", 1 << 30}, {"M", 1 << 20}, {"K", 1 << 10},
+		{"B", 1},
+	}
+
+	factor := int64(1)
+	for _, m := range multipliers {
+		if strings.HasSuffix(str, m.suffix) {
+			factor = m.factor
+			str = strings.TrimSpace(strings.TrimSuffix(str, m.suffix))
+			break
+		}
+	}
+
+	value, err := strconv.ParseFloat(str, 64)
+	if err != nil || value < 0 {
+		return 0, fmt.Errorf("invalid size %q", s)
 	}
 
-	return style.Width(10).Align(lipgloss.Right).Render(sizeStr)
+	return int64(value * float64(factor)), nil
+}
+
+// ParseSizeThresholds parses "medium,large" color thresholds such as "10M,1G"
+// Both sizes use ParseSize; large must be bigger than medium
+func ParseSizeThresholds(s string) (int64, int64, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("expected two sizes separated by a comma, got %q", s)
+	}
+
+	medium, err := ParseSize(parts[0])
+	if err != nil {
+		return 0, 0, err
+	}
+	large, err := ParseSize(parts[1])
+	if err != nil {
+		return 0, 0, err
+	}
+	if medium <= 0 || large <= medium {
+		return 0, 0, fmt.Errorf("thresholds must be positive and increasing, got %q", s)
+	}
+	return medium, large, nil
+}
+
+// FormatRelativeTime describes how long ago t was, e.g. "5m ago", "3d ago", "3mo ago", "2y ago"
+// Months are 30 days and years 365 days; times in the future read "just now"
+func FormatRelativeTime(t time.Time) string {
+	age := time.Since(t)
+	day := 24 * time.Hour
+
+	switch {
+	case age < time.Minute:
+		return "just now"
+	case age < time.Hour:
+		return fmt.Sprintf("%dm ago", int(age/time.Minute))
+	case age < day:
+		return fmt.Sprintf("%dh ago", int(age/time.Hour))
+	case age < 7*day:
+		return fmt.Sprintf("%dd ago", int(age/day))
+	case age < 30*day:
+		return fmt.Sprintf("%dw ago", int(age/(7*day)))
+	case age < 365*day:
+		return fmt.Sprintf("%dmo ago", int(age/(30*day)))
+	default:
+		return fmt.Sprintf("%dy ago", int(age/(365*day)))
+	}
 }
 
 // FormatSafetyLevel returns a styled string for a risk level
@@ -120,3 +336,33 @@ func FormatSafetyLevel(riskLevel int) string {
 		return "Unknown"
 	}
 }
+
+// ParseAge parses an age like "36h", "7d", "2w", "6m" or "2y"
+// Months count as 30 days and years as 365 days (the same approximation the timeline uses)
+func ParseAge(s string) (time.Duration, error) {
+	str := strings.ToLower(strings.TrimSpace(s))
+	if len(str) < 2 {
+		return 0, fmt.Errorf("invalid age %q", s)
+	}
+
+	day := 24 * time.Hour
+	units := map[byte]time.Duration{
+		'h': time.Hour,
+		'd': day,
+		'w': 7 * day,
+		'm': 30 * day,
+		'y': 365 * day,
+	}
+
+	unit, ok := units[str[len(str)-1]]
+	if !ok {
+		return 0, fmt.Errorf("invalid age %q (use h, d, w, m or y)", s)
+	}
+
+	value, err := strconv.ParseFloat(str[:len(str)-1], 64)
+	if err != nil || value <= 0 {
+		return 0, fmt.Errorf("invalid age %q", s)
+	}
+
+	return time.Duration(value * float64(unit)), nil
+}
@@ -0,0 +1,117 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// withSizeFormat sets the unit mode and precision for one test and restores the defaults after
+func withSizeFormat(t *testing.T, decimal bool, precision int) {
+	t.Helper()
+	SetSizeFormat(SizeFormat{Precision: precision, Decimal: decimal})
+	t.Cleanup(func() { SetSizeFormat(DefaultSizeFormat) })
+}
+
+func TestFormatBytesPlainUnits(t *testing.T) {
+	tests := []struct {
+		decimal   bool
+		precision int
+		bytes     int64
+		want      string
+	}{
+		{false, PrecisionAuto, 1023, "< 1 KiB"},
+		{false, PrecisionAuto, 1024, "1.0 KiB"},
+		{false, PrecisionAuto, 1536, "1.5 KiB"},
+		{false, PrecisionAuto, 1 << 30, "1.0 GiB"},
+		{true, PrecisionAuto, 999, "< 1 KB"},
+		{true, PrecisionAuto, 1000, "1.0 KB"},
+		{true, PrecisionAuto, 1500000, "1.5 MB"},
+		{true, PrecisionAuto, 250 * 1000 * 1000 * 1000, "250 GB"},
+
+		// Four-digit values move up a unit
+		{false, 2, 1020 * 1024, "1.00 MiB"},
+		{false, 2, 999*1024 + 1023, "0.98 MiB"},
+		{false, 2, 999 * 1024, "999.00 KiB"},
+		{false, 0, 1023 * 1024, "1 MiB"},
+		{false, PrecisionAuto, 1000 << 20, "1.0 GiB"},
+		{true, 2, 999999, "1.00 MB"},
+		{true, 1, 999940, "999.9 KB"},
+
+		// There is no unit above PiB
+		{false, 0, 2000 << 50, "2000 PiB"},
+	}
+	for _, tt := range tests {
+		withSizeFormat(t, tt.decimal, tt.precision)
+		if got := FormatBytesPlain(tt.bytes); got != tt.want {
+			t.Errorf("FormatBytesPlain(%d) decimal=%v precision=%d = %q, want %q",
+				tt.bytes, tt.decimal, tt.precision, got, tt.want)
+		}
+	}
+}
+
+func TestFormatBytesFitsColumn(t *testing.T) {
+	for _, decimal := range []bool{false, true} {
+		for _, precision := range []int{PrecisionAuto, 0, 1, 2} {
+			withSizeFormat(t, decimal, precision)
+			for bytes := int64(1024); bytes < 1<<50; bytes = bytes*101/100 + 1 {
+				got := FormatBytes(bytes)
+				if strings.Contains(got, "\n") || lipgloss.Width(got) != 10 {
+					t.Fatalf("FormatBytes(%d) decimal=%v precision=%d = %q, want one 10-column line",
+						bytes, decimal, precision, got)
+				}
+			}
+		}
+	}
+}
+
+func TestFormatBytesPlainPrecision(t *testing.T) {
+	tests := []struct {
+		precision int
+		bytes     int64
+		want      string
+	}{
+		{PrecisionAuto, 1536, "1.5 KiB"},
+		{PrecisionAuto, 15 * 1024, "15 KiB"},
+		{PrecisionAuto, 2560 << 20, "2.5 GiB"},
+		{PrecisionAuto, 123 << 30, "123 GiB"},
+		{0, 1536, "2 KiB"},
+		{0, 2662 << 20, "3 GiB"},
+		{0, 123 << 30, "123 GiB"},
+		{1, 1536, "1.5 KiB"},
+		{1, 15 * 1024, "15.0 KiB"},
+		{1, 123<<30 + 200<<20, "123.2 GiB"},
+		{2, 1536, "1.50 KiB"},
+		{2, 15*1024 + 256, "15.25 KiB"},
+		{2, 123<<30 + 200<<20, "123.20 GiB"},
+
+		// Out-of-range precisions restore the automatic one
+		{3, 15 * 1024, "15 KiB"},
+		{-2, 1536, "1.5 KiB"},
+	}
+	for _, tt := range tests {
+		withSizeFormat(t, false, tt.precision)
+		if got := FormatBytesPlain(tt.bytes); got != tt.want {
+			t.Errorf("precision %d: FormatBytesPlain(%d) = %q, want %q", tt.precision, tt.bytes, got, tt.want)
+		}
+	}
+}
+
+func TestSizeFormatIgnoresInstalledFormat(t *testing.T) {
+	withSizeFormat(t, true, 2)
+
+	binary := DefaultSizeFormat
+	if got := binary.FormatBytesPlain(1536); got != "1.5 KiB" {
+		t.Errorf("default format gave %q, want %q", got, "1.5 KiB")
+	}
+	if got := FormatBytesPlain(1536); got != "1.54 KB" {
+		t.Errorf("installed format gave %q, want %q", got, "1.54 KB")
+	}
+
+	// Out-of-range precision falls back to automatic, as -precision validation expects
+	SetSizeFormat(SizeFormat{Precision: 5})
+	if got := CurrentSizeFormat().Precision; got != PrecisionAuto {
+		t.Errorf("precision 5 was kept as %d, want PrecisionAuto", got)
+	}
+}
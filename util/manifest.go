@@ -0,0 +1,107 @@
+package util
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ManifestEntry is one marked item in a deletion manifest
+type ManifestEntry struct {
+	Path string
+	Size int64 // Bytes, in the size mode that was active when it was written
+}
+
+// WriteManifest writes entries as tab-separated "bytes<TAB>size<TAB>path" lines under
+// a "#" comment header with the time and totals. The path comes last, so it may contain tabs
+func WriteManifest(w io.Writer, entries []ManifestEntry, now time.Time) error {
+	var total int64
+	for _, entry := range entries {
+		total += entry.Size
+	}
+
+	buf := bufio.NewWriter(w)
+	fmt.Fprintf(buf, "# SpaceForce deletion manifest, %s\n", now.Format(time.RFC3339))
+	fmt.Fprintf(buf, "# %d item(s), %s\n", len(entries), FormatBytesPlain(total))
+	for _, entry := range entries {
+		fmt.Fprintf(buf, "%d\t%s\t%s\n", entry.Size, FormatBytesPlain(entry.Size), entry.Path)
+	}
+	return buf.Flush()
+}
+
+// SaveManifest writes a manifest to path and syncs it to disk, so it survives even if
+// whatever runs next (a deletion) is interrupted
+func SaveManifest(path string, entries []ManifestEntry, now time.Time) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := WriteManifest(file, entries, now); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// manifestLine matches a WriteManifest line, capturing the path
+var manifestLine = regexp.MustCompile(`^\d+\t[^\t]*\t(.+)$`)
+
+// ReadPathList reads the paths in a manifest or an exported path list
+// Accepted are WriteManifest output, WritePathList output (shell-quoted lines or
+// NUL-delimited) and plain one-path-per-line text. Blank lines and "#" comments are skipped
+func ReadPathList(r io.Reader) ([]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	if bytes.IndexByte(data, 0) >= 0 {
+		paths := make([]string, 0)
+		for _, path := range strings.Split(string(data), "\x00") {
+			if path != "" {
+				paths = append(paths, path)
+			}
+		}
+		return paths, nil
+	}
+
+	paths := make([]string, 0)
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSuffix(line, "\r")
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if match := manifestLine.FindStringSubmatch(line); match != nil {
+			line = match[1]
+		}
+		paths = append(paths, shellUnquote(line))
+	}
+	return paths, nil
+}
+
+// shellUnquote reverses ShellQuote; anything not wrapped in single quotes is returned as is
+func shellUnquote(s string) string {
+	if len(s) < 2 || s[0] != '\'' || s[len(s)-1] != '\'' {
+		return s
+	}
+	return strings.ReplaceAll(s[1:len(s)-1], `'\''`, "'")
+}
+
+// ReadPathListFile reads a path list from a file (see ReadPathList)
+func ReadPathListFile(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return ReadPathList(file)
+}
@@ -0,0 +1,11 @@
+package util
+
+import "os/exec"
+
+// openCommand opens a file with its default application, or reveals a directory in the Finder
+func openCommand(path string, isDir bool) *exec.Cmd {
+	if isDir {
+		return exec.Command("open", "-R", path)
+	}
+	return exec.Command("open", path)
+}
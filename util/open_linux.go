@@ -0,0 +1,8 @@
+package util
+
+import "os/exec"
+
+// openCommand opens a file with its default application, or a directory in the file manager
+func openCommand(path string, isDir bool) *exec.Cmd {
+	return exec.Command("xdg-open", path)
+}
@@ -0,0 +1,59 @@
+package util
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// CdCommand returns a shell command that changes into dir
+func CdCommand(dir string) string {
+	return "cd " + ShellQuote(dir)
+}
+
+// clipboardCommands are tried in order: macOS, then Wayland and X11 on Linux
+var clipboardCommands = [][]string{
+	{"pbcopy"},
+	{"wl-copy"},
+	{"xclip", "-selection", "clipboard"},
+	{"xsel", "--clipboard", "--input"},
+}
+
+// CopyToClipboard puts text on the clipboard via the first available clipboard tool
+func CopyToClipboard(text string) error {
+	for _, args := range clipboardCommands {
+		if _, err := exec.LookPath(args[0]); err != nil {
+			continue
+		}
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		return cmd.Run()
+	}
+	return fmt.Errorf("no clipboard tool found (pbcopy, wl-copy, xclip or xsel)")
+}
+
+// WriteShellCommand writes a single command to a file a shell function can source
+func WriteShellCommand(path string, command string) error {
+	return os.WriteFile(path, []byte(command+"\n"), 0600)
+}
+
+// OpenPath opens a file with its default application, or shows a directory in the file manager
+func OpenPath(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%s no longer exists", path)
+		}
+		return err
+	}
+
+	output, err := openCommand(path, info.IsDir()).CombinedOutput()
+	if err != nil {
+		if message := strings.TrimSpace(string(output)); message != "" {
+			return fmt.Errorf("%s", message)
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package util
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCdCommandQuoting(t *testing.T) {
+	tests := []struct {
+		dir  string
+		want string
+	}{
+		{"/tmp/plain", `cd '/tmp/plain'`},
+		{"/tmp/with space", `cd '/tmp/with space'`},
+		{"/tmp/it's here", `cd '/tmp/it'\''s here'`},
+		{"/tmp/$HOME", `cd '/tmp/$HOME'`},
+	}
+	for _, tt := range tests {
+		if got := CdCommand(tt.dir); got != tt.want {
+			t.Errorf("CdCommand(%q) = %s, want %s", tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestCdCommandRunsInShell(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no sh to run the command in")
+	}
+	base, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"with space", "it's quoted", "$HOME `date`", "line\nbreak"} {
+		dir := filepath.Join(base, name)
+		if err := os.Mkdir(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		out, err := exec.Command("sh", "-c", CdCommand(dir)+" && pwd").Output()
+		if err != nil {
+			t.Fatalf("%s failed: %v", CdCommand(dir), err)
+		}
+		if got := strings.TrimSuffix(string(out), "\n"); got != dir {
+			t.Errorf("%s ended up in %q", CdCommand(dir), got)
+		}
+	}
+}